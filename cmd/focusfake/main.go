@@ -0,0 +1,32 @@
+// Command focusfake sobe a Focus NFe falsa (internal/focus/focustest) para desenvolvimento offline.
+//
+//	FOCUS_FAKE_ADDR=:8090 go run ./cmd/focusfake
+//	FOCUS_URL=http://localhost:8090 FOCUS_API_TOKEN=qualquer make run
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/seuuser/focus-integration-service/internal/focus/focustest"
+)
+
+func main() {
+	addr := strings.TrimSpace(os.Getenv("FOCUS_FAKE_ADDR"))
+	if addr == "" {
+		addr = ":8090"
+	}
+
+	fake := focustest.NewHandler()
+	if v := strings.TrimSpace(os.Getenv("FOCUS_FAKE_RATE_LIMIT")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			fake.RateLimit = n
+		}
+	}
+
+	log.Printf("Focus fake listening on %s …", addr)
+	log.Fatal(http.ListenAndServe(addr, fake))
+}
@@ -0,0 +1,35 @@
+-- ============================================================
+-- focus_certificate_alerts: avisos de vencimento do certificado digital (A1)
+--
+-- Context:
+-- - The certificate monitor (internal/certmonitor) reads the certificate dates via
+--   rpc_service_list_certificate_dates and writes one row per company, certificate
+--   (expiration_date) and window (FOCUS_CERT_ALERT_WINDOWS, default 30/15/7/1 days)
+-- - The unique key makes each window notify only once per certificate
+-- - notified_at / notify_attempts / notify_error: result of the notifiers (e-mail, webhook)
+-- - resolved_at: set when the company gets a new certificate (alerts of the old one stop
+--   showing up in vw_company_warnings)
+-- ============================================================
+
+create table if not exists focus_certificate_alerts (
+  id uuid primary key default gen_random_uuid(),
+  company_id uuid not null references companies(id) on delete cascade,
+  expiration_date timestamptz not null,
+  window_days integer not null,
+  days_left integer not null,
+  notified_at timestamptz null,
+  notify_attempts integer not null default 0,
+  notify_error text null,
+  resolved_at timestamptz null,
+  created_at timestamptz not null default now(),
+
+  constraint focus_certificate_alerts_key unique (company_id, expiration_date, window_days)
+);
+
+create index if not exists idx_focus_certificate_alerts_open
+  on focus_certificate_alerts (company_id, expiration_date)
+  where resolved_at is null;
+
+alter table focus_certificate_alerts enable row level security;
+
+comment on table focus_certificate_alerts is 'Avisos de vencimento do certificado digital por empresa e janela (30/15/7/1 dias)';
@@ -0,0 +1,30 @@
+-- ============================================================
+-- focus_document_events: linha do tempo dos documentos fiscais
+--
+-- Context:
+-- - One row per operation on a document (emissao, cancelamento, carta_correcao,
+--   inutilizacao, ...) and per status change observed on consulta
+-- - Identified by (company_id, document_type, ref), same key as focus_documents;
+--   inutilização has no ref, so it is recorded with ref = 'inutilizacao-SERIE-INICIAL-FINAL'
+-- - status_anterior/status: Focus status before/after the event
+-- ============================================================
+
+create table if not exists focus_document_events (
+  id uuid primary key default gen_random_uuid(),
+  company_id uuid not null references companies(id) on delete cascade,
+  document_type text not null,
+  ref text not null,
+  event text not null,
+  status_anterior text null,
+  status text null,
+  mensagem text null,
+  payload jsonb null,
+  created_at timestamptz not null default now()
+);
+
+create index if not exists idx_focus_document_events_doc
+  on focus_document_events (company_id, document_type, ref, created_at);
+
+alter table focus_document_events enable row level security;
+
+comment on table focus_document_events is 'Eventos e mudanças de status dos documentos fiscais emitidos via Focus';
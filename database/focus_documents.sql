@@ -0,0 +1,43 @@
+-- ============================================================
+-- focus_documents: documentos fiscais emitidos via Focus NFe
+--
+-- Context:
+-- - One row per document, keyed by (company_id, document_type, ref)
+--   `ref` is the reference chosen by us when emitting (Focus requires it to be
+--   unique per company token and document type)
+-- - document_type: nfse | nfsen | nfe | nfce | cte | mdfe
+-- - status mirrors the Focus status (processando_autorizacao, autorizado,
+--   cancelado, erro_autorizacao, ...); "erro" = request rejected by Focus
+-- - payload: request sent to Focus; response: last response received
+-- ============================================================
+
+create table if not exists focus_documents (
+  id uuid primary key default gen_random_uuid(),
+  company_id uuid not null references companies(id) on delete cascade,
+  document_type text not null,
+  ref text not null,
+  environment text not null default 'producao',
+  status text null,
+  numero text null,
+  serie text null,
+  chave text null,
+  codigo_verificacao text null,
+  mensagem text null,
+  caminho_xml text null,
+  caminho_pdf text null,
+  payload jsonb null,
+  response jsonb null,
+  created_at timestamptz not null default now(),
+  updated_at timestamptz not null default now(),
+
+  constraint focus_documents_company_type_ref_key unique (company_id, document_type, ref),
+  constraint focus_documents_document_type_check
+    check (document_type in ('nfse', 'nfsen', 'nfe', 'nfce', 'cte', 'mdfe'))
+);
+
+create index if not exists idx_focus_documents_company_type_created
+  on focus_documents (company_id, document_type, created_at desc);
+
+alter table focus_documents enable row level security;
+
+comment on table focus_documents is 'Documentos fiscais emitidos via Focus NFe e seu último status';
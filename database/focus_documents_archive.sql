@@ -0,0 +1,28 @@
+-- ============================================================
+-- focus_documents: arquivamento de XML/PDF no Supabase Storage
+--
+-- Context:
+-- - The archiver (internal/archive) downloads the XML and PDF of
+--   authorized/cancelled documents with the company token and uploads
+--   them to the Storage bucket FOCUS_ARCHIVE_BUCKET (default
+--   `focus-documents`), path:
+--   {company_id}/{document_type}/{environment}/{YYYY-MM}/{ref}-{xml|pdf}.{ext}
+-- - archived_at null = pending; archive_attempts counts failures
+--   (documents stop being retried after 5), archive_error keeps the last one
+-- ============================================================
+
+alter table focus_documents
+  add column if not exists xml_storage_path text null,
+  add column if not exists pdf_storage_path text null,
+  add column if not exists archived_at timestamptz null,
+  add column if not exists archive_attempts integer not null default 0,
+  add column if not exists archive_error text null;
+
+create index if not exists idx_focus_documents_pending_archive
+  on focus_documents (created_at)
+  where archived_at is null and caminho_xml is not null;
+
+-- private bucket (access through the service role / signed URLs)
+insert into storage.buckets (id, name, public)
+values ('focus-documents', 'focus-documents', false)
+on conflict (id) do nothing;
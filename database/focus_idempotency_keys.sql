@@ -0,0 +1,45 @@
+-- ============================================================
+-- focus_idempotency_keys: respostas de requisições com Idempotency-Key
+--
+-- Context:
+-- - POST /v2/empresas accepts an `Idempotency-Key` header; the first request
+--   claims the key (status = 'pending') and stores its final outcome
+--   (status = 'completed', response_status/body/headers)
+-- - Repeated submissions with the same key and payload get the stored
+--   response back; the same key with a different payload is rejected
+-- - Transient failures (5xx) release the key so the client can retry
+-- - Keys are scoped by company and expire after 24 hours (the service
+--   treats older rows as absent; the cleanup below can run periodically)
+-- - response_body never stores the company tokens (token_producao /
+--   token_homologacao); replays read the token back from focus_integration
+-- ============================================================
+
+create table if not exists focus_idempotency_keys (
+  id uuid primary key default gen_random_uuid(),
+  company_id uuid not null references companies(id) on delete cascade,
+  idempotency_key text not null,
+  operation text not null,
+  request_hash text not null,
+  status text not null default 'pending',
+  response_status integer null,
+  response_body jsonb null,
+  response_headers jsonb null,
+  created_at timestamptz not null default now(),
+  completed_at timestamptz null,
+
+  constraint focus_idempotency_keys_company_key unique (company_id, idempotency_key),
+  constraint focus_idempotency_keys_status_check check (status in ('pending', 'completed'))
+);
+
+alter table focus_idempotency_keys enable row level security;
+
+comment on table focus_idempotency_keys is 'Resultado de requisições com Idempotency-Key (ex: POST /v2/empresas)';
+
+-- remove tokens gravados antes da redação
+update focus_idempotency_keys
+  set response_body = response_body - 'token_producao' - 'token_homologacao'
+  where jsonb_typeof(response_body) = 'object'
+    and response_body ?| array['token_producao', 'token_homologacao'];
+
+-- cleanup (opcional, ex: pg_cron diário)
+-- delete from focus_idempotency_keys where created_at < now() - interval '24 hours';
@@ -0,0 +1,22 @@
+-- ============================================================
+-- focus_integration.environment
+--
+-- Context:
+-- - The service can talk to both Focus environments (produção / homologação)
+-- - Each integration must remember where the company was created, so later
+--   calls using the company token hit the same environment
+--
+-- Values: 'producao' (default, existing rows) | 'homologacao'
+-- ============================================================
+
+alter table focus_integration
+  add column if not exists environment text not null default 'producao';
+
+alter table focus_integration
+  drop constraint if exists focus_integration_environment_check;
+
+alter table focus_integration
+  add constraint focus_integration_environment_check
+  check (environment in ('producao', 'homologacao'));
+
+comment on column focus_integration.environment is 'Ambiente da Focus NFe em que a empresa foi integrada (producao | homologacao)';
@@ -0,0 +1,55 @@
+-- ============================================================
+-- focus_integration_outbox: gravações no Supabase pendentes após o cadastro na Focus
+--
+-- Context:
+-- - POST /v2/empresas grava aqui, logo após o sucesso na Focus, cada passo local
+--   (focus_integration, companies.focus_integrated, datas do certificado, limpeza
+--   de focus_integration_errors) e executa os passos na mesma requisição
+-- - passos que falham ficam pending e são refeitos pelo worker do serviço
+--   (FOCUS_OUTBOX_INTERVAL_MS), com backoff exponencial, até darem certo
+-- - os passos de uma operação são encadeados (depends_on): um passo só roda
+--   depois do anterior estar done
+-- - quem executa um passo o reserva antes (status running, lock_token,
+--   locked_until); uma reserva vencida (instância que caiu) volta para pending
+-- - os passos são idempotentes: executar duas vezes o mesmo passo não duplica dados
+-- - payload pode conter o token da empresa na Focus (o mesmo de focus_integration)
+-- ============================================================
+
+create table if not exists focus_integration_outbox (
+  id uuid primary key default gen_random_uuid(),
+  company_id uuid not null references companies(id) on delete cascade,
+  step text not null,
+  payload jsonb not null default '{}'::jsonb,
+  status text not null default 'pending',
+  depends_on uuid null references focus_integration_outbox(id) on delete set null,
+  lock_token text null,
+  locked_until timestamptz null,
+  attempts integer not null default 0,
+  last_error text null,
+  next_attempt_at timestamptz not null default now(),
+  created_at timestamptz not null default now(),
+  completed_at timestamptz null,
+
+  constraint focus_integration_outbox_step_check check (step in (
+    'insert_focus_integration',
+    'mark_focus_integrated',
+    'update_certificate_dates',
+    'delete_certificate_errors'
+  )),
+  constraint focus_integration_outbox_status_check check (status in ('pending', 'running', 'done'))
+);
+
+create index if not exists idx_focus_integration_outbox_due
+  on focus_integration_outbox (next_attempt_at)
+  where status = 'pending';
+
+create index if not exists idx_focus_integration_outbox_locked
+  on focus_integration_outbox (locked_until)
+  where status = 'running';
+
+create index if not exists idx_focus_integration_outbox_company
+  on focus_integration_outbox (company_id, created_at desc);
+
+alter table focus_integration_outbox enable row level security;
+
+comment on table focus_integration_outbox is 'Passos pendentes de gravação no Supabase após o cadastro de empresas na Focus NFe';
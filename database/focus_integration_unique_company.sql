@@ -0,0 +1,18 @@
+-- ============================================================
+-- focus_integration: uma integração por empresa
+--
+-- Context:
+-- - The service always reads the newest row of a company (token and environment)
+-- - Re-creating a company removed from Focus, or adopting it in the other
+--   environment, used to insert a second row; the service now upserts by
+--   company_id, replacing the previous integration
+-- - Existing duplicates are removed below, keeping the newest row per company
+-- ============================================================
+
+delete from focus_integration fi
+using focus_integration newer
+where newer.company_id = fi.company_id
+  and (newer.created_at, newer.id::text) > (fi.created_at, fi.id::text);
+
+create unique index if not exists idx_focus_integration_company
+  on focus_integration (company_id);
@@ -0,0 +1,37 @@
+-- ============================================================
+-- focus_request_logs: auditoria das chamadas HTTP à Focus NFe
+--
+-- Context:
+-- - Every outbound request made by focus-integration-service is recorded here
+--   (method, path, status, latency, request/response bodies)
+-- - Sensitive fields are masked by the service BEFORE insert:
+--   arquivo_certificado_base64, senha_certificado, senha_responsavel,
+--   token_producao, token_homologacao
+-- - company_id is null for lookups not tied to a company (CNPJ, municípios)
+-- ============================================================
+
+create table if not exists focus_request_logs (
+  id uuid primary key default gen_random_uuid(),
+  company_id uuid null references companies(id) on delete set null,
+  environment text not null default 'producao',
+  method text not null,
+  path text not null,
+  query text null,
+  status_code integer null,
+  latency_ms bigint not null default 0,
+  request_body jsonb null,
+  response_body jsonb null,
+  error text null,
+  created_at timestamptz not null default now()
+);
+
+create index if not exists idx_focus_request_logs_company_created
+  on focus_request_logs (company_id, created_at desc);
+
+create index if not exists idx_focus_request_logs_created
+  on focus_request_logs (created_at desc);
+
+-- Only the backend (service_role) reads/writes audit logs.
+alter table focus_request_logs enable row level security;
+
+comment on table focus_request_logs is 'Auditoria das requisições enviadas à Focus NFe (corpos com segredos mascarados)';
@@ -0,0 +1,36 @@
+-- ============================================================
+-- focus_webhook_events: notificações (webhooks) recebidas da Focus NFe
+--
+-- Context:
+-- - POST /webhooks/focus grava cada entrega aqui antes de atualizar focus_documents
+-- - delivery_hash = sha256(document_type + company_id + corpo): a Focus reenvia
+--   notificações que não receberam 2xx, e a chave única evita processar duas vezes
+-- - A entrega é gravada antes da resposta 2xx; processed_at/error: resultado da atualização
+--   assíncrona do documento. Linhas com processed_at nulo são retomadas pelo worker
+-- ============================================================
+
+create table if not exists focus_webhook_events (
+  id uuid primary key default gen_random_uuid(),
+  delivery_hash text not null,
+  company_id uuid null references companies(id) on delete cascade,
+  document_type text not null,
+  ref text null,
+  status text null,
+  payload jsonb not null,
+  received_at timestamptz not null default now(),
+  processed_at timestamptz null,
+  error text null,
+
+  constraint focus_webhook_events_delivery_hash_key unique (delivery_hash)
+);
+
+create index if not exists idx_focus_webhook_events_doc
+  on focus_webhook_events (company_id, document_type, ref);
+
+create index if not exists idx_focus_webhook_events_pending
+  on focus_webhook_events (received_at)
+  where processed_at is null;
+
+alter table focus_webhook_events enable row level security;
+
+comment on table focus_webhook_events is 'Webhooks recebidos da Focus NFe (deduplicados por delivery_hash)';
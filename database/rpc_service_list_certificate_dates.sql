@@ -0,0 +1,53 @@
+-- ============================================================
+-- RPC (service_role): list certificate dates of all companies
+--
+-- Context:
+-- - Certificate tables live in schema `company_private` (not exposed via PostgREST),
+--   same as rpc_service_update_certificate_dates_for_company
+-- - Used by the Focus reconciler (internal/reconcile) to compare the local
+--   expiration date with `certificado_valido_ate` returned by Focus
+--
+-- This RPC:
+-- - Returns one row per company: the most recent certificate linked to it
+-- - Is callable ONLY by role `service_role`
+-- ============================================================
+
+create or replace function public.rpc_service_list_certificate_dates()
+returns table (
+  company_id uuid,
+  effective_date timestamp,
+  expiration_date timestamp
+)
+language plpgsql
+security definer
+set search_path = company_private, company, public
+set row_security = off
+as $$
+declare
+  v_role text := coalesce(
+    auth.role(),
+    nullif(current_setting('request.jwt.claim.role', true), ''),
+    nullif((current_setting('request.jwt.claims', true))::jsonb->>'role', ''),
+    ''
+  );
+begin
+  if v_role not in ('service_role', 'supabase_admin') then
+    raise exception 'ACCESS_DENIED';
+  end if;
+
+  return query
+  select distinct on (cca.company_id)
+    cca.company_id,
+    ca.effective_date::timestamp,
+    ca.expiration_date::timestamp
+  from company_private.company_certificates_access cca
+  join company_private.certificates_access ca on ca.id = cca.certificate_access_id
+  order by cca.company_id, cca.created_at desc nulls last;
+end;
+$$;
+
+revoke all on function public.rpc_service_list_certificate_dates() from public;
+revoke all on function public.rpc_service_list_certificate_dates() from anon;
+revoke all on function public.rpc_service_list_certificate_dates() from authenticated;
+grant execute on function public.rpc_service_list_certificate_dates() to service_role;
+grant execute on function public.rpc_service_list_certificate_dates() to supabase_admin;
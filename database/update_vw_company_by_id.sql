@@ -39,6 +39,7 @@ SELECT
         'id', fi.id,
         'focus_company_id', fi.focus_company_id,
         'token_focus_company', fi.token_focus_company,
+        'environment', fi.environment,
         'created_at', fi.created_at
       )
     ELSE NULL
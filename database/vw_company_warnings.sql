@@ -30,6 +30,19 @@ company_certs AS (
   GROUP BY cca.company_id
 ),
 
+-- Avisos de vencimento do certificado gravados pelo monitor (internal/certmonitor):
+-- menor janela ainda aberta do certificado atual de cada empresa
+cert_alerts AS (
+  SELECT DISTINCT ON (fca.company_id)
+    fca.company_id,
+    fca.expiration_date,
+    fca.window_days
+  FROM focus_certificate_alerts fca
+  WHERE fca.resolved_at IS NULL
+    AND fca.expiration_date >= CURRENT_DATE
+  ORDER BY fca.company_id, fca.expiration_date DESC, fca.window_days ASC
+),
+
 -- Documentos fiscais habilitados por empresa
 company_fiscal_docs AS (
   SELECT 
@@ -297,7 +310,24 @@ warnings AS (
           'action_route', '/companies/' || c.id || '/info'
         )
       ELSE NULL
-    END AS warning_incomplete_address
+    END AS warning_incomplete_address,
+
+    -- Warning 15: Certificado expirando (monitor de certificados), quando as tabelas de
+    -- certificados acima não enxergam o certificado (ex: certificados em company_private)
+    CASE
+      WHEN cal.company_id IS NOT NULL AND cc.latest_expiration_date IS NULL THEN
+        jsonb_build_object(
+          'id', 'certificate_expiration_alert',
+          'severity', CASE WHEN cal.window_days <= 7 THEN 'error' ELSE 'warn' END,
+          'title', 'Certificado Expirando em ' || GREATEST((cal.expiration_date::date - CURRENT_DATE), 0) || ' Dias',
+          'message', 'O certificado digital irá vencer em ' || TO_CHAR(cal.expiration_date, 'DD/MM/YYYY') || '. Providencie a renovação' ||
+                     CASE WHEN cal.window_days <= 7 THEN ' com urgência.' ELSE ' em breve.' END,
+          'icon', 'pi pi-calendar-times',
+          'action_label', 'Renovar Certificado',
+          'action_route', '/companies/' || c.id || '/info'
+        )
+      ELSE NULL
+    END AS warning_cert_expiration_alert
     
   FROM public.companies c
   LEFT JOIN focus_errors fe ON fe.company_id = c.id
@@ -305,6 +335,7 @@ warnings AS (
   LEFT JOIN company_fiscal_docs cfd ON cfd.company_id = c.id
   LEFT JOIN company_cnaes cn ON cn.company_id = c.id
   LEFT JOIN company_addresses ca ON ca.company_id = c.id
+  LEFT JOIN cert_alerts cal ON cal.company_id = c.id
   WHERE c.status = 'ACTIVE'
 )
 
@@ -326,7 +357,8 @@ SELECT
     warning_nfse_requirements,
     warning_no_municipal_reg,
     warning_no_address,
-    warning_incomplete_address
+    warning_incomplete_address,
+    warning_cert_expiration_alert
   ], NULL) AS warnings
 FROM warnings;
 
@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/health": {
             "get": {
-                "description": "Check the health of the service",
+                "description": "Check the health of the service. Status \"degraded\" indica circuit breaker da Focus aberto.",
                 "tags": [
                     "status"
                 ],
@@ -32,30 +32,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/cnpjs/{cnpj}": {
+        "/v2/ceps/{cep}": {
             "get": {
-                "description": "Proxy para Focus: GET /v2/cnpjs/{cnpj}. Informe 14 dígitos (somente números).",
+                "description": "Proxy para Focus: GET /v2/ceps/{cep}. Informe 8 dígitos (somente números).\nRespostas encontradas ficam em cache por alguns minutos; use para preencher logradouro, bairro, município e UF do cadastro da empresa.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "CNPJs"
+                    "CEPs"
                 ],
-                "summary": "Consulta cadastro de CNPJ",
+                "summary": "Consulta endereço por CEP",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "CNPJ (14 dígitos, somente números)",
-                        "name": "cnpj",
+                        "description": "CEP (8 dígitos, somente números)",
+                        "name": "cep",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.FocusCnpjResponse"
+                            "$ref": "#/definitions/model.FocusCepResponse"
                         }
                     },
                     "400": {
@@ -87,31 +93,37 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
                     }
                 }
             }
         },
-        "/v2/empresas": {
+        "/v2/cfops": {
             "get": {
-                "description": "Proxy para Focus: GET /v2/empresas (suporta cnpj, cpf, offset)",
+                "description": "Proxy para Focus: GET /v2/cfops. Suporta filtros via querystring (codigo, descricao, offset).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Empresas"
+                    "CFOPs"
                 ],
-                "summary": "Lista empresas na Focus",
+                "summary": "Lista CFOPs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "CNPJ (somente números)",
-                        "name": "cnpj",
+                        "description": "Trecho inicial do código (ex: 51)",
+                        "name": "codigo",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "CPF (somente números)",
-                        "name": "cpf",
+                        "description": "Trecho da descrição",
+                        "name": "descricao",
                         "in": "query"
                     },
                     {
@@ -119,6 +131,12 @@ const docTemplate = `{
                         "description": "Paginação (offset)",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -127,7 +145,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.FocusEmpresaResponse"
+                                "$ref": "#/definitions/model.FocusCfopResponse"
                             }
                         }
                     },
@@ -148,44 +166,46 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
                     }
                 }
-            },
-            "post": {
-                "description": "Proxy para Focus: POST /v2/empresas",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/v2/cfops/{codigo}": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/cfops/{codigo}. Informe 4 dígitos (somente números).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Empresas"
+                    "CFOPs"
                 ],
-                "summary": "Cria uma nova empresa na Focus",
+                "summary": "Busca CFOP por código",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID da empresa (companies.id)",
-                        "name": "company_id",
-                        "in": "query",
+                        "description": "CFOP (4 dígitos, somente números)",
+                        "name": "codigo",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Dados da empresa",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.FocusEmpresaCreateRequest"
-                        }
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.FocusEmpresaResponse"
+                            "$ref": "#/definitions/model.FocusCfopResponse"
                         }
                     },
                     "400": {
@@ -200,6 +220,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.RawPayload"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -211,34 +237,52 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
                     }
                 }
             }
         },
-        "/v2/empresas/{id}": {
+        "/v2/cnpjs/{cnpj}": {
             "get": {
-                "description": "Proxy para Focus: GET /v2/empresas/{id}",
+                "description": "Proxy para Focus: GET /v2/cnpjs/{cnpj}. Informe 14 dígitos (somente números).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Empresas"
+                    "CNPJs"
                 ],
-                "summary": "Consulta uma empresa por ID na Focus",
+                "summary": "Consulta cadastro de CNPJ",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID da empresa na Focus",
-                        "name": "id",
+                        "description": "CNPJ (14 dígitos, somente números)",
+                        "name": "cnpj",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.FocusEmpresaResponse"
+                            "$ref": "#/definitions/model.FocusCnpjResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
                         }
                     },
                     "401": {
@@ -264,62 +308,46 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Proxy para Focus: PUT /v2/empresas/{id}. Apenas os campos enviados serão atualizados. Se atualizar certificado com sucesso, erros antigos serão removidos.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/v2/companies/{company_id}/backups": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/backups/{cnpj}.json com o token da empresa. O CNPJ é lido de companies (Supabase).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Empresas"
+                    "Backups"
                 ],
-                "summary": "Altera uma empresa específica na Focus",
+                "summary": "Lista backups mensais de XML da empresa",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID da empresa na Focus",
-                        "name": "id",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "ID da empresa no Supabase (para limpeza de erros)",
-                        "name": "company_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "ID do certificado no Supabase (para limpeza de erros)",
-                        "name": "certificate_id",
-                        "in": "query"
-                    },
-                    {
-                        "description": "Dados para atualização (campos opcionais)",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.FocusEmpresaUpdateRequest"
-                        }
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.FocusEmpresaResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RawPayload"
+                            "$ref": "#/definitions/model.FocusBackupsResponse"
                         }
                     },
                     "401": {
@@ -334,6 +362,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.RawPayload"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -345,32 +385,59 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Proxy para Focus: DELETE /v2/empresas/{id}",
+            }
+        },
+        "/v2/companies/{company_id}/backups/{mes}": {
+            "post": {
+                "description": "Baixa da Focus os arquivos (zip de XMLs e de DANFEs) do mês informado e grava no bucket de arquivamento (FOCUS_ARCHIVE_BUCKET),\nem {company_id}/backups/{ambiente}/{mes}/. Devolve os caminhos e URLs assinadas válidas por 1 hora.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Empresas"
+                    "Backups"
                 ],
-                "summary": "Exclui uma empresa na Focus",
+                "summary": "Arquiva backup mensal de XML no Supabase Storage",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID da empresa na Focus",
-                        "name": "id",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Mês do backup (AAAA-MM)",
+                        "name": "mes",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.FocusEmpresaResponse"
+                            "$ref": "#/definitions/model.CompanyBackupArchiveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
                         }
                     },
                     "401": {
@@ -385,6 +452,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.RawPayload"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -396,50 +475,45 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
                     }
                 }
             }
         },
-        "/v2/municipios": {
+        "/v2/companies/{company_id}/hooks": {
             "get": {
-                "description": "Proxy para Focus: GET /v2/municipios. Suporta filtros via querystring (sigla_uf, nome_municipio, nome, status_nfse, offset).",
+                "description": "Proxy para Focus: GET /v2/hooks, filtrado pelo CNPJ da empresa.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Municípios"
+                    "Webhooks"
                 ],
-                "summary": "Lista municípios (IBGE)",
+                "summary": "Lista gatilhos (webhooks) da Focus da empresa",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sigla UF (ex: PR)",
-                        "name": "sigla_uf",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Nome exato do município (ex: Curitiba)",
-                        "name": "nome_municipio",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Trecho do nome do município",
-                        "name": "nome",
-                        "in": "query"
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Status NFSe (ativo, fora_do_ar, pausado, em_implementacao, em_reimplementacao, inativo, nao_implementado)",
-                        "name": "status_nfse",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Paginação (offset)",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -448,7 +522,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.FocusMunicipioResponse"
+                                "$ref": "#/definitions/model.FocusHookResponse"
                             }
                         }
                     },
@@ -458,6 +532,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.RawPayload"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -469,34 +555,62 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
                     }
                 }
-            }
-        },
-        "/v2/municipios/{codigo_municipio}": {
-            "get": {
-                "description": "Proxy para Focus: GET /v2/municipios/{codigo_municipio}.",
+            },
+            "post": {
+                "description": "Proxy para Focus: POST /v2/hooks com o CNPJ da empresa, apontando para o nosso /webhooks/focus (FOCUS_WEBHOOK_URL).\nIdempotente: se o gatilho do evento já existe, ele é devolvido com 200.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Municípios"
+                    "Webhooks"
                 ],
-                "summary": "Busca município por código IBGE",
+                "summary": "Cadastra gatilho (webhook) da Focus para a empresa",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Código do município (IBGE)",
-                        "name": "codigo_municipio",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
+                    },
+                    {
+                        "description": "Evento (tipo de documento)",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CompanyHookRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.FocusMunicipioResponse"
+                            "$ref": "#/definitions/model.FocusHookResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.FocusHookResponse"
                         }
                     },
                     "400": {
@@ -511,8 +625,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.RawPayload"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
@@ -528,61 +648,53 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
                     }
                 }
             }
         },
-        "/v2/municipios/{codigo_municipio}/codigos_tributarios_municipio": {
-            "get": {
-                "description": "Proxy para Focus: GET /v2/municipios/{codigo_municipio}/codigos_tributarios_municipio. Suporta filtros via querystring (codigo, descricao, offset).",
+        "/v2/companies/{company_id}/hooks/{hook_id}": {
+            "delete": {
+                "description": "Proxy para Focus: DELETE /v2/hooks/{hook_id}. O gatilho precisa pertencer ao CNPJ da empresa.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Municípios - Códigos Tributários"
+                    "Webhooks"
                 ],
-                "summary": "Lista códigos tributários municipais por município",
+                "summary": "Remove gatilho (webhook) da Focus da empresa",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Código do município (IBGE)",
-                        "name": "codigo_municipio",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Trecho do código (ex: 14.)",
-                        "name": "codigo",
-                        "in": "query"
+                        "description": "ID do gatilho na Focus",
+                        "name": "hook_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Trecho da descrição",
-                        "name": "descricao",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Paginação (offset)",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handler.RawPayload"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RawPayload"
+                            "$ref": "#/definitions/model.FocusHookResponse"
                         }
                     },
                     "401": {
@@ -597,6 +709,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.RawPayload"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -608,41 +732,65 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
                     }
                 }
             }
         },
-        "/v2/municipios/{codigo_municipio}/codigos_tributarios_municipio/{codigo}": {
-            "get": {
-                "description": "Proxy para Focus: GET /v2/municipios/{codigo_municipio}/codigos_tributarios_municipio/{codigo}.",
+        "/v2/cte": {
+            "post": {
+                "description": "Proxy para Focus: POST /v2/cte?ref=REF usando o token da empresa (focus_integration).\nA empresa precisa estar com habilita_cte na Focus. A emissão e o status são registrados em focus_documents.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Municípios - Códigos Tributários"
+                    "CT-e"
                 ],
-                "summary": "Busca código tributário municipal por código (município)",
+                "summary": "Emite CT-e",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Código do município (IBGE)",
-                        "name": "codigo_municipio",
-                        "in": "path",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Código tributário municipal",
-                        "name": "codigo",
-                        "in": "path",
+                        "description": "Referência única do documento (letras, números, '-', '_' e '.')",
+                        "name": "ref",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Payload do CT-e (campos adicionais da Focus são repassados)",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CTeRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/handler.RawPayload"
+                            "$ref": "#/definitions/model.CTeResponse"
                         }
                     },
                     "400": {
@@ -657,8 +805,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.RawPayload"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
@@ -674,55 +828,53 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
                     }
                 }
             }
         },
-        "/v2/municipios/{codigo_municipio}/itens_lista_servico": {
+        "/v2/cte/{ref}": {
             "get": {
-                "description": "Proxy para Focus: GET /v2/municipios/{codigo_municipio}/itens_lista_servico. Suporta filtros via querystring (codigo, descricao, offset).",
+                "description": "Proxy para Focus: GET /v2/cte/{ref} usando o token da empresa. Atualiza o status em focus_documents.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Municípios - Itens Lista de Serviço"
+                    "CT-e"
                 ],
-                "summary": "Lista itens da lista de serviço por município",
+                "summary": "Consulta CT-e",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Código do município (IBGE)",
-                        "name": "codigo_municipio",
+                        "description": "Referência do documento",
+                        "name": "ref",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Trecho do código (ex: 14.)",
-                        "name": "codigo",
-                        "in": "query"
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Trecho da descrição",
-                        "name": "descricao",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Paginação (offset)",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handler.RawPayload"
-                            }
+                            "$ref": "#/definitions/model.CTeResponse"
                         }
                     },
                     "400": {
@@ -743,6 +895,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.RawPayload"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -754,41 +918,63 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
                     }
                 }
-            }
-        },
-        "/v2/municipios/{codigo_municipio}/itens_lista_servico/{codigo}": {
-            "get": {
-                "description": "Proxy para Focus: GET /v2/municipios/{codigo_municipio}/itens_lista_servico/{codigo}.",
+            },
+            "delete": {
+                "description": "Proxy para Focus: DELETE /v2/cte/{ref} usando o token da empresa. A justificativa deve ter de 15 a 255 caracteres.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Municípios - Itens Lista de Serviço"
+                    "CT-e"
                 ],
-                "summary": "Busca item da lista de serviço por código (município)",
+                "summary": "Cancela CT-e",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Código do município (IBGE)",
-                        "name": "codigo_municipio",
+                        "description": "Referência do documento",
+                        "name": "ref",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Código do item (ex: 14.01)",
-                        "name": "codigo",
-                        "in": "path",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Justificativa do cancelamento",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NFeCancelRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RawPayload"
+                            "$ref": "#/definitions/model.CTeResponse"
                         }
                     },
                     "400": {
@@ -809,6 +995,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.RawPayload"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -820,548 +1018,5662 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.RawPayload"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handler.HealthResponse": {
-            "type": "object",
-            "properties": {
-                "status": {
-                    "type": "string"
-                },
-                "time": {
-                    "type": "string"
-                }
-            }
-        },
-        "handler.RawPayload": {
-            "type": "object",
-            "additionalProperties": {}
-        },
-        "model.FocusCnpjEndereco": {
-            "type": "object",
-            "properties": {
-                "bairro": {
-                    "type": "string"
-                },
-                "cep": {
-                    "type": "string"
-                },
-                "codigo_ibge": {
-                    "type": "string"
-                },
-                "codigo_municipio": {
-                    "type": "string"
-                },
-                "codigo_siafi": {
-                    "type": "string"
-                },
-                "complemento": {
-                    "type": "string"
-                },
-                "logradouro": {
-                    "type": "string"
-                },
-                "nome_municipio": {
-                    "type": "string"
-                },
-                "numero": {
-                    "type": "string"
-                },
-                "uf": {
-                    "type": "string"
-                }
-            }
         },
-        "model.FocusCnpjResponse": {
-            "type": "object",
-            "properties": {
-                "cnae_principal": {
-                    "type": "string"
-                },
+        "/v2/empresas": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/empresas (suporta cnpj, cpf, offset)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Empresas"
+                ],
+                "summary": "Lista empresas na Focus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "CNPJ (somente números)",
+                        "name": "cnpj",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CPF (somente números)",
+                        "name": "cpf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Paginação (offset)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.FocusEmpresaResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Proxy para Focus: POST /v2/empresas\nCom FOCUS_WEBHOOK_URL configurada, cadastra também os gatilhos (FOCUS_WEBHOOK_EVENTS) apontando para /webhooks/focus.\nSe a empresa já está integrada (focus_integration) ou o CNPJ/CPF já existe na Focus, a empresa existente é atualizada\n(PUT) em vez de criada de novo: a resposta é 200 e X-Integration-Status = adopted (created no cadastro novo).\nSe a verificação não puder ser feita (Supabase ou Focus indisponível), responde 503 sem cadastrar.\nSe, depois do cadastro, nem a integração nem o outbox puderem ser gravados no Supabase, responde 503: repetir adota a empresa.\nCom o header Idempotency-Key, repetições com o mesmo payload devolvem a resposta original (Idempotent-Replayed: true).\nO certificado (arquivo_certificado_base64 + senha_certificado) é aberto localmente antes do envio: senha incorreta,\ncertificado fora da validade, sem indícios de ICP-Brasil ou de outro CNPJ (raiz) respondem 422 com erros por campo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Empresas"
+                ],
+                "summary": "Cria uma nova empresa na Focus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados da empresa",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FocusEmpresaCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave única da submissão (até 255 caracteres), válida por 24h",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FocusEmpresaResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.FocusEmpresaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/empresas/export": {
+            "get": {
+                "description": "Percorre GET /v2/empresas (offset) até X-Total-Count e devolve a lista completa, em streaming.\nformat=json (padrão) devolve um array JSON; format=ndjson devolve uma empresa por linha.",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Empresas"
+                ],
+                "summary": "Exporta todas as empresas da conta na Focus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "json (padrão) ou ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CNPJ (somente números)",
+                        "name": "cnpj",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CPF (somente números)",
+                        "name": "cpf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.FocusEmpresaResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/empresas/{id}": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/empresas/{id}",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Empresas"
+                ],
+                "summary": "Consulta uma empresa por ID na Focus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da empresa na Focus",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FocusEmpresaResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Proxy para Focus: PUT /v2/empresas/{id}. Apenas os campos enviados serão atualizados. Se atualizar certificado com sucesso, erros antigos serão removidos.\nUm novo certificado é validado localmente (senha, validade, ICP-Brasil, CNPJ) antes do envio; problemas respondem 422 com erros por campo\ne, com company_id, são registrados em focus_integration_errors.\nSe as datas do novo certificado não puderem ser gravadas no Supabase (nem no outbox), responde 503.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Empresas"
+                ],
+                "summary": "Altera uma empresa específica na Focus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da empresa na Focus",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa no Supabase (para limpeza de erros)",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do certificado no Supabase (para limpeza de erros)",
+                        "name": "certificate_id",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Dados para atualização (campos opcionais)",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FocusEmpresaUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FocusEmpresaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Proxy para Focus: DELETE /v2/empresas/{id}",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Empresas"
+                ],
+                "summary": "Exclui uma empresa na Focus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da empresa na Focus",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FocusEmpresaResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/mdfe": {
+            "post": {
+                "description": "Proxy para Focus: POST /v2/mdfe?ref=REF usando o token da empresa (focus_integration).\nA empresa precisa estar com habilita_mdfe na Focus. A emissão e o status são registrados em focus_documents.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MDF-e"
+                ],
+                "summary": "Emite MDF-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Referência única do documento (letras, números, '-', '_' e '.')",
+                        "name": "ref",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Payload do MDF-e (campos adicionais da Focus são repassados)",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MDFeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.MDFeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/mdfe/{ref}": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/mdfe/{ref} usando o token da empresa. Atualiza o status em focus_documents.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MDF-e"
+                ],
+                "summary": "Consulta MDF-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência do documento",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MDFeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Proxy para Focus: DELETE /v2/mdfe/{ref} usando o token da empresa. A justificativa deve ter de 15 a 255 caracteres.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MDF-e"
+                ],
+                "summary": "Cancela MDF-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência do documento",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Justificativa do cancelamento",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NFeCancelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MDFeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/mdfe/{ref}/encerrar": {
+            "post": {
+                "description": "Proxy para Focus: POST /v2/mdfe/{ref}/encerrar usando o token da empresa. Informe a data e o município de encerramento.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MDF-e"
+                ],
+                "summary": "Encerra MDF-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência do documento",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dados do encerramento",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MDFeEncerramentoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MDFeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/mdfe/{ref}/inclusao_condutor": {
+            "post": {
+                "description": "Proxy para Focus: POST /v2/mdfe/{ref}/inclusao_condutor usando o token da empresa (MDF-e autorizado e não encerrado).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MDF-e"
+                ],
+                "summary": "Inclui condutor no MDF-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência do documento",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Condutor",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MDFeCondutor"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MDFeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/municipios": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/municipios. Suporta filtros via querystring (sigla_uf, nome_municipio, nome, status_nfse, offset).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Municípios"
+                ],
+                "summary": "Lista municípios (IBGE)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sigla UF (ex: PR)",
+                        "name": "sigla_uf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nome exato do município (ex: Curitiba)",
+                        "name": "nome_municipio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trecho do nome do município",
+                        "name": "nome",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status NFSe (ativo, fora_do_ar, pausado, em_implementacao, em_reimplementacao, inativo, nao_implementado)",
+                        "name": "status_nfse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Paginação (offset)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.FocusMunicipioResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/municipios/{codigo_municipio}": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/municipios/{codigo_municipio}.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Municípios"
+                ],
+                "summary": "Busca município por código IBGE",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código do município (IBGE)",
+                        "name": "codigo_municipio",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FocusMunicipioResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/municipios/{codigo_municipio}/codigos_tributarios_municipio": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/municipios/{codigo_municipio}/codigos_tributarios_municipio. Suporta filtros via querystring (codigo, descricao, offset).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Municípios - Códigos Tributários"
+                ],
+                "summary": "Lista códigos tributários municipais por município",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código do município (IBGE)",
+                        "name": "codigo_municipio",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trecho do código (ex: 14.)",
+                        "name": "codigo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trecho da descrição",
+                        "name": "descricao",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Paginação (offset)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.RawPayload"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/municipios/{codigo_municipio}/codigos_tributarios_municipio/{codigo}": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/municipios/{codigo_municipio}/codigos_tributarios_municipio/{codigo}.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Municípios - Códigos Tributários"
+                ],
+                "summary": "Busca código tributário municipal por código (município)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código do município (IBGE)",
+                        "name": "codigo_municipio",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Código tributário municipal",
+                        "name": "codigo",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/municipios/{codigo_municipio}/itens_lista_servico": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/municipios/{codigo_municipio}/itens_lista_servico. Suporta filtros via querystring (codigo, descricao, offset).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Municípios - Itens Lista de Serviço"
+                ],
+                "summary": "Lista itens da lista de serviço por município",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código do município (IBGE)",
+                        "name": "codigo_municipio",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trecho do código (ex: 14.)",
+                        "name": "codigo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trecho da descrição",
+                        "name": "descricao",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Paginação (offset)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.RawPayload"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/municipios/{codigo_municipio}/itens_lista_servico/{codigo}": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/municipios/{codigo_municipio}/itens_lista_servico/{codigo}.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Municípios - Itens Lista de Serviço"
+                ],
+                "summary": "Busca item da lista de serviço por código (município)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código do município (IBGE)",
+                        "name": "codigo_municipio",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Código do item (ex: 14.01)",
+                        "name": "codigo",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/ncms": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/ncms. Suporta filtros via querystring (codigo, descricao, capitulo, posicao, subposicao1, subposicao2, item1, item2, offset).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NCMs"
+                ],
+                "summary": "Lista NCMs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trecho inicial do código (ex: 8471)",
+                        "name": "codigo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trecho da descrição",
+                        "name": "descricao",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Capítulo (2 dígitos)",
+                        "name": "capitulo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posição (2 dígitos)",
+                        "name": "posicao",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subposição 1",
+                        "name": "subposicao1",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subposição 2",
+                        "name": "subposicao2",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item 1",
+                        "name": "item1",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item 2",
+                        "name": "item2",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Paginação (offset)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.FocusNcmResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/ncms/{codigo}": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/ncms/{codigo}. Informe 8 dígitos (somente números).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NCMs"
+                ],
+                "summary": "Busca NCM por código",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código NCM (8 dígitos, somente números)",
+                        "name": "codigo",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ambiente da Focus: producao (padrão) ou homologacao",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FocusNcmResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/nfce": {
+            "post": {
+                "description": "Proxy para Focus: POST /v2/nfce?ref=REF usando o token da empresa (focus_integration). A NFC-e é sempre síncrona.\nSe a SEFAZ estiver indisponível, a Focus emite em contingência offline (contingencia_offline=true) e transmite depois;\nacompanhe por GET /v2/nfce/{ref} até contingencia_offline_efetivada=true.\nRejeições são registradas em focus_integration_errors; a nota e seus eventos em focus_documents / focus_document_events.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFC-e"
+                ],
+                "summary": "Emite NFC-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Referência única da nota (letras, números, '-', '_' e '.')",
+                        "name": "ref",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Payload da NFC-e (campos adicionais da Focus são repassados)",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NFCeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFCeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/nfce/{ref}": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/nfce/{ref} usando o token da empresa. Atualiza o status em focus_documents\ne registra quando uma nota emitida em contingência offline é efetivada na SEFAZ.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFC-e"
+                ],
+                "summary": "Consulta NFC-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência da nota",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1 = inclui o conteúdo completo da nota",
+                        "name": "completa",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFCeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Proxy para Focus: DELETE /v2/nfce/{ref} usando o token da empresa. A justificativa deve ter de 15 a 255 caracteres.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFC-e"
+                ],
+                "summary": "Cancela NFC-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência da nota",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Justificativa do cancelamento",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NFeCancelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFCeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/nfe": {
+            "get": {
+                "description": "Lista as NF-e emitidas pela empresa (focus_documents), da mais recente para a mais antiga. O total vai em X-Total-Count.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NF-e"
+                ],
+                "summary": "Histórico de NF-e da empresa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtra por status (ex: autorizado, cancelado, processando_autorizacao, erro_autorizacao)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tamanho da página (padrão 50, máx 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Paginação (offset)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/supabase.FocusDocument"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Proxy para Focus: POST /v2/nfe?ref=REF usando o token da empresa (focus_integration).\nNo modo assíncrono (padrão) a Focus responde 202 com status processando_autorizacao: acompanhe por GET /v2/nfe/{ref}.\nA emissão e cada mudança de status são registradas em focus_documents / focus_document_events.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NF-e"
+                ],
+                "summary": "Emite NF-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Referência única da nota (letras, números, '-', '_' e '.')",
+                        "name": "ref",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Payload da NF-e (campos adicionais da Focus são repassados)",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NFeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFeResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/nfe/inutilizacao": {
+            "post": {
+                "description": "Proxy para Focus: POST /v2/nfe/inutilizacao usando o token da empresa.\nO resultado é registrado em focus_document_events com ref inutilizacao-SERIE-INICIAL-FINAL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NF-e"
+                ],
+                "summary": "Inutiliza faixa de numeração de NF-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Faixa a inutilizar",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NFeInutilizacaoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFeInutilizacaoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/nfe/{ref}": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/nfe/{ref} usando o token da empresa. Atualiza o status em focus_documents.\nCom aguardar=N (segundos, máx 60), consulta a Focus a cada 2s enquanto o status for processando_autorizacao.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NF-e"
+                ],
+                "summary": "Consulta NF-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência da nota",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1 = inclui o conteúdo completo da nota e dos eventos",
+                        "name": "completa",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Segundos para aguardar a autorização (máx 60)",
+                        "name": "aguardar",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Proxy para Focus: DELETE /v2/nfe/{ref} usando o token da empresa. A justificativa deve ter de 15 a 255 caracteres.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NF-e"
+                ],
+                "summary": "Cancela NF-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência da nota",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Justificativa do cancelamento",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NFeCancelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/nfe/{ref}/carta_correcao": {
+            "post": {
+                "description": "Proxy para Focus: POST /v2/nfe/{ref}/carta_correcao usando o token da empresa. A correção deve ter de 15 a 1000 caracteres.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NF-e"
+                ],
+                "summary": "Emite carta de correção (CC-e) da NF-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência da nota",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Texto da correção",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NFeCartaCorrecaoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/nfe/{ref}/eventos": {
+            "get": {
+                "description": "Lista as operações e mudanças de status registradas para a nota (focus_document_events), em ordem cronológica.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NF-e"
+                ],
+                "summary": "Linha do tempo da NF-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência da nota",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/supabase.FocusDocumentEvent"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/nfse": {
+            "post": {
+                "description": "Proxy para Focus: POST /v2/nfse?ref=REF usando o token da empresa (focus_integration).\nAntes do envio, o payload é validado contra os campos obrigatórios do município do prestador.\nA emissão e o status retornado são registrados em focus_documents.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFS-e"
+                ],
+                "summary": "Emite NFS-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Referência única da nota (letras, números, '-', '_' e '.')",
+                        "name": "ref",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Payload da NFS-e (campos adicionais da Focus são repassados)",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NFSeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFSeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/nfse/{ref}": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/nfse/{ref} usando o token da empresa. Atualiza o status em focus_documents.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFS-e"
+                ],
+                "summary": "Consulta NFS-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência da nota",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFSeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Proxy para Focus: DELETE /v2/nfse/{ref} usando o token da empresa. Atualiza o status em focus_documents.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFS-e"
+                ],
+                "summary": "Cancela NFS-e",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência da nota",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Justificativa do cancelamento",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NFSeCancelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFSeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/nfse/{ref}/email": {
+            "post": {
+                "description": "Proxy para Focus: POST /v2/nfse/{ref}/email usando o token da empresa.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFS-e"
+                ],
+                "summary": "Reenvia NFS-e por e-mail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência da nota",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Destinatários",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NFSeEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/nfsen": {
+            "post": {
+                "description": "Proxy para Focus: POST /v2/nfsen?ref=REF usando o token da empresa (focus_integration).\nEm produção, exige que a empresa esteja com habilita_nfsen_producao ativo na Focus.\nA emissão e o status retornado são registrados em focus_documents.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFS-e Nacional"
+                ],
+                "summary": "Emite NFS-e Nacional (DPS)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Referência única da nota (letras, números, '-', '_' e '.')",
+                        "name": "ref",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "DPS (campos adicionais da Focus são repassados)",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NFSeNRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFSeNResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/nfsen/{ref}": {
+            "get": {
+                "description": "Proxy para Focus: GET /v2/nfsen/{ref} usando o token da empresa. Atualiza o status em focus_documents.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFS-e Nacional"
+                ],
+                "summary": "Consulta NFS-e Nacional",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência da nota",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFSeNResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Proxy para Focus: DELETE /v2/nfsen/{ref} usando o token da empresa. Atualiza o status em focus_documents.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFS-e Nacional"
+                ],
+                "summary": "Cancela NFS-e Nacional",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referência da nota",
+                        "name": "ref",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id)",
+                        "name": "company_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409",
+                        "name": "X-Focus-Environment",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Justificativa do cancelamento",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NFSeCancelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NFSeNResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/reconciliation": {
+            "get": {
+                "description": "Devolve o relatório da última reconciliação (agendada ou manual) entre companies / focus_integration e as empresas da Focus.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reconciliação"
+                ],
+                "summary": "Último relatório de reconciliação Supabase x Focus",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReconciliationReport"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Percorre todas as empresas da Focus (GET /v2/empresas), casa pelo CNPJ/CPF com companies e devolve as divergências:\nmissing_link, integrated_flag, missing_in_focus, orphaned_focus_empresa, token_mismatch e certificate_dates.\nCom fix=true corrige o que é possível do lado do Supabase (a Focus não é alterada).\nUm relatório parcial (com error) é devolvido com 502 quando a execução é interrompida.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reconciliação"
+                ],
+                "summary": "Executa a reconciliação Supabase x Focus",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Corrige as divergências no Supabase (padrão: false)",
+                        "name": "fix",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReconciliationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReconciliationReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/focus": {
+            "post": {
+                "description": "Endpoint chamado pela Focus quando o status de um documento muda (gatilhos cadastrados em /v2/hooks).\nAutenticado pelo segredo compartilhado no header configurado (FOCUS_WEBHOOK_SECRET_HEADER).\nEntregas repetidas são ignoradas. O evento é gravado em focus_webhook_events antes da resposta (falha: 503, a Focus reenvia);\no documento em focus_documents é atualizado de forma assíncrona.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Recebe webhook da Focus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tipo do documento: nfse, nfsen, nfe, nfce, cte ou mdfe",
+                        "name": "evento",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da empresa (companies.id); sem ele, a empresa é deduzida pela ref",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Documento no formato da consulta da Focus",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RawPayload"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "focus.BreakerState": {
+            "type": "string",
+            "enum": [
+                "closed",
+                "open",
+                "half_open"
+            ],
+            "x-enum-varnames": [
+                "BreakerClosed",
+                "BreakerOpen",
+                "BreakerHalfOpen"
+            ]
+        },
+        "handler.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "focus": {
+                    "description": "Estado dos circuit breakers da Focus por família de endpoints (closed, open, half_open).",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/focus.BreakerState"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RawPayload": {
+            "type": "object",
+            "additionalProperties": {}
+        },
+        "model.CTeDocumentoNFe": {
+            "type": "object",
+            "properties": {
+                "chave_nfe": {
+                    "type": "string",
+                    "example": "41250110964044000164550010000000011000000016"
+                }
+            }
+        },
+        "model.CTeRequest": {
+            "type": "object",
+            "properties": {
+                "cfop": {
+                    "type": "string",
+                    "example": "5353"
+                },
+                "cnpj_destinatario": {
+                    "type": "string"
+                },
+                "cnpj_emitente": {
+                    "type": "string",
+                    "example": "10964044000164"
+                },
+                "cnpj_remetente": {
+                    "type": "string",
+                    "example": "07504505000132"
+                },
+                "codigo_municipio_envio": {
+                    "type": "string",
+                    "example": "4106902"
+                },
+                "codigo_municipio_fim": {
+                    "type": "string",
+                    "example": "3550308"
+                },
+                "codigo_municipio_inicio": {
+                    "type": "string",
+                    "example": "4106902"
+                },
+                "cpf_destinatario": {
+                    "type": "string"
+                },
+                "data_emissao": {
+                    "type": "string",
+                    "example": "2025-01-15T10:00:00-03:00"
+                },
+                "icms_situacao_tributaria": {
+                    "type": "string",
+                    "example": "00"
+                },
+                "indicador_tomador": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "modal": {
+                    "type": "string",
+                    "example": "01"
+                },
+                "natureza_operacao": {
+                    "type": "string",
+                    "example": "Prestação de serviço de transporte"
+                },
+                "nfes": {
+                    "description": "Chaves das NF-e transportadas",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CTeDocumentoNFe"
+                    }
+                },
+                "nome_destinatario": {
+                    "type": "string",
+                    "example": "Maria da Silva"
+                },
+                "nome_remetente": {
+                    "type": "string",
+                    "example": "Acme Ltda"
+                },
+                "produto_predominante": {
+                    "type": "string",
+                    "example": "Materiais de escritório"
+                },
+                "tipo_documento": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "tipo_servico": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "uf_fim": {
+                    "type": "string",
+                    "example": "SP"
+                },
+                "uf_inicio": {
+                    "type": "string",
+                    "example": "PR"
+                },
+                "valor_receber": {
+                    "type": "number",
+                    "example": 350
+                },
+                "valor_total": {
+                    "type": "number",
+                    "example": 350
+                },
+                "valor_total_carga": {
+                    "type": "number",
+                    "example": 12000
+                }
+            }
+        },
+        "model.CTeResponse": {
+            "type": "object",
+            "properties": {
+                "caminho_dacte": {
+                    "type": "string"
+                },
+                "caminho_xml_cancelamento": {
+                    "type": "string"
+                },
+                "caminho_xml_nota_fiscal": {
+                    "type": "string"
+                },
+                "chave": {
+                    "type": "string"
+                },
+                "cnpj_emitente": {
+                    "type": "string",
+                    "example": "10964044000164"
+                },
+                "mensagem_sefaz": {
+                    "type": "string",
+                    "example": "Autorizado o uso do CT-e"
+                },
+                "numero": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "ref": {
+                    "type": "string",
+                    "example": "cte-2025-0001"
+                },
+                "serie": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "autorizado"
+                },
+                "status_sefaz": {
+                    "type": "string",
+                    "example": "100"
+                }
+            }
+        },
+        "model.CompanyBackupArchiveResponse": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string",
+                    "example": "focus-documents"
+                },
+                "danfes_storage_path": {
+                    "type": "string",
+                    "example": "b3c1.../backups/producao/2024-03/danfes.zip"
+                },
+                "danfes_url": {
+                    "type": "string"
+                },
+                "mes": {
+                    "type": "string",
+                    "example": "2024-03"
+                },
+                "xmls_storage_path": {
+                    "type": "string",
+                    "example": "b3c1.../backups/producao/2024-03/xmls.zip"
+                },
+                "xmls_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CompanyHookRequest": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "type": "string",
+                    "example": "nfe"
+                }
+            }
+        },
+        "model.FocusBackup": {
+            "type": "object",
+            "properties": {
+                "danfes": {
+                    "type": "string",
+                    "example": "https://api.focusnfe.com.br/arquivos/backups/10964044000164_2024-03_danfes.zip"
+                },
+                "mes": {
+                    "type": "string",
+                    "example": "2024-03"
+                },
+                "xmls": {
+                    "type": "string",
+                    "example": "https://api.focusnfe.com.br/arquivos/backups/10964044000164_2024-03_xmls.zip"
+                }
+            }
+        },
+        "model.FocusBackupsResponse": {
+            "type": "object",
+            "properties": {
+                "backups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FocusBackup"
+                    }
+                }
+            }
+        },
+        "model.FocusCepResponse": {
+            "type": "object",
+            "properties": {
+                "cep": {
+                    "type": "string",
+                    "example": "01310100"
+                },
+                "codigo_ibge": {
+                    "type": "string",
+                    "example": "3550308"
+                },
+                "complemento": {
+                    "type": "string",
+                    "example": "de 612 a 1510 - lado par"
+                },
+                "descricao": {
+                    "type": "string",
+                    "example": "Avenida Paulista"
+                },
+                "descricao_bairro": {
+                    "type": "string",
+                    "example": "Bela Vista"
+                },
+                "nome_bairro_inicial": {
+                    "type": "string"
+                },
+                "nome_localidade": {
+                    "type": "string",
+                    "example": "São Paulo"
+                },
+                "nome_logradouro": {
+                    "type": "string",
+                    "example": "Paulista"
+                },
+                "tipo": {
+                    "type": "string",
+                    "example": "logradouro"
+                },
+                "tipo_logradouro": {
+                    "type": "string",
+                    "example": "Avenida"
+                },
+                "uf": {
+                    "type": "string",
+                    "example": "SP"
+                }
+            }
+        },
+        "model.FocusCfopResponse": {
+            "type": "object",
+            "properties": {
+                "codigo": {
+                    "type": "string",
+                    "example": "5102"
+                },
+                "descricao": {
+                    "type": "string",
+                    "example": "Venda de mercadoria adquirida ou recebida de terceiros"
+                }
+            }
+        },
+        "model.FocusCnpjEndereco": {
+            "type": "object",
+            "properties": {
+                "bairro": {
+                    "type": "string"
+                },
+                "cep": {
+                    "type": "string"
+                },
+                "codigo_ibge": {
+                    "type": "string"
+                },
+                "codigo_municipio": {
+                    "type": "string"
+                },
+                "codigo_siafi": {
+                    "type": "string"
+                },
+                "complemento": {
+                    "type": "string"
+                },
+                "logradouro": {
+                    "type": "string"
+                },
+                "nome_municipio": {
+                    "type": "string"
+                },
+                "numero": {
+                    "type": "string"
+                },
+                "uf": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FocusCnpjResponse": {
+            "type": "object",
+            "properties": {
+                "cnae_principal": {
+                    "type": "string"
+                },
+                "cnpj": {
+                    "type": "string"
+                },
+                "endereco": {
+                    "$ref": "#/definitions/model.FocusCnpjEndereco"
+                },
+                "optante_mei": {
+                    "type": "boolean"
+                },
+                "optante_simples_nacional": {
+                    "type": "boolean"
+                },
+                "razao_social": {
+                    "type": "string"
+                },
+                "situacao_cadastral": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FocusDocumentoErro": {
+            "type": "object",
+            "properties": {
+                "codigo": {
+                    "type": "string",
+                    "example": "E10"
+                },
+                "correcao": {
+                    "type": "string"
+                },
+                "mensagem": {
+                    "type": "string",
+                    "example": "RPS já informado"
+                }
+            }
+        },
+        "model.FocusEmpresaCreateRequest": {
+            "type": "object",
+            "required": [
+                "arquivo_certificado_base64",
+                "bairro",
+                "cep",
+                "cnpj",
+                "complemento",
+                "email",
+                "inscricao_municipal",
+                "logradouro",
+                "municipio",
+                "nome",
+                "nome_fantasia",
+                "numero",
+                "regime_tributario",
+                "senha_certificado",
+                "uf"
+            ],
+            "properties": {
+                "arquivo_certificado_base64": {
+                    "type": "string",
+                    "example": "MIIj4gIBAzCCI54GCSqGSIb3DQEHAaCC...ASD=="
+                },
+                "bairro": {
+                    "type": "string",
+                    "example": "Vila Isabel"
+                },
+                "cep": {
+                    "type": "integer",
+                    "example": 80210000
+                },
+                "cnpj": {
+                    "type": "string",
+                    "example": "10964044000164"
+                },
+                "complemento": {
+                    "type": "string",
+                    "example": "Loja 1"
+                },
+                "cpf_responsavel": {
+                    "type": "string",
+                    "example": "12345678901"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "test@example.com"
+                },
+                "inscricao_municipal": {
+                    "description": "Must be string to preserve leading zeros (JSON number cannot represent \"00\", \"00123\", etc.)",
+                    "type": "string",
+                    "example": "0046532"
+                },
+                "logradouro": {
+                    "type": "string",
+                    "example": "Rua João da Silva"
+                },
+                "municipio": {
+                    "type": "string",
+                    "example": "Curitiba"
+                },
+                "nome": {
+                    "type": "string",
+                    "example": "Nome da empresa Ltda"
+                },
+                "nome_fantasia": {
+                    "type": "string",
+                    "example": "Nome Fantasia"
+                },
+                "nome_responsavel": {
+                    "type": "string",
+                    "example": "Fulano de Tal"
+                },
+                "numero": {
+                    "type": "integer",
+                    "example": 153
+                },
+                "regime_tributario": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "senha_certificado": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "telefone": {
+                    "type": "string",
+                    "example": "4130333333"
+                },
+                "uf": {
+                    "type": "string",
+                    "example": "PR"
+                }
+            }
+        },
+        "model.FocusEmpresaResponse": {
+            "type": "object",
+            "properties": {
+                "bairro": {
+                    "type": "string",
+                    "example": "PARQUE DOM PEDRO II"
+                },
+                "cep": {
+                    "type": "string",
+                    "example": "13056430"
+                },
+                "certificado_cnpj": {
+                    "type": "string",
+                    "example": "61453926000127"
+                },
+                "certificado_especifico": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "certificado_valido_ate": {
+                    "description": "Certificado",
+                    "type": "string",
+                    "example": "2026-11-12T14:33:00-03:00"
+                },
+                "certificado_valido_de": {
+                    "type": "string",
+                    "example": "2025-11-12T14:33:00-03:00"
+                },
+                "client_app_id": {
+                    "type": "integer",
+                    "example": 357307
+                },
+                "cnpj": {
+                    "type": "string",
+                    "example": "61453926000127"
+                },
+                "codigo_municipio": {
+                    "type": "string",
+                    "example": "3509502"
+                },
+                "codigo_pais": {
+                    "type": "string",
+                    "example": "1058"
+                },
+                "codigo_uf": {
+                    "type": "string",
+                    "example": "35"
+                },
+                "complemento": {
+                    "type": "string",
+                    "example": "BLOCO 24 APT 04 COND RES OURO VERDE"
+                },
+                "cpf": {
+                    "type": "string",
+                    "example": "12345678901"
+                },
+                "cpf_responsavel": {
+                    "type": "string"
+                },
+                "discrimina_impostos": {
+                    "description": "Flags comuns",
+                    "type": "boolean",
+                    "example": true
+                },
+                "email": {
+                    "type": "string",
+                    "example": "andre.vi.riibeiro@gmail.com"
+                },
+                "enviar_email_destinatario": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "enviar_email_homologacao": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "habilita_csrt_nfe": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "habilita_cte": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "habilita_manifestacao": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "habilita_mdfe": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "habilita_nfce": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "habilita_nfe": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "habilita_nfse": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "habilita_nfsen_producao": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "description": "Campos principais + campos retornados pela Focus (exemplo real).",
+                    "type": "integer",
+                    "example": 170571
+                },
+                "inscricao_estadual": {
+                    "type": "string"
+                },
+                "inscricao_municipal": {
+                    "type": "string",
+                    "example": "10721193"
+                },
+                "logradouro": {
+                    "type": "string",
+                    "example": "COACIARA"
+                },
+                "municipio": {
+                    "type": "string",
+                    "example": "CAMPINAS"
+                },
+                "nfe_sincrono": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "nfe_sincrono_homologacao": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "nome": {
+                    "type": "string",
+                    "example": "INFINITY CODE SOLUTIONS LTDA"
+                },
+                "nome_fantasia": {
+                    "type": "string",
+                    "example": "INFINITY CODE"
+                },
+                "nome_responsavel": {
+                    "description": "Outros",
+                    "type": "string"
+                },
+                "numero": {
+                    "type": "string",
+                    "example": "1101"
+                },
+                "pais": {
+                    "type": "string",
+                    "example": "Brasil"
+                },
+                "regime_tributario": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "telefone": {
+                    "type": "string",
+                    "example": "1993675739"
+                },
+                "token_homologacao": {
+                    "type": "string",
+                    "example": "tmyEw3AL8QIEqjObIuqnbGcP7wMW961F"
+                },
+                "token_producao": {
+                    "description": "Tokens",
+                    "type": "string",
+                    "example": "mQadVh1LFN7KQNESwDFLsDBq039z273s"
+                },
+                "uf": {
+                    "type": "string",
+                    "example": "SP"
+                }
+            }
+        },
+        "model.FocusEmpresaUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "arquivo_certificado_base64": {
+                    "type": "string",
+                    "example": "MIIj4gIBAzCCI54GCSqGSIb3DQEHAaCC...ASD=="
+                },
+                "bairro": {
+                    "type": "string",
+                    "example": "Vila Isabel"
+                },
+                "cep": {
+                    "type": "integer",
+                    "example": 80210000
+                },
+                "cnpj": {
+                    "type": "string",
+                    "example": "10964044000164"
+                },
+                "complemento": {
+                    "type": "string",
+                    "example": "Loja 1"
+                },
+                "cpf_responsavel": {
+                    "type": "string",
+                    "example": "12345678901"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "test@example.com"
+                },
+                "habilita_cte": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "habilita_mdfe": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "habilita_nfce": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "habilita_nfe": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "habilita_nfse": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "habilita_nfsen_producao": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "inscricao_municipal": {
+                    "description": "Must be string to preserve leading zeros",
+                    "type": "string",
+                    "example": "0046532"
+                },
+                "login_responsavel": {
+                    "type": "string",
+                    "example": "usuario@example.com"
+                },
+                "logradouro": {
+                    "type": "string",
+                    "example": "Rua João da Silva"
+                },
+                "municipio": {
+                    "type": "string",
+                    "example": "Curitiba"
+                },
+                "nome": {
+                    "type": "string",
+                    "example": "Nome da empresa Ltda"
+                },
+                "nome_fantasia": {
+                    "type": "string",
+                    "example": "Nome Fantasia"
+                },
+                "nome_responsavel": {
+                    "type": "string",
+                    "example": "Fulano de Tal"
+                },
+                "numero": {
+                    "type": "integer",
+                    "example": 153
+                },
+                "proximo_numero_cte_producao": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "proximo_numero_mdfe_producao": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "proximo_numero_nfce_producao": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "proximo_numero_nfe_producao": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "proximo_numero_nfse_producao": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "proximo_numero_nfsen_producao": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "regime_tributario": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "senha_certificado": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "senha_responsavel": {
+                    "type": "string",
+                    "example": "senha123"
+                },
+                "senha_responsavel_preenchida": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "telefone": {
+                    "type": "string",
+                    "example": "4130333333"
+                },
+                "uf": {
+                    "type": "string",
+                    "example": "PR"
+                }
+            }
+        },
+        "model.FocusHookResponse": {
+            "type": "object",
+            "properties": {
+                "authorization_header": {
+                    "type": "string",
+                    "example": "X-Focus-Webhook-Secret"
+                },
                 "cnpj": {
+                    "type": "string",
+                    "example": "10964044000164"
+                },
+                "cpf": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "nfe"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "Vj5rmkBq"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://api.exemplo.com.br/webhooks/focus?evento=nfe"
+                }
+            }
+        },
+        "model.FocusMunicipioResponse": {
+            "type": "object",
+            "properties": {
+                "codigo_cnae_obrigatorio_nfse": {
+                    "type": "boolean"
+                },
+                "codigo_municipio": {
+                    "type": "string"
+                },
+                "codigo_tributario_municipio_obrigatorio_nfse": {
+                    "type": "boolean"
+                },
+                "cpf_cnpj_obrigatorio_nfse": {
+                    "type": "boolean"
+                },
+                "data_previsao_reimplementacao_nfse": {
+                    "type": "string"
+                },
+                "endereco_obrigatorio_nfse": {
+                    "type": "boolean"
+                },
+                "item_lista_servico_obrigatorio_nfse": {
+                    "type": "boolean"
+                },
+                "nfse_habilitada": {
+                    "type": "boolean"
+                },
+                "nome_municipio": {
+                    "type": "string"
+                },
+                "nome_uf": {
+                    "type": "string"
+                },
+                "possui_ambiente_homologacao_nfse": {
+                    "type": "boolean"
+                },
+                "possui_cancelamento_nfse": {
+                    "type": "boolean"
+                },
+                "provedor_nfse": {
+                    "type": "string"
+                },
+                "requer_certificado_nfse": {
+                    "type": "boolean"
+                },
+                "sigla_uf": {
+                    "type": "string"
+                },
+                "status_nfse": {
+                    "type": "string"
+                },
+                "ultima_emissao_nfse": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FocusNcmResponse": {
+            "type": "object",
+            "properties": {
+                "capitulo": {
+                    "type": "string",
+                    "example": "84"
+                },
+                "codigo": {
+                    "type": "string",
+                    "example": "84713012"
+                },
+                "descricao_completa": {
+                    "type": "string",
+                    "example": "Máquinas automáticas para processamento de dados, portáteis..."
+                },
+                "item1": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "item2": {
+                    "type": "string",
+                    "example": "2"
+                },
+                "posicao": {
+                    "type": "string",
+                    "example": "71"
+                },
+                "subposicao1": {
+                    "type": "string",
+                    "example": "3"
+                },
+                "subposicao2": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "model.MDFeCondutor": {
+            "type": "object",
+            "properties": {
+                "cpf": {
+                    "type": "string",
+                    "example": "12345678901"
+                },
+                "nome": {
+                    "type": "string",
+                    "example": "João da Silva"
+                }
+            }
+        },
+        "model.MDFeDocumentoCTe": {
+            "type": "object",
+            "properties": {
+                "chave_cte": {
+                    "type": "string",
+                    "example": "41250110964044000164570010000000011000000016"
+                }
+            }
+        },
+        "model.MDFeEncerramentoRequest": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": "2025-01-16"
+                },
+                "nome_municipio": {
+                    "type": "string",
+                    "example": "São Paulo"
+                },
+                "sigla_uf": {
+                    "type": "string",
+                    "example": "SP"
+                }
+            }
+        },
+        "model.MDFeModalRodoviario": {
+            "type": "object",
+            "properties": {
+                "veiculo_tracao": {
+                    "$ref": "#/definitions/model.MDFeVeiculo"
+                }
+            }
+        },
+        "model.MDFeMunicipio": {
+            "type": "object",
+            "properties": {
+                "codigo": {
+                    "type": "string",
+                    "example": "4106902"
+                },
+                "nome": {
+                    "type": "string",
+                    "example": "Curitiba"
+                }
+            }
+        },
+        "model.MDFeMunicipioDescarga": {
+            "type": "object",
+            "properties": {
+                "codigo": {
+                    "type": "string",
+                    "example": "3550308"
+                },
+                "ctes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MDFeDocumentoCTe"
+                    }
+                },
+                "nfes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CTeDocumentoNFe"
+                    }
+                },
+                "nome": {
+                    "type": "string",
+                    "example": "São Paulo"
+                }
+            }
+        },
+        "model.MDFePercurso": {
+            "type": "object",
+            "properties": {
+                "uf": {
+                    "type": "string",
+                    "example": "SC"
+                }
+            }
+        },
+        "model.MDFeRequest": {
+            "type": "object",
+            "properties": {
+                "cnpj_emitente": {
+                    "type": "string",
+                    "example": "10964044000164"
+                },
+                "codigo_unidade_medida_peso_bruto": {
+                    "type": "string",
+                    "example": "01"
+                },
+                "data_emissao": {
+                    "type": "string",
+                    "example": "2025-01-15T10:00:00-03:00"
+                },
+                "modal": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "modal_rodoviario": {
+                    "$ref": "#/definitions/model.MDFeModalRodoviario"
+                },
+                "municipios_carregamento": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MDFeMunicipio"
+                    }
+                },
+                "municipios_descarregamento": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MDFeMunicipioDescarga"
+                    }
+                },
+                "percursos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MDFePercurso"
+                    }
+                },
+                "peso_bruto": {
+                    "type": "number",
+                    "example": 850.5
+                },
+                "tipo_emitente": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "uf_fim": {
+                    "type": "string",
+                    "example": "SP"
+                },
+                "uf_inicio": {
+                    "type": "string",
+                    "example": "PR"
+                },
+                "valor_total_carga": {
+                    "type": "number",
+                    "example": 12000
+                }
+            }
+        },
+        "model.MDFeResponse": {
+            "type": "object",
+            "properties": {
+                "caminho_damdfe": {
+                    "type": "string"
+                },
+                "caminho_xml_cancelamento": {
+                    "type": "string"
+                },
+                "caminho_xml_encerramento": {
+                    "type": "string"
+                },
+                "caminho_xml_nota_fiscal": {
+                    "type": "string"
+                },
+                "chave": {
+                    "type": "string"
+                },
+                "cnpj_emitente": {
+                    "type": "string",
+                    "example": "10964044000164"
+                },
+                "mensagem_sefaz": {
+                    "type": "string",
+                    "example": "Autorizado o uso do MDF-e"
+                },
+                "numero": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "ref": {
+                    "type": "string",
+                    "example": "mdfe-2025-0001"
+                },
+                "serie": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "autorizado"
+                },
+                "status_sefaz": {
+                    "type": "string",
+                    "example": "100"
+                }
+            }
+        },
+        "model.MDFeVeiculo": {
+            "type": "object",
+            "properties": {
+                "condutores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MDFeCondutor"
+                    }
+                },
+                "placa": {
+                    "type": "string",
+                    "example": "ABC1D23"
+                },
+                "tara": {
+                    "type": "integer",
+                    "example": 8000
+                },
+                "tipo_carroceria": {
+                    "type": "string",
+                    "example": "02"
+                },
+                "tipo_rodado": {
+                    "type": "string",
+                    "example": "03"
+                },
+                "uf_licenciamento": {
+                    "type": "string",
+                    "example": "PR"
+                }
+            }
+        },
+        "model.NFCeItem": {
+            "type": "object",
+            "properties": {
+                "cfop": {
+                    "type": "string",
+                    "example": "5102"
+                },
+                "codigo_barras_comercial": {
+                    "type": "string",
+                    "example": "SEM GTIN"
+                },
+                "codigo_ncm": {
+                    "type": "string",
+                    "example": "96081000"
+                },
+                "codigo_produto": {
+                    "type": "string",
+                    "example": "SKU-001"
+                },
+                "descricao": {
+                    "type": "string",
+                    "example": "Caneta azul"
+                },
+                "icms_origem": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "icms_situacao_tributaria": {
+                    "type": "string",
+                    "example": "102"
+                },
+                "numero_item": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantidade_comercial": {
+                    "type": "number",
+                    "example": 2
+                },
+                "unidade_comercial": {
+                    "type": "string",
+                    "example": "UN"
+                },
+                "valor_bruto": {
+                    "type": "number",
+                    "example": 30
+                },
+                "valor_desconto": {
+                    "type": "number",
+                    "example": 0
+                },
+                "valor_unitario_comercial": {
+                    "type": "number",
+                    "example": 15
+                }
+            }
+        },
+        "model.NFCePagamento": {
+            "type": "object",
+            "properties": {
+                "bandeira_operadora": {
+                    "type": "string",
+                    "example": "01"
+                },
+                "cnpj_credenciadora": {
                     "type": "string"
                 },
-                "endereco": {
-                    "$ref": "#/definitions/model.FocusCnpjEndereco"
+                "forma_pagamento": {
+                    "type": "string",
+                    "example": "01"
                 },
-                "optante_mei": {
-                    "type": "boolean"
+                "numero_autorizacao": {
+                    "type": "string"
                 },
-                "optante_simples_nacional": {
-                    "type": "boolean"
+                "tipo_integracao": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "razao_social": {
+                "troco": {
+                    "type": "number",
+                    "example": 20
+                },
+                "valor_pagamento": {
+                    "type": "number",
+                    "example": 50
+                }
+            }
+        },
+        "model.NFCeRequest": {
+            "type": "object",
+            "properties": {
+                "cnpj_destinatario": {
+                    "type": "string"
+                },
+                "cnpj_emitente": {
+                    "type": "string",
+                    "example": "10964044000164"
+                },
+                "cpf_destinatario": {
+                    "type": "string",
+                    "example": "12345678901"
+                },
+                "data_emissao": {
+                    "type": "string",
+                    "example": "2025-01-15T10:00:00-03:00"
+                },
+                "forma_emissao": {
+                    "description": "FormaEmissao: 1 = normal (padrão); 9 = contingência offline (a Focus transmite à SEFAZ depois).",
+                    "type": "integer",
+                    "example": 1
+                },
+                "formas_pagamento": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NFCePagamento"
+                    }
+                },
+                "informacoes_adicionais_contribuinte": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NFCeItem"
+                    }
+                },
+                "local_destino": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "modalidade_frete": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "natureza_operacao": {
+                    "type": "string",
+                    "example": "Venda ao consumidor"
+                },
+                "nome_destinatario": {
+                    "description": "Consumidor (opcional na NFC-e; obrigatório acima do limite da UF)",
+                    "type": "string",
+                    "example": "Maria da Silva"
+                },
+                "presenca_comprador": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "valor_desconto": {
+                    "type": "number",
+                    "example": 0
+                },
+                "valor_produtos": {
+                    "type": "number",
+                    "example": 30
+                },
+                "valor_total": {
+                    "type": "number",
+                    "example": 30
+                }
+            }
+        },
+        "model.NFCeResponse": {
+            "type": "object",
+            "properties": {
+                "caminho_danfe": {
+                    "type": "string"
+                },
+                "caminho_xml_cancelamento": {
+                    "type": "string"
+                },
+                "caminho_xml_nota_fiscal": {
+                    "type": "string"
+                },
+                "chave_nfe": {
+                    "type": "string"
+                },
+                "cnpj_emitente": {
+                    "type": "string",
+                    "example": "10964044000164"
+                },
+                "contingencia_offline": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "contingencia_offline_efetivada": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "mensagem_sefaz": {
+                    "type": "string",
+                    "example": "Autorizado o uso da NF-e"
+                },
+                "numero": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "qrcode_url": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string",
+                    "example": "nfce-2025-0001"
+                },
+                "serie": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "autorizado"
+                },
+                "status_sefaz": {
+                    "type": "string",
+                    "example": "100"
+                },
+                "url_consulta_nf": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.NFSeCancelRequest": {
+            "type": "object",
+            "properties": {
+                "justificativa": {
+                    "type": "string",
+                    "example": "Serviço não prestado; nota emitida em duplicidade"
+                }
+            }
+        },
+        "model.NFSeEmailRequest": {
+            "type": "object",
+            "properties": {
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "financeiro@acme.com.br"
+                    ]
+                }
+            }
+        },
+        "model.NFSeEndereco": {
+            "type": "object",
+            "properties": {
+                "bairro": {
+                    "type": "string",
+                    "example": "Vila Isabel"
+                },
+                "cep": {
+                    "type": "string",
+                    "example": "80210000"
+                },
+                "codigo_municipio": {
+                    "type": "string",
+                    "example": "4106902"
+                },
+                "complemento": {
+                    "type": "string",
+                    "example": "Sala 2"
+                },
+                "logradouro": {
+                    "type": "string",
+                    "example": "Rua João da Silva"
+                },
+                "numero": {
+                    "type": "string",
+                    "example": "153"
+                },
+                "uf": {
+                    "type": "string",
+                    "example": "PR"
+                }
+            }
+        },
+        "model.NFSeNRequest": {
+            "type": "object",
+            "properties": {
+                "bairro_tomador": {
+                    "type": "string",
+                    "example": "Vila Isabel"
+                },
+                "cep_tomador": {
+                    "type": "string",
+                    "example": "80210000"
+                },
+                "cnpj_prestador": {
+                    "description": "Prestador",
+                    "type": "string",
+                    "example": "10964044000164"
+                },
+                "cnpj_tomador": {
+                    "description": "Tomador",
+                    "type": "string",
+                    "example": "07504505000132"
+                },
+                "codigo_municipio_emissora": {
+                    "type": "string",
+                    "example": "4106902"
+                },
+                "codigo_municipio_prestacao": {
+                    "description": "Serviço",
+                    "type": "string",
+                    "example": "4106902"
+                },
+                "codigo_municipio_tomador": {
+                    "type": "string",
+                    "example": "4106902"
+                },
+                "codigo_opcao_simples_nacional": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "codigo_tributacao_municipal_iss": {
+                    "type": "string",
+                    "example": "001"
+                },
+                "codigo_tributacao_nacional_iss": {
+                    "type": "string",
+                    "example": "170201"
+                },
+                "cpf_prestador": {
+                    "type": "string"
+                },
+                "cpf_tomador": {
+                    "type": "string"
+                },
+                "data_competencia": {
+                    "type": "string",
+                    "example": "2025-01-15"
+                },
+                "data_emissao": {
+                    "type": "string",
+                    "example": "2025-01-15T10:00:00-03:00"
+                },
+                "descricao_servico": {
+                    "type": "string",
+                    "example": "Serviços de contabilidade - competência 01/2025"
+                },
+                "email_tomador": {
+                    "type": "string",
+                    "example": "financeiro@acme.com.br"
+                },
+                "inscricao_municipal_prestador": {
+                    "type": "string",
+                    "example": "0046532"
+                },
+                "logradouro_tomador": {
+                    "type": "string",
+                    "example": "Rua João da Silva"
+                },
+                "numero_tomador": {
+                    "type": "string",
+                    "example": "153"
+                },
+                "percentual_aliquota_relativa_municipio": {
+                    "type": "number",
+                    "example": 2.5
+                },
+                "razao_social_tomador": {
+                    "type": "string",
+                    "example": "Acme Ltda"
+                },
+                "regime_especial_tributacao": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "tipo_retencao_iss": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "tributacao_iss": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "valor_servico": {
+                    "type": "number",
+                    "example": 1500
+                }
+            }
+        },
+        "model.NFSeNResponse": {
+            "type": "object",
+            "properties": {
+                "caminho_xml_nota_fiscal": {
+                    "type": "string"
+                },
+                "chave_nfse": {
+                    "type": "string"
+                },
+                "cnpj_prestador": {
+                    "type": "string",
+                    "example": "10964044000164"
+                },
+                "codigo_verificacao": {
+                    "type": "string"
+                },
+                "data_emissao": {
+                    "type": "string",
+                    "example": "2025-01-15T10:00:00-03:00"
+                },
+                "erros": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FocusDocumentoErro"
+                    }
+                },
+                "numero": {
+                    "type": "string",
+                    "example": "1024"
+                },
+                "numero_dps": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "ref": {
+                    "type": "string",
+                    "example": "nfsen-2025-0001"
+                },
+                "serie_dps": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "autorizado"
+                },
+                "url": {
                     "type": "string"
                 },
-                "situacao_cadastral": {
+                "url_danfse": {
                     "type": "string"
                 }
             }
         },
-        "model.FocusEmpresaCreateRequest": {
+        "model.NFSePrestador": {
             "type": "object",
-            "required": [
-                "arquivo_certificado_base64",
-                "bairro",
-                "cep",
-                "cnpj",
-                "complemento",
-                "email",
-                "inscricao_municipal",
-                "logradouro",
-                "municipio",
-                "nome",
-                "nome_fantasia",
-                "numero",
-                "regime_tributario",
-                "senha_certificado",
-                "uf"
-            ],
             "properties": {
-                "arquivo_certificado_base64": {
-                    "type": "string",
-                    "example": "MIIj4gIBAzCCI54GCSqGSIb3DQEHAaCC...ASD=="
-                },
-                "bairro": {
-                    "type": "string",
-                    "example": "Vila Isabel"
-                },
-                "cep": {
-                    "type": "integer",
-                    "example": 80210000
-                },
                 "cnpj": {
                     "type": "string",
                     "example": "10964044000164"
                 },
-                "complemento": {
-                    "type": "string",
-                    "example": "Loja 1"
-                },
-                "cpf_responsavel": {
-                    "type": "string",
-                    "example": "12345678901"
-                },
-                "email": {
+                "codigo_municipio": {
                     "type": "string",
-                    "example": "test@example.com"
+                    "example": "4106902"
                 },
                 "inscricao_municipal": {
-                    "description": "Must be string to preserve leading zeros (JSON number cannot represent \"00\", \"00123\", etc.)",
                     "type": "string",
                     "example": "0046532"
-                },
-                "logradouro": {
+                }
+            }
+        },
+        "model.NFSeRequest": {
+            "type": "object",
+            "properties": {
+                "data_emissao": {
                     "type": "string",
-                    "example": "Rua João da Silva"
+                    "example": "2025-01-15T10:00:00-03:00"
                 },
-                "municipio": {
-                    "type": "string",
-                    "example": "Curitiba"
+                "natureza_operacao": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "nome": {
+                "optante_simples_nacional": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "prestador": {
+                    "$ref": "#/definitions/model.NFSePrestador"
+                },
+                "servico": {
+                    "$ref": "#/definitions/model.NFSeServico"
+                },
+                "tomador": {
+                    "$ref": "#/definitions/model.NFSeTomador"
+                }
+            }
+        },
+        "model.NFSeResponse": {
+            "type": "object",
+            "properties": {
+                "caminho_xml_nota_fiscal": {
+                    "type": "string"
+                },
+                "cnpj_prestador": {
                     "type": "string",
-                    "example": "Nome da empresa Ltda"
+                    "example": "10964044000164"
                 },
-                "nome_fantasia": {
+                "codigo_verificacao": {
                     "type": "string",
-                    "example": "Nome Fantasia"
+                    "example": "ABC123XYZ"
                 },
-                "nome_responsavel": {
+                "data_emissao": {
                     "type": "string",
-                    "example": "Fulano de Tal"
+                    "example": "2025-01-15T10:00:00-03:00"
+                },
+                "erros": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FocusDocumentoErro"
+                    }
                 },
                 "numero": {
-                    "type": "integer",
-                    "example": 153
+                    "type": "string",
+                    "example": "1024"
                 },
-                "regime_tributario": {
-                    "type": "integer",
-                    "example": 1
+                "numero_rps": {
+                    "type": "string",
+                    "example": "12"
                 },
-                "senha_certificado": {
+                "ref": {
                     "type": "string",
-                    "example": "123456"
+                    "example": "nfse-2025-0001"
                 },
-                "telefone": {
+                "serie_rps": {
                     "type": "string",
-                    "example": "4130333333"
+                    "example": "1"
                 },
-                "uf": {
+                "status": {
                     "type": "string",
-                    "example": "PR"
+                    "example": "autorizado"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "url_danfse": {
+                    "type": "string"
                 }
             }
         },
-        "model.FocusEmpresaResponse": {
+        "model.NFSeServico": {
             "type": "object",
             "properties": {
-                "bairro": {
+                "aliquota": {
+                    "type": "number",
+                    "example": 2.5
+                },
+                "codigo_cnae": {
                     "type": "string",
-                    "example": "PARQUE DOM PEDRO II"
+                    "example": "6920601"
                 },
-                "cep": {
+                "codigo_municipio": {
                     "type": "string",
-                    "example": "13056430"
+                    "example": "4106902"
                 },
-                "certificado_cnpj": {
+                "codigo_tributario_municipio": {
                     "type": "string",
-                    "example": "61453926000127"
+                    "example": "171901"
                 },
-                "certificado_especifico": {
+                "discriminacao": {
+                    "type": "string",
+                    "example": "Serviços de contabilidade - competência 01/2025"
+                },
+                "iss_retido": {
                     "type": "boolean",
                     "example": false
                 },
-                "certificado_valido_ate": {
-                    "description": "Certificado",
+                "item_lista_servico": {
                     "type": "string",
-                    "example": "2026-11-12T14:33:00-03:00"
+                    "example": "17.19"
                 },
-                "certificado_valido_de": {
+                "valor_servicos": {
+                    "type": "number",
+                    "example": 1500
+                }
+            }
+        },
+        "model.NFSeTomador": {
+            "type": "object",
+            "properties": {
+                "cnpj": {
                     "type": "string",
-                    "example": "2025-11-12T14:33:00-03:00"
+                    "example": "07504505000132"
                 },
-                "client_app_id": {
-                    "type": "integer",
-                    "example": 357307
-                },
-                "cnpj": {
+                "cpf": {
                     "type": "string",
-                    "example": "61453926000127"
+                    "example": "12345678901"
                 },
-                "codigo_municipio": {
+                "email": {
                     "type": "string",
-                    "example": "3509502"
+                    "example": "financeiro@acme.com.br"
                 },
-                "codigo_pais": {
+                "endereco": {
+                    "$ref": "#/definitions/model.NFSeEndereco"
+                },
+                "razao_social": {
                     "type": "string",
-                    "example": "1058"
+                    "example": "Acme Ltda"
+                }
+            }
+        },
+        "model.NFeCancelRequest": {
+            "type": "object",
+            "properties": {
+                "justificativa": {
+                    "type": "string",
+                    "example": "Nota emitida com valor incorreto"
+                }
+            }
+        },
+        "model.NFeCartaCorrecaoRequest": {
+            "type": "object",
+            "properties": {
+                "correcao": {
+                    "type": "string",
+                    "example": "Corrigido o endereço de entrega para Rua João da Silva, 153"
+                }
+            }
+        },
+        "model.NFeInutilizacaoRequest": {
+            "type": "object",
+            "properties": {
+                "cnpj": {
+                    "type": "string",
+                    "example": "10964044000164"
                 },
-                "codigo_uf": {
+                "justificativa": {
                     "type": "string",
-                    "example": "35"
+                    "example": "Numeração pulada por falha no sistema emissor"
                 },
-                "complemento": {
+                "numero_final": {
                     "type": "string",
-                    "example": "BLOCO 24 APT 04 COND RES OURO VERDE"
+                    "example": "12"
                 },
-                "cpf": {
+                "numero_inicial": {
                     "type": "string",
-                    "example": "12345678901"
+                    "example": "10"
                 },
-                "cpf_responsavel": {
+                "serie": {
+                    "type": "string",
+                    "example": "1"
+                }
+            }
+        },
+        "model.NFeInutilizacaoResponse": {
+            "type": "object",
+            "properties": {
+                "caminho_xml": {
                     "type": "string"
                 },
-                "discrimina_impostos": {
-                    "description": "Flags comuns",
-                    "type": "boolean",
-                    "example": true
-                },
-                "email": {
+                "mensagem_sefaz": {
                     "type": "string",
-                    "example": "andre.vi.riibeiro@gmail.com"
+                    "example": "Inutilização de número homologado"
                 },
-                "enviar_email_destinatario": {
-                    "type": "boolean",
-                    "example": true
+                "numero_final": {
+                    "type": "string",
+                    "example": "12"
                 },
-                "enviar_email_homologacao": {
-                    "type": "boolean",
-                    "example": false
+                "numero_inicial": {
+                    "type": "string",
+                    "example": "10"
                 },
-                "habilita_csrt_nfe": {
-                    "type": "boolean",
-                    "example": true
+                "serie": {
+                    "type": "string",
+                    "example": "1"
                 },
-                "habilita_cte": {
-                    "type": "boolean",
-                    "example": false
+                "status": {
+                    "type": "string",
+                    "example": "autorizado"
                 },
-                "habilita_manifestacao": {
-                    "type": "boolean",
-                    "example": false
+                "status_sefaz": {
+                    "type": "string",
+                    "example": "102"
+                }
+            }
+        },
+        "model.NFeItem": {
+            "type": "object",
+            "properties": {
+                "cfop": {
+                    "type": "string",
+                    "example": "5102"
                 },
-                "habilita_mdfe": {
-                    "type": "boolean",
-                    "example": false
+                "codigo_ncm": {
+                    "type": "string",
+                    "example": "96081000"
                 },
-                "habilita_nfce": {
-                    "type": "boolean",
-                    "example": false
+                "codigo_produto": {
+                    "type": "string",
+                    "example": "SKU-001"
                 },
-                "habilita_nfe": {
-                    "type": "boolean",
-                    "example": false
+                "cofins_situacao_tributaria": {
+                    "type": "string",
+                    "example": "07"
                 },
-                "habilita_nfse": {
-                    "type": "boolean",
-                    "example": false
+                "descricao": {
+                    "type": "string",
+                    "example": "Caneta azul"
                 },
-                "id": {
-                    "description": "Campos principais + campos retornados pela Focus (exemplo real).",
+                "icms_origem": {
                     "type": "integer",
-                    "example": 170571
+                    "example": 0
                 },
-                "inscricao_estadual": {
-                    "type": "string"
-                },
-                "inscricao_municipal": {
+                "icms_situacao_tributaria": {
                     "type": "string",
-                    "example": "10721193"
+                    "example": "102"
                 },
-                "logradouro": {
-                    "type": "string",
-                    "example": "COACIARA"
+                "numero_item": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "municipio": {
+                "pis_situacao_tributaria": {
                     "type": "string",
-                    "example": "CAMPINAS"
+                    "example": "07"
                 },
-                "nfe_sincrono": {
-                    "type": "boolean",
-                    "example": false
+                "quantidade_comercial": {
+                    "type": "number",
+                    "example": 10
                 },
-                "nfe_sincrono_homologacao": {
-                    "type": "boolean",
-                    "example": false
+                "quantidade_tributavel": {
+                    "type": "number",
+                    "example": 10
                 },
-                "nome": {
+                "unidade_comercial": {
                     "type": "string",
-                    "example": "INFINITY CODE SOLUTIONS LTDA"
+                    "example": "UN"
                 },
-                "nome_fantasia": {
+                "unidade_tributavel": {
                     "type": "string",
-                    "example": "INFINITY CODE"
+                    "example": "UN"
                 },
-                "nome_responsavel": {
-                    "description": "Outros",
-                    "type": "string"
+                "valor_bruto": {
+                    "type": "number",
+                    "example": 150
                 },
-                "numero": {
+                "valor_desconto": {
+                    "type": "number",
+                    "example": 0
+                },
+                "valor_unitario_comercial": {
+                    "type": "number",
+                    "example": 15
+                },
+                "valor_unitario_tributavel": {
+                    "type": "number",
+                    "example": 15
+                }
+            }
+        },
+        "model.NFePagamento": {
+            "type": "object",
+            "properties": {
+                "bandeira_operadora": {
                     "type": "string",
-                    "example": "1101"
+                    "example": "01"
                 },
-                "pais": {
-                    "type": "string",
-                    "example": "Brasil"
+                "cnpj_credenciadora": {
+                    "type": "string"
                 },
-                "regime_tributario": {
+                "forma_pagamento": {
                     "type": "string",
-                    "example": "1"
+                    "example": "01"
                 },
-                "telefone": {
-                    "type": "string",
-                    "example": "1993675739"
+                "numero_autorizacao": {
+                    "type": "string"
                 },
-                "token_homologacao": {
-                    "type": "string",
-                    "example": "tmyEw3AL8QIEqjObIuqnbGcP7wMW961F"
+                "tipo_integracao": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "token_producao": {
-                    "description": "Tokens",
-                    "type": "string",
-                    "example": "mQadVh1LFN7KQNESwDFLsDBq039z273s"
+                "troco": {
+                    "type": "number",
+                    "example": 0
                 },
-                "uf": {
-                    "type": "string",
-                    "example": "SP"
+                "valor_pagamento": {
+                    "type": "number",
+                    "example": 150
                 }
             }
         },
-        "model.FocusEmpresaUpdateRequest": {
+        "model.NFeRequest": {
             "type": "object",
             "properties": {
-                "arquivo_certificado_base64": {
+                "bairro_destinatario": {
                     "type": "string",
-                    "example": "MIIj4gIBAzCCI54GCSqGSIb3DQEHAaCC...ASD=="
+                    "example": "Vila Isabel"
                 },
-                "bairro": {
+                "cep_destinatario": {
                     "type": "string",
-                    "example": "Vila Isabel"
+                    "example": "80210000"
                 },
-                "cep": {
-                    "type": "integer",
-                    "example": 80210000
+                "cnpj_destinatario": {
+                    "type": "string",
+                    "example": "07504505000132"
                 },
-                "cnpj": {
+                "cnpj_emitente": {
+                    "description": "Emitente",
                     "type": "string",
                     "example": "10964044000164"
                 },
-                "complemento": {
-                    "type": "string",
-                    "example": "Loja 1"
+                "consumidor_final": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "cpf_responsavel": {
-                    "type": "string",
-                    "example": "12345678901"
+                "cpf_destinatario": {
+                    "type": "string"
                 },
-                "email": {
+                "data_emissao": {
                     "type": "string",
-                    "example": "test@example.com"
+                    "example": "2025-01-15T10:00:00-03:00"
                 },
-                "habilita_cte": {
-                    "type": "boolean",
-                    "example": true
+                "data_entrada_saida": {
+                    "type": "string",
+                    "example": "2025-01-15T10:00:00-03:00"
                 },
-                "habilita_mdfe": {
-                    "type": "boolean",
-                    "example": true
+                "email_destinatario": {
+                    "type": "string",
+                    "example": "financeiro@acme.com.br"
                 },
-                "habilita_nfce": {
-                    "type": "boolean",
-                    "example": true
+                "finalidade_emissao": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "habilita_nfe": {
-                    "type": "boolean",
-                    "example": true
+                "formas_pagamento": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NFePagamento"
+                    }
                 },
-                "habilita_nfse": {
-                    "type": "boolean",
-                    "example": true
+                "indicador_inscricao_estadual_destinatario": {
+                    "type": "integer",
+                    "example": 9
                 },
-                "habilita_nfsen_producao": {
-                    "type": "boolean",
-                    "example": true
+                "inscricao_estadual_destinatario": {
+                    "type": "string"
                 },
-                "inscricao_municipal": {
-                    "description": "Must be string to preserve leading zeros",
+                "inscricao_estadual_emitente": {
                     "type": "string",
-                    "example": "0046532"
+                    "example": "1234567890"
                 },
-                "login_responsavel": {
-                    "type": "string",
-                    "example": "usuario@example.com"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NFeItem"
+                    }
                 },
-                "logradouro": {
+                "local_destino": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "logradouro_destinatario": {
                     "type": "string",
                     "example": "Rua João da Silva"
                 },
-                "municipio": {
+                "modalidade_frete": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "municipio_destinatario": {
                     "type": "string",
                     "example": "Curitiba"
                 },
-                "nome": {
+                "natureza_operacao": {
                     "type": "string",
-                    "example": "Nome da empresa Ltda"
+                    "example": "Venda de mercadoria"
                 },
-                "nome_fantasia": {
+                "nome_destinatario": {
+                    "description": "Destinatário",
                     "type": "string",
-                    "example": "Nome Fantasia"
+                    "example": "Acme Ltda"
                 },
-                "nome_responsavel": {
+                "numero_destinatario": {
                     "type": "string",
-                    "example": "Fulano de Tal"
+                    "example": "153"
                 },
-                "numero": {
-                    "type": "integer",
-                    "example": 153
+                "pais_destinatario": {
+                    "type": "string",
+                    "example": "Brasil"
                 },
-                "proximo_numero_cte_producao": {
+                "presenca_comprador": {
                     "type": "integer",
                     "example": 1
                 },
-                "proximo_numero_mdfe_producao": {
-                    "type": "integer",
-                    "example": 1
+                "telefone_destinatario": {
+                    "type": "string"
                 },
-                "proximo_numero_nfce_producao": {
+                "tipo_documento": {
                     "type": "integer",
                     "example": 1
                 },
-                "proximo_numero_nfe_producao": {
-                    "type": "integer",
-                    "example": 1
+                "uf_destinatario": {
+                    "type": "string",
+                    "example": "PR"
                 },
-                "proximo_numero_nfse_producao": {
-                    "type": "integer",
-                    "example": 1
+                "valor_frete": {
+                    "type": "number",
+                    "example": 0
                 },
-                "proximo_numero_nfsen_producao": {
-                    "type": "integer",
-                    "example": 1
+                "valor_produtos": {
+                    "description": "Totais e frete",
+                    "type": "number",
+                    "example": 150
                 },
-                "regime_tributario": {
+                "valor_seguro": {
+                    "type": "number",
+                    "example": 0
+                },
+                "valor_total": {
+                    "type": "number",
+                    "example": 150
+                }
+            }
+        },
+        "model.NFeResponse": {
+            "type": "object",
+            "properties": {
+                "caminho_danfe": {
+                    "type": "string"
+                },
+                "caminho_pdf_carta_correcao": {
+                    "type": "string"
+                },
+                "caminho_xml_cancelamento": {
+                    "type": "string"
+                },
+                "caminho_xml_carta_correcao": {
+                    "type": "string"
+                },
+                "caminho_xml_nota_fiscal": {
+                    "type": "string"
+                },
+                "chave_nfe": {
+                    "type": "string",
+                    "example": "NFe41250110964044000164550010000000011000000016"
+                },
+                "cnpj_emitente": {
+                    "type": "string",
+                    "example": "10964044000164"
+                },
+                "mensagem_sefaz": {
+                    "type": "string",
+                    "example": "Autorizado o uso da NF-e"
+                },
+                "numero": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "numero_carta_correcao": {
                     "type": "integer",
                     "example": 1
                 },
-                "senha_certificado": {
+                "ref": {
                     "type": "string",
-                    "example": "123456"
+                    "example": "nfe-2025-0001"
                 },
-                "senha_responsavel": {
+                "serie": {
                     "type": "string",
-                    "example": "senha123"
-                },
-                "senha_responsavel_preenchida": {
-                    "type": "boolean",
-                    "example": false
+                    "example": "1"
                 },
-                "telefone": {
+                "status": {
                     "type": "string",
-                    "example": "4130333333"
+                    "example": "autorizado"
                 },
-                "uf": {
+                "status_sefaz": {
                     "type": "string",
-                    "example": "PR"
+                    "example": "100"
                 }
             }
         },
-        "model.FocusMunicipioResponse": {
+        "model.ReconciliationIssue": {
             "type": "object",
             "properties": {
-                "codigo_cnae_obrigatorio_nfse": {
-                    "type": "boolean"
+                "cnpj": {
+                    "type": "string",
+                    "example": "61453926000127"
                 },
-                "codigo_municipio": {
+                "company_id": {
                     "type": "string"
                 },
-                "codigo_tributario_municipio_obrigatorio_nfse": {
+                "detail": {
+                    "type": "string"
+                },
+                "fix_error": {
+                    "type": "string"
+                },
+                "fixed": {
                     "type": "boolean"
                 },
-                "cpf_cnpj_obrigatorio_nfse": {
+                "focus_company_id": {
+                    "type": "string",
+                    "example": "170571"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "missing_link"
+                }
+            }
+        },
+        "model.ReconciliationReport": {
+            "type": "object",
+            "properties": {
+                "auto_fix": {
                     "type": "boolean"
                 },
-                "data_previsao_reimplementacao_nfse": {
+                "companies": {
+                    "type": "integer",
+                    "example": 118
+                },
+                "environment": {
+                    "type": "string",
+                    "example": "producao"
+                },
+                "error": {
+                    "description": "Error: motivo da interrupção (relatório parcial) ou de uma verificação que não pôde ser feita.",
                     "type": "string"
                 },
-                "endereco_obrigatorio_nfse": {
-                    "type": "boolean"
+                "finished_at": {
+                    "type": "string"
                 },
-                "item_lista_servico_obrigatorio_nfse": {
-                    "type": "boolean"
+                "fixed": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "nfse_habilitada": {
-                    "type": "boolean"
+                "focus_empresas": {
+                    "type": "integer",
+                    "example": 120
                 },
-                "nome_municipio": {
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReconciliationIssue"
+                    }
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "supabase.FocusDocument": {
+            "type": "object",
+            "properties": {
+                "archive_attempts": {
+                    "type": "integer"
+                },
+                "archive_error": {
                     "type": "string"
                 },
-                "nome_uf": {
+                "archived_at": {
                     "type": "string"
                 },
-                "possui_ambiente_homologacao_nfse": {
-                    "type": "boolean"
+                "caminho_pdf": {
+                    "type": "string"
                 },
-                "possui_cancelamento_nfse": {
-                    "type": "boolean"
+                "caminho_xml": {
+                    "type": "string"
                 },
-                "provedor_nfse": {
+                "chave": {
                     "type": "string"
                 },
-                "requer_certificado_nfse": {
-                    "type": "boolean"
+                "codigo_verificacao": {
+                    "type": "string"
                 },
-                "sigla_uf": {
+                "company_id": {
                     "type": "string"
                 },
-                "status_nfse": {
+                "created_at": {
                     "type": "string"
                 },
-                "ultima_emissao_nfse": {
+                "document_type": {
+                    "type": "string"
+                },
+                "environment": {
+                    "type": "string"
+                },
+                "mensagem": {
+                    "type": "string"
+                },
+                "numero": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "pdf_storage_path": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "response": {
+                    "type": "object"
+                },
+                "serie": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "xml_storage_path": {
+                    "type": "string"
+                }
+            }
+        },
+        "supabase.FocusDocumentEvent": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "document_type": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "mensagem": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "status_anterior": {
                     "type": "string"
                 }
             }
@@ -0,0 +1,173 @@
+// Package archive copia para o Supabase Storage os arquivos (XML e PDF) dos documentos emitidos via Focus.
+//
+// Os caminhos devolvidos pela Focus (caminho_xml_nota_fiscal, caminho_danfe, url_danfse...) não servem para
+// guarda de longo prazo: a legislação exige manter o XML por anos. O Archiver varre focus_documents
+// periodicamente, baixa os arquivos dos documentos autorizados/cancelados com o token da empresa e grava os
+// caminhos no Storage em xml_storage_path / pdf_storage_path.
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"path"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/supabase"
+)
+
+const (
+	// Documentos processados por varredura.
+	batchSize = 50
+	// Depois de maxAttempts falhas o documento sai da fila (archive_error guarda o último motivo).
+	maxAttempts = 5
+	// Tamanho máximo de um arquivo baixado da Focus.
+	maxFileSize = 20 << 20
+)
+
+type Archiver struct {
+	focus    *focus.Client
+	bucket   string
+	interval time.Duration
+}
+
+// NewArchiver cria o arquivador. focusClient precisa resolver o token das empresas (ForCompany).
+func NewArchiver(focusClient *focus.Client, bucket string, interval time.Duration) *Archiver {
+	return &Archiver{focus: focusClient, bucket: bucket, interval: interval}
+}
+
+// Run varre focus_documents a cada intervalo até ctx ser cancelado.
+func (a *Archiver) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := a.RunOnce(ctx); err != nil {
+			log.Printf("[archive] erro ao listar documentos para arquivar: %v", err)
+		} else if n > 0 {
+			log.Printf("[archive] %d documento(s) arquivado(s) no bucket %s", n, a.bucket)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce arquiva um lote de documentos pendentes e devolve quantos foram arquivados.
+// Falhas por documento são registradas em archive_attempts / archive_error e não interrompem o lote.
+func (a *Archiver) RunOnce(ctx context.Context) (int, error) {
+	docs, err := supabase.ListFocusDocumentsToArchive(maxAttempts, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	archived := 0
+	for _, doc := range docs {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := a.archive(ctx, doc); err != nil {
+			log.Printf("[archive] %s ref=%s (company_id=%s) não arquivado: %v", doc.DocumentType, doc.Ref, doc.CompanyID, err)
+			if markErr := supabase.MarkFocusDocumentArchiveFailed(doc, err.Error()); markErr != nil {
+				log.Printf("[archive] erro ao registrar falha (%s ref=%s): %v", doc.DocumentType, doc.Ref, markErr)
+			}
+			continue
+		}
+		archived++
+	}
+	return archived, nil
+}
+
+func (a *Archiver) archive(ctx context.Context, doc supabase.FocusDocument) error {
+	// o token salvo é o do ambiente em que a empresa está integrada hoje: documentos emitidos no outro
+	// ambiente (antes de uma reintegração) não podem ser baixados com ele.
+	if env, ok := focus.ParseEnvironment(doc.Environment); ok {
+		ctx = focus.WithRequestedEnvironment(ctx, env)
+	}
+	fc, err := a.focus.ForCompany(ctx, doc.CompanyID)
+	var mismatch *focus.EnvironmentMismatchError
+	if errors.As(err, &mismatch) {
+		return fmt.Errorf("documento emitido em %s, mas a empresa está integrada em %s: sem token para baixar os arquivos", mismatch.Requested, mismatch.Company)
+	}
+	if err != nil {
+		return fmt.Errorf("credenciais da empresa: %w", err)
+	}
+
+	xmlPath, err := a.copy(ctx, fc, doc, doc.CaminhoXML, "xml")
+	if err != nil {
+		return fmt.Errorf("xml: %w", err)
+	}
+	var pdfPath string
+	if doc.CaminhoPDF != "" {
+		if pdfPath, err = a.copy(ctx, fc, doc, doc.CaminhoPDF, "pdf"); err != nil {
+			return fmt.Errorf("pdf: %w", err)
+		}
+	}
+
+	return supabase.MarkFocusDocumentArchived(doc, xmlPath, pdfPath)
+}
+
+// copy baixa o arquivo da Focus e o grava no Storage, devolvendo o caminho do objeto.
+// A extensão segue o Content-Type devolvido (o "PDF" de algumas prefeituras é uma página HTML).
+func (a *Archiver) copy(ctx context.Context, fc *focus.Client, doc supabase.FocusDocument, caminho, kind string) (string, error) {
+	resp, err := fc.DownloadArquivo(ctx, caminho)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFileSize+1))
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return "", focus.ParseAPIError(resp.StatusCode, data)
+	}
+	if len(data) > maxFileSize {
+		return "", fmt.Errorf("arquivo maior que %d bytes", maxFileSize)
+	}
+
+	contentType, ext := fileType(resp.Header.Get("Content-Type"), kind)
+	objectPath := objectPath(doc, kind, ext)
+	if err := supabase.UploadFile(a.bucket, objectPath, contentType, bytes.NewReader(data)); err != nil {
+		return "", err
+	}
+	return objectPath, nil
+}
+
+// objectPath: {company_id}/{document_type}/{environment}/{AAAA-MM}/{ref}-{kind}.{ext}, com o mês da emissão.
+func objectPath(doc supabase.FocusDocument, kind, ext string) string {
+	emitido := time.Now().UTC()
+	if doc.CreatedAt != nil {
+		emitido = doc.CreatedAt.UTC()
+	}
+	env := doc.Environment
+	if env == "" {
+		env = string(focus.EnvProducao)
+	}
+	return path.Join(doc.CompanyID, doc.DocumentType, env, emitido.Format("2006-01"), doc.Ref+"-"+kind+"."+ext)
+}
+
+func fileType(header, kind string) (contentType, ext string) {
+	mediaType, _, _ := mime.ParseMediaType(header)
+	switch mediaType {
+	case "application/pdf":
+		return mediaType, "pdf"
+	case "text/html":
+		return mediaType, "html"
+	case "application/xml", "text/xml":
+		return mediaType, "xml"
+	}
+	if kind == "xml" {
+		return "application/xml", "xml"
+	}
+	return "application/pdf", "pdf"
+}
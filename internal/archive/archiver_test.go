@@ -0,0 +1,40 @@
+package archive_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/archive"
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/focus/focustest"
+	"github.com/seuuser/focus-integration-service/internal/supabase/supabasetest"
+)
+
+func TestRunOnceSkipsDocumentsFromAnotherEnvironment(t *testing.T) {
+	db := supabasetest.Start(t)
+	db.Insert("focus_documents", supabasetest.Row{
+		"company_id": "c1", "document_type": "nfe", "ref": "nfe-1", "environment": "homologacao",
+		"status": "autorizado", "caminho_xml": "/arquivos/nfe/nfe-1.xml", "archive_attempts": 0,
+	})
+	srv := focustest.NewServer()
+	defer srv.Close()
+
+	// a empresa foi reintegrada em produção depois da emissão em homologação
+	resolver := focus.NewCachingTokenResolver(func(context.Context, string) (focus.CompanyCredentials, error) {
+		return focus.CompanyCredentials{FocusCompanyID: "1", Token: "token-producao", Environment: focus.EnvProducao}, nil
+	}, time.Minute)
+	client := focus.NewClient(srv.URL, "token", focus.WithHomologacao(srv.URL, "token-homologacao"), focus.WithTokenResolver(resolver))
+
+	if n, err := archive.NewArchiver(client, "documentos", time.Hour).RunOnce(context.Background()); err != nil || n != 0 {
+		t.Fatalf("o documento não deveria ser arquivado: n=%d err=%v", n, err)
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("nenhum download deveria ir para a Focus, vieram %d", n)
+	}
+	doc := db.Rows("focus_documents")[0]
+	if msg, _ := doc["archive_error"].(string); !strings.Contains(msg, "integrada em producao") || doc["archived_at"] != nil {
+		t.Fatalf("a falha deveria explicar a troca de ambiente: %v", doc)
+	}
+}
@@ -0,0 +1,192 @@
+// Package certmonitor avisa antes que o certificado digital (A1) de uma empresa vença.
+//
+// As datas do certificado são gravadas por UpdateCertificateDatesForCompany a cada cadastro/atualização
+// na Focus, mas ninguém olha para elas até a emissão começar a falhar. O Monitor varre as datas
+// periodicamente e, quando um certificado entra numa janela (30/15/7/1 dias, configurável), grava o aviso
+// em focus_certificate_alerts (lido por vw_company_warnings) e o envia pelos notificadores configurados.
+// Cada janela é avisada uma única vez por certificado.
+package certmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/supabase"
+)
+
+// Depois de maxNotifyAttempts falhas o aviso deixa de ser reenviado (notify_error guarda o último motivo).
+const maxNotifyAttempts = 5
+
+// Fuso das datas de validade: o Supabase guarda timestamp sem fuso, no horário de Brasília.
+var brasilia = time.FixedZone("BRT", -3*60*60)
+
+type Monitor struct {
+	windows   []int
+	interval  time.Duration
+	notifiers []Notifier
+	now       func() time.Time
+}
+
+// NewMonitor cria o monitor. windows são as antecedências, em dias, em que o aviso é dado.
+func NewMonitor(windows []int, interval time.Duration, notifiers ...Notifier) *Monitor {
+	ws := make([]int, 0, len(windows))
+	for _, w := range windows {
+		if w >= 0 {
+			ws = append(ws, w)
+		}
+	}
+	sort.Ints(ws)
+	return &Monitor{windows: ws, interval: interval, notifiers: notifiers, now: time.Now}
+}
+
+// Run varre os certificados a cada intervalo até ctx ser cancelado.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := m.RunOnce(ctx); err != nil {
+			log.Printf("[certmonitor] erro ao verificar certificados: %v", err)
+		} else if n > 0 {
+			log.Printf("[certmonitor] %d aviso(s) de vencimento de certificado enviado(s)", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce verifica os certificados de todas as empresas e devolve quantos avisos foram enviados.
+// Falhas por empresa são registradas no aviso e não interrompem a varredura.
+func (m *Monitor) RunOnce(ctx context.Context) (int, error) {
+	certs, err := supabase.ListCertificateDates()
+	if err != nil {
+		return 0, err
+	}
+
+	current := make(map[string]time.Time, len(certs))
+	for _, cd := range certs {
+		if cd.ExpirationDate != nil {
+			current[cd.CompanyID] = *cd.ExpirationDate
+		}
+	}
+	m.resolveRenewed(current)
+
+	today := dateOf(m.now().In(brasilia))
+	sent := 0
+	for companyID, expiration := range current {
+		if ctx.Err() != nil {
+			break
+		}
+		daysLeft := int(dateOf(expiration).Sub(today).Hours() / 24)
+		window, ok := m.window(daysLeft)
+		if !ok {
+			continue
+		}
+
+		created, existing, err := supabase.ClaimCertificateAlert(supabase.CertificateAlert{
+			CompanyID:      companyID,
+			ExpirationDate: expiration,
+			WindowDays:     window,
+			DaysLeft:       daysLeft,
+		})
+		if err != nil {
+			log.Printf("[certmonitor] erro ao gravar aviso (company_id=%s): %v", companyID, err)
+			continue
+		}
+		alert := created
+		if alert == nil {
+			if existing.NotifiedAt != nil || existing.NotifyAttempts >= maxNotifyAttempts {
+				continue
+			}
+			alert = existing
+		}
+
+		if err := m.notify(ctx, *alert, daysLeft); err != nil {
+			log.Printf("[certmonitor] aviso de certificado não enviado (company_id=%s, janela %d dias): %v", companyID, window, err)
+			if markErr := supabase.MarkCertificateAlertNotified(*alert, err.Error()); markErr != nil {
+				log.Printf("[certmonitor] erro ao registrar falha do aviso (company_id=%s): %v", companyID, markErr)
+			}
+			continue
+		}
+		if err := supabase.MarkCertificateAlertNotified(*alert, ""); err != nil {
+			log.Printf("[certmonitor] erro ao registrar aviso enviado (company_id=%s): %v", companyID, err)
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// window devolve a menor janela que contém daysLeft (certificados já vencidos não entram).
+func (m *Monitor) window(daysLeft int) (int, bool) {
+	if daysLeft < 0 {
+		return 0, false
+	}
+	for _, w := range m.windows {
+		if daysLeft <= w {
+			return w, true
+		}
+	}
+	return 0, false
+}
+
+// notify envia o aviso por todos os notificadores; o erro junta as falhas de cada um.
+func (m *Monitor) notify(ctx context.Context, a supabase.CertificateAlert, daysLeft int) error {
+	if len(m.notifiers) == 0 {
+		return nil
+	}
+
+	alert := Alert{
+		CompanyID:      a.CompanyID,
+		ExpirationDate: dateOf(a.ExpirationDate),
+		DaysLeft:       daysLeft,
+		WindowDays:     a.WindowDays,
+	}
+	if cnpj, legalName, err := supabase.GetCompanyCNPJ(a.CompanyID); err != nil {
+		log.Printf("[certmonitor] erro ao ler dados da empresa (company_id=%s): %v", a.CompanyID, err)
+	} else {
+		alert.CNPJ, alert.LegalName = cnpj, legalName
+	}
+
+	var errs []string
+	for _, n := range m.notifiers {
+		if err := n.Notify(ctx, alert); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", n.Name(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// resolveRenewed encerra os avisos de certificados que não são mais o certificado atual da empresa.
+func (m *Monitor) resolveRenewed(current map[string]time.Time) {
+	open, err := supabase.ListOpenCertificateAlerts()
+	if err != nil {
+		log.Printf("[certmonitor] erro ao listar avisos abertos: %v", err)
+		return
+	}
+	for _, a := range open {
+		if exp, ok := current[a.CompanyID]; ok && exp.Equal(a.ExpirationDate) {
+			continue
+		}
+		if err := supabase.ResolveCertificateAlert(a.ID); err != nil {
+			log.Printf("[certmonitor] erro ao encerrar aviso (company_id=%s): %v", a.CompanyID, err)
+		}
+	}
+}
+
+// dateOf devolve o dia (meia-noite) da data, mantendo o ano/mês/dia em que ela foi escrita.
+func dateOf(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, time.UTC)
+}
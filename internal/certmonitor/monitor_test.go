@@ -0,0 +1,57 @@
+package certmonitor_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/certmonitor"
+	"github.com/seuuser/focus-integration-service/internal/supabase/supabasetest"
+)
+
+func TestRunOnceNotifiesEachWindowOnceWithCompanyData(t *testing.T) {
+	db := supabasetest.Start(t)
+	db.Unique("focus_certificate_alerts", "company_id", "expiration_date", "window_days")
+	db.Insert("companies", supabasetest.Row{"id": "c1", "cnpj": "11.222.333/0001-81", "legal_name": "Empresa Teste LTDA"})
+	expiration := time.Now().AddDate(0, 0, 10).Format("2006-01-02T15:04:05")
+	db.HandleRPC("rpc_service_list_certificate_dates", func(map[string]any) (any, error) {
+		return []map[string]any{{"company_id": "c1", "expiration_date": expiration}}, nil
+	})
+
+	var mu sync.Mutex
+	var alerts []map[string]any
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		var alert map[string]any
+		_ = json.Unmarshal(b, &alert)
+		mu.Lock()
+		alerts = append(alerts, alert)
+		mu.Unlock()
+	}))
+	t.Cleanup(hook.Close)
+
+	m := certmonitor.NewMonitor([]int{30, 15, 7, 1}, time.Hour, certmonitor.NewWebhookNotifier(hook.URL, "segredo"))
+	if n, err := m.RunOnce(context.Background()); err != nil || n != 1 {
+		t.Fatalf("esperava um aviso enviado, n=%d err=%v", n, err)
+	}
+	if n, err := m.RunOnce(context.Background()); err != nil || n != 0 {
+		t.Fatalf("a mesma janela não deveria ser avisada de novo, n=%d err=%v", n, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(alerts) != 1 {
+		t.Fatalf("esperava um webhook, vieram %d", len(alerts))
+	}
+	if alerts[0]["cnpj"] != "11222333000181" || alerts[0]["legal_name"] != "Empresa Teste LTDA" || alerts[0]["window_days"] != float64(15) {
+		t.Fatalf("aviso sem os dados da empresa: %v", alerts[0])
+	}
+	if rows := db.Rows("focus_certificate_alerts"); len(rows) != 1 || rows[0]["notified_at"] == nil {
+		t.Fatalf("o aviso deveria ser gravado como enviado: %v", rows)
+	}
+}
@@ -0,0 +1,195 @@
+package certmonitor
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Alert é o aviso enviado aos notificadores: o certificado da empresa vence em DaysLeft dias
+// (dentro da janela WindowDays).
+type Alert struct {
+	CompanyID      string    `json:"company_id"`
+	CNPJ           string    `json:"cnpj,omitempty"`
+	LegalName      string    `json:"legal_name,omitempty"`
+	ExpirationDate time.Time `json:"expiration_date"`
+	DaysLeft       int       `json:"days_left"`
+	WindowDays     int       `json:"window_days"`
+}
+
+func (a Alert) subject() string {
+	name := a.LegalName
+	if name == "" {
+		name = a.CNPJ
+	}
+	if name == "" {
+		name = a.CompanyID
+	}
+	if a.DaysLeft == 0 {
+		return fmt.Sprintf("Certificado digital de %s vence hoje", name)
+	}
+	return fmt.Sprintf("Certificado digital de %s vence em %d dia(s)", name, a.DaysLeft)
+}
+
+// Notifier entrega um aviso de vencimento (e-mail, webhook...). Um erro faz o aviso ser reenviado
+// na próxima varredura do monitor.
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, a Alert) error
+}
+
+// SMTPConfig configura o envio de e-mail. Username vazio envia sem autenticação (ex: relay interno ou
+// MailHog em desenvolvimento).
+type SMTPConfig struct {
+	Addr     string // host:porta
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// SMTPNotifier envia o aviso por e-mail, com STARTTLS quando o servidor oferece.
+type SMTPNotifier struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPNotifier(cfg SMTPConfig) *SMTPNotifier {
+	return &SMTPNotifier{cfg: cfg}
+}
+
+func (n *SMTPNotifier) Name() string { return "email" }
+
+func (n *SMTPNotifier) Notify(ctx context.Context, a Alert) error {
+	host, _, err := net.SplitHostPort(n.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("endereço SMTP inválido: %w", err)
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", n.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar no SMTP: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else {
+		_ = conn.SetDeadline(time.Now().Add(30 * time.Second))
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("erro ao iniciar sessão SMTP: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("erro no STARTTLS: %w", err)
+		}
+	}
+	if n.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, host)); err != nil {
+			return fmt.Errorf("erro na autenticação SMTP: %w", err)
+		}
+	}
+	if err := c.Mail(n.cfg.From); err != nil {
+		return err
+	}
+	for _, to := range n.cfg.To {
+		if err := c.Rcpt(to); err != nil {
+			return fmt.Errorf("destinatário %s recusado: %w", to, err)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(n.message(a)); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (n *SMTPNotifier) message(a Alert) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.cfg.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", a.subject()))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+
+	fmt.Fprintf(&b, "O certificado digital da empresa abaixo vence em %s.\r\n\r\n", a.ExpirationDate.Format("02/01/2006"))
+	if a.LegalName != "" {
+		fmt.Fprintf(&b, "Empresa: %s\r\n", a.LegalName)
+	}
+	if a.CNPJ != "" {
+		fmt.Fprintf(&b, "CNPJ: %s\r\n", a.CNPJ)
+	}
+	fmt.Fprintf(&b, "ID: %s\r\n", a.CompanyID)
+	fmt.Fprintf(&b, "Dias restantes: %d\r\n\r\n", a.DaysLeft)
+	b.WriteString("Após o vencimento, a emissão de notas fiscais da empresa é recusada. Providencie a renovação e envie o novo certificado.\r\n")
+	return b.Bytes()
+}
+
+// WebhookNotifier envia o aviso como JSON (POST) para uma URL externa. O segredo vai no header
+// X-Certificate-Alert-Secret.
+type WebhookNotifier struct {
+	url    string
+	secret string
+	http   *http.Client
+}
+
+func NewWebhookNotifier(url, secret string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, secret: secret, http: &http.Client{Timeout: 15 * time.Second}}
+}
+
+func (n *WebhookNotifier) Name() string { return "webhook" }
+
+func (n *WebhookNotifier) Notify(ctx context.Context, a Alert) error {
+	body, err := json.Marshal(struct {
+		Event   string `json:"event"`
+		Message string `json:"message"`
+		Alert
+	}{Event: "certificate_expiring", Message: a.subject(), Alert: a})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.secret != "" {
+		req.Header.Set("X-Certificate-Alert-Secret", n.secret)
+	}
+
+	resp, err := n.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao enviar webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook respondeu HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -14,6 +16,68 @@ type Config struct {
 	CorsAllowedOrigins []string
 	FocusURL           string
 	FocusToken         string
+
+	// Ambiente de homologação (sandbox) da Focus, selecionado por requisição.
+	FocusHomologacaoURL   string
+	FocusHomologacaoToken string
+
+	// Tempo de cache em memória do token Focus por empresa (focus_integration.token_focus_company).
+	FocusCompanyTokenCacheTTL time.Duration
+
+	// Auditoria das chamadas à Focus em focus_request_logs.
+	FocusAuditEnabled bool
+
+	// Retry de chamadas idempotentes à Focus (GET/PUT/DELETE).
+	FocusRetryMaxAttempts int
+	FocusRetryBaseDelay   time.Duration
+	FocusRetryMaxDelay    time.Duration
+
+	// Limitador local (token bucket) compartilhado por todas as chamadas à Focus.
+	// FocusRateLimitPerMinute = 0 desliga o limitador.
+	FocusRateLimitPerMinute    int
+	FocusRateLimitWriteReserve float64
+
+	// Webhooks recebidos da Focus (POST /webhooks/focus): segredo compartilhado e o header que o carrega.
+	// FocusWebhookSecret vazio desliga o endpoint.
+	FocusWebhookSecret       string
+	FocusWebhookSecretHeader string
+
+	// URL pública do POST /webhooks/focus, cadastrada na Focus como gatilho de cada empresa nova,
+	// para os eventos de FocusWebhookEvents. FocusWebhookURL vazia desliga o cadastro automático.
+	FocusWebhookURL    string
+	FocusWebhookEvents []string
+
+	// Arquivamento de XML/PDF dos documentos no Supabase Storage.
+	// FocusArchiveInterval = 0 desliga o arquivador.
+	FocusArchiveBucket   string
+	FocusArchiveInterval time.Duration
+
+	// Intervalo do worker que refaz as gravações pendentes em focus_integration_outbox.
+	// FocusOutboxInterval = 0 desliga o worker (os passos continuam sendo executados na requisição).
+	FocusOutboxInterval time.Duration
+
+	// Reconciliação periódica entre companies / focus_integration e as empresas da Focus.
+	// FocusReconcileInterval = 0 desliga a execução agendada (POST /v2/reconciliation continua disponível).
+	FocusReconcileInterval    time.Duration
+	FocusReconcileAutoFix     bool
+	FocusReconcileEnvironment string
+
+	// Monitor de vencimento do certificado digital: janelas de aviso em dias e notificadores.
+	// FocusCertMonitorInterval = 0 desliga o monitor. E-mail exige SMTP addr + destinatários; webhook, a URL.
+	FocusCertMonitorInterval    time.Duration
+	FocusCertAlertWindows       []int
+	FocusCertAlertSMTPAddr      string
+	FocusCertAlertSMTPUser      string
+	FocusCertAlertSMTPPassword  string
+	FocusCertAlertEmailFrom     string
+	FocusCertAlertEmailTo       []string
+	FocusCertAlertWebhookURL    string
+	FocusCertAlertWebhookSecret string
+
+	// Circuit breaker por família de endpoints da Focus.
+	// FocusBreakerFailureThreshold = 0 desliga o breaker.
+	FocusBreakerFailureThreshold int
+	FocusBreakerOpenTimeout      time.Duration
 }
 
 func Load() Config {
@@ -36,11 +100,82 @@ func Load() Config {
 
 	token := strings.TrimSpace(os.Getenv("FOCUS_API_TOKEN"))
 
+	webhookHeader := strings.TrimSpace(os.Getenv("FOCUS_WEBHOOK_SECRET_HEADER"))
+	if webhookHeader == "" {
+		webhookHeader = "X-Focus-Webhook-Secret"
+	}
+
+	webhookEvents := parseCSV(os.Getenv("FOCUS_WEBHOOK_EVENTS"))
+	if len(webhookEvents) == 0 {
+		webhookEvents = []string{"nfse", "nfe"}
+	}
+
+	archiveBucket := strings.TrimSpace(os.Getenv("FOCUS_ARCHIVE_BUCKET"))
+	if archiveBucket == "" {
+		archiveBucket = "focus-documents"
+	}
+
+	certAlertWindows := parseIntCSV(os.Getenv("FOCUS_CERT_ALERT_WINDOWS"))
+	if len(certAlertWindows) == 0 {
+		certAlertWindows = []int{30, 15, 7, 1}
+	}
+
+	homologacaoURL := strings.TrimSpace(os.Getenv("FOCUS_HOMOLOGACAO_URL"))
+	if homologacaoURL == "" {
+		homologacaoURL = "https://homologacao.focusnfe.com.br"
+	}
+	homologacaoToken := strings.TrimSpace(os.Getenv("FOCUS_HOMOLOGACAO_API_TOKEN"))
+	if homologacaoToken == "" {
+		// a Focus aceita o mesmo token de revenda nos dois ambientes
+		homologacaoToken = token
+	}
+
 	return Config{
 		Port:               port,
 		CorsAllowedOrigins: origins,
 		FocusURL:           focusURL,
 		FocusToken:         token,
+
+		FocusHomologacaoURL:   homologacaoURL,
+		FocusHomologacaoToken: homologacaoToken,
+
+		FocusCompanyTokenCacheTTL: parseMillis(os.Getenv("FOCUS_COMPANY_TOKEN_CACHE_TTL_MS"), 5*time.Minute),
+
+		FocusAuditEnabled: parseBool(os.Getenv("FOCUS_AUDIT_ENABLED"), true),
+
+		FocusRetryMaxAttempts: parseInt(os.Getenv("FOCUS_RETRY_MAX_ATTEMPTS"), 3),
+		FocusRetryBaseDelay:   parseMillis(os.Getenv("FOCUS_RETRY_BASE_DELAY_MS"), 500*time.Millisecond),
+		FocusRetryMaxDelay:    parseMillis(os.Getenv("FOCUS_RETRY_MAX_DELAY_MS"), 10*time.Second),
+
+		FocusRateLimitPerMinute:    parseInt(os.Getenv("FOCUS_RATE_LIMIT_PER_MINUTE"), 100),
+		FocusRateLimitWriteReserve: float64(parseInt(os.Getenv("FOCUS_RATE_LIMIT_WRITE_RESERVE_PERCENT"), 20)) / 100,
+
+		FocusWebhookSecret:       strings.TrimSpace(os.Getenv("FOCUS_WEBHOOK_SECRET")),
+		FocusWebhookSecretHeader: webhookHeader,
+		FocusWebhookURL:          strings.TrimSpace(os.Getenv("FOCUS_WEBHOOK_URL")),
+		FocusWebhookEvents:       webhookEvents,
+
+		FocusArchiveBucket:   archiveBucket,
+		FocusArchiveInterval: parseMillis(os.Getenv("FOCUS_ARCHIVE_INTERVAL_MS"), time.Minute),
+
+		FocusOutboxInterval: parseMillis(os.Getenv("FOCUS_OUTBOX_INTERVAL_MS"), 30*time.Second),
+
+		FocusReconcileInterval:    parseMillis(os.Getenv("FOCUS_RECONCILE_INTERVAL_MS"), 6*time.Hour),
+		FocusReconcileAutoFix:     parseBool(os.Getenv("FOCUS_RECONCILE_AUTOFIX"), false),
+		FocusReconcileEnvironment: strings.TrimSpace(os.Getenv("FOCUS_RECONCILE_ENVIRONMENT")),
+
+		FocusCertMonitorInterval:    parseMillis(os.Getenv("FOCUS_CERT_MONITOR_INTERVAL_MS"), time.Hour),
+		FocusCertAlertWindows:       certAlertWindows,
+		FocusCertAlertSMTPAddr:      strings.TrimSpace(os.Getenv("FOCUS_CERT_ALERT_SMTP_ADDR")),
+		FocusCertAlertSMTPUser:      strings.TrimSpace(os.Getenv("FOCUS_CERT_ALERT_SMTP_USER")),
+		FocusCertAlertSMTPPassword:  os.Getenv("FOCUS_CERT_ALERT_SMTP_PASSWORD"),
+		FocusCertAlertEmailFrom:     strings.TrimSpace(os.Getenv("FOCUS_CERT_ALERT_EMAIL_FROM")),
+		FocusCertAlertEmailTo:       parseCSV(os.Getenv("FOCUS_CERT_ALERT_EMAIL_TO")),
+		FocusCertAlertWebhookURL:    strings.TrimSpace(os.Getenv("FOCUS_CERT_ALERT_WEBHOOK_URL")),
+		FocusCertAlertWebhookSecret: strings.TrimSpace(os.Getenv("FOCUS_CERT_ALERT_WEBHOOK_SECRET")),
+
+		FocusBreakerFailureThreshold: parseInt(os.Getenv("FOCUS_BREAKER_FAILURE_THRESHOLD"), 5),
+		FocusBreakerOpenTimeout:      parseMillis(os.Getenv("FOCUS_BREAKER_OPEN_TIMEOUT_MS"), 30*time.Second),
 	}
 }
 
@@ -73,4 +208,56 @@ func parseCSV(s string) []string {
 	return out
 }
 
+// parseIntCSV lê uma lista de inteiros (ex: "30,15,7,1"); valores inválidos são ignorados.
+func parseIntCSV(s string) []int {
+	var out []int
+	for _, p := range parseCSV(s) {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			log.Printf("[config] valor inválido %q ignorado", p)
+			continue
+		}
+		out = append(out, n)
+	}
+	return out
+}
 
+func parseInt(s string, def int) int {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return def
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		log.Printf("[config] valor inválido %q, usando padrão %d", s, def)
+		return def
+	}
+	return n
+}
+
+// parseMillis lê uma duração em milissegundos (ex: "500").
+func parseMillis(s string, def time.Duration) time.Duration {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return def
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		log.Printf("[config] valor inválido %q, usando padrão %s", s, def)
+		return def
+	}
+	return time.Duration(n) * time.Millisecond
+}
+
+func parseBool(s string, def bool) bool {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		log.Printf("[config] valor inválido %q, usando padrão %v", s, def)
+		return def
+	}
+	return b
+}
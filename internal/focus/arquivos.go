@@ -0,0 +1,43 @@
+package focus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DownloadArquivo baixa um arquivo de documento (XML, DANFE, DANFSE...) pelo caminho devolvido nas consultas
+// (caminho_xml_nota_fiscal, caminho_danfe, url_danfse...). Caminhos relativos e URLs da própria Focus usam
+// o token do Client (o da empresa, quando obtido por ForCompany); URLs externas, como o DANFSE no site da
+// prefeitura, são baixadas sem autenticação.
+func (c *Client) DownloadArquivo(ctx context.Context, caminho string) (*http.Response, error) {
+	caminho = strings.TrimSpace(caminho)
+	if caminho == "" {
+		return nil, fmt.Errorf("caminho do arquivo vazio")
+	}
+	if strings.HasPrefix(caminho, "/") {
+		return c.do(ctx, http.MethodGet, caminho, "", nil)
+	}
+
+	env := EnvironmentFromContext(ctx)
+	if c.company != nil {
+		env = c.company.Environment
+	}
+	if base := c.envs[env].baseURL; base != "" && strings.HasPrefix(caminho, base+"/") {
+		return c.do(ctx, http.MethodGet, strings.TrimPrefix(caminho, base), "", nil)
+	}
+	if !strings.HasPrefix(caminho, "https://") && !strings.HasPrefix(caminho, "http://") {
+		return nil, fmt.Errorf("caminho do arquivo inválido: %q", caminho)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, caminho, nil)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao baixar arquivo: %w", err)
+	}
+	return resp, nil
+}
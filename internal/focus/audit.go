@@ -0,0 +1,204 @@
+package focus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Campos mascarados nos corpos gravados pela auditoria (em qualquer nível do JSON).
+var redactedFields = map[string]bool{
+	"arquivo_certificado_base64": true,
+	"senha_certificado":          true,
+	"senha_responsavel":          true,
+	"token_producao":             true,
+	"token_homologacao":          true,
+	// segredo do /webhooks/focus, enviado no cadastro de gatilhos e devolvido pela Focus em /v2/hooks
+	"authorization": true,
+}
+
+const (
+	redactedValue = "***"
+	// maxAuditBody limita o tamanho de cada corpo gravado (listas grandes são truncadas).
+	maxAuditBody = 64 << 10
+)
+
+// AuditEntry descreve uma chamada HTTP feita à Focus, com corpos já mascarados.
+type AuditEntry struct {
+	CompanyID    string
+	Environment  Environment
+	Method       string
+	Path         string
+	Query        string
+	StatusCode   int
+	Latency      time.Duration
+	RequestBody  json.RawMessage
+	ResponseBody json.RawMessage
+	Error        string
+	CreatedAt    time.Time
+}
+
+// AuditSink recebe as entradas de auditoria. Não deve bloquear (ver NewAsyncAuditSink).
+type AuditSink func(AuditEntry)
+
+// AuditTransport é um http.RoundTripper que registra cada requisição enviada à Focus.
+type AuditTransport struct {
+	Base http.RoundTripper
+	Sink AuditSink
+}
+
+func (t *AuditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req.Body = io.NopCloser(bytes.NewReader(b))
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+
+	entry := AuditEntry{
+		CompanyID:   companyIDFromContext(req.Context()),
+		Environment: EnvironmentFromContext(req.Context()),
+		Method:      req.Method,
+		Path:        req.URL.Path,
+		Query:       req.URL.RawQuery,
+		Latency:     time.Since(start),
+		RequestBody: redactBody(reqBody),
+		CreatedAt:   start.UTC(),
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+		t.Sink(entry)
+		return nil, err
+	}
+
+	entry.StatusCode = resp.StatusCode
+	if isJSONResponse(resp) {
+		b, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(b))
+		if readErr != nil {
+			entry.Error = readErr.Error()
+		}
+		entry.ResponseBody = redactBody(b)
+	}
+
+	t.Sink(entry)
+	return resp, nil
+}
+
+func isJSONResponse(resp *http.Response) bool {
+	return strings.Contains(resp.Header.Get("Content-Type"), "json")
+}
+
+// redactBody devolve o corpo como JSON, com os campos sensíveis mascarados.
+// Corpos que não são JSON são gravados como string JSON.
+func redactBody(b []byte) json.RawMessage {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return nil
+	}
+
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return truncatedString(string(b))
+	}
+
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return nil
+	}
+	if len(out) > maxAuditBody {
+		return truncatedString(string(out))
+	}
+	return out
+}
+
+func redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			if redactedFields[k] {
+				if child != nil && child != "" {
+					t[k] = redactedValue
+				}
+				continue
+			}
+			t[k] = redactValue(child)
+		}
+	case []any:
+		for i, child := range t {
+			t[i] = redactValue(child)
+		}
+	}
+	return v
+}
+
+func truncatedString(s string) json.RawMessage {
+	if len(s) > maxAuditBody {
+		s = s[:maxAuditBody] + "…(truncado)"
+	}
+	b, _ := json.Marshal(s)
+	return b
+}
+
+type companyIDKey struct{}
+
+// WithCompanyID associa o companies.id ao context, para que a auditoria registre de qual empresa é a chamada.
+func WithCompanyID(ctx context.Context, companyID string) context.Context {
+	return context.WithValue(ctx, companyIDKey{}, companyID)
+}
+
+func companyIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(companyIDKey{}).(string)
+	return id
+}
+
+// WithAudit registra todas as chamadas do Client em sink.
+func WithAudit(sink AuditSink) Option {
+	return func(c *Client) {
+		c.http.Transport = &AuditTransport{Base: c.http.Transport, Sink: sink}
+	}
+}
+
+// NewAsyncAuditSink grava as entradas em background (uma goroutine), para não somar a latência
+// do destino (Supabase) às chamadas da Focus. Com a fila cheia, a entrada é descartada e logada.
+func NewAsyncAuditSink(write func(AuditEntry) error, buffer int) AuditSink {
+	if buffer <= 0 {
+		buffer = 256
+	}
+	queue := make(chan AuditEntry, buffer)
+
+	go func() {
+		for e := range queue {
+			if err := write(e); err != nil {
+				log.Printf("[focus] erro ao gravar auditoria (%s %s): %v", e.Method, e.Path, err)
+			}
+		}
+	}()
+
+	return func(e AuditEntry) {
+		select {
+		case queue <- e:
+		default:
+			log.Printf("[focus] fila de auditoria cheia, descartando registro %s %s (%d)", e.Method, e.Path, e.StatusCode)
+		}
+	}
+}
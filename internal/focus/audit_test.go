@@ -0,0 +1,72 @@
+package focus_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/focus/focustest"
+)
+
+func TestAuditRedactsHookAuthorization(t *testing.T) {
+	const secret = "segredo-do-webhook-123"
+
+	srv := focustest.NewServer()
+	defer srv.Close()
+
+	var mu sync.Mutex
+	var entries []focus.AuditEntry
+	client := focus.NewClient(srv.URL, "token", focus.WithAudit(func(e focus.AuditEntry) {
+		mu.Lock()
+		entries = append(entries, e)
+		mu.Unlock()
+	}))
+	ctx := context.Background()
+
+	body := `{"cnpj":"10964044000164","event":"nfe","url":"https://api.exemplo.com.br/webhooks/focus",` +
+		`"authorization":"` + secret + `","authorization_header":"X-Focus-Webhook-Secret"}`
+	echoed := false
+	for _, call := range []func() (*http.Response, error){
+		func() (*http.Response, error) { return client.CreateHook(ctx, []byte(body)) },
+		func() (*http.Response, error) { return client.ListHooks(ctx) },
+		func() (*http.Response, error) { return client.GetHook(ctx, "hook1") },
+		func() (*http.Response, error) { return client.DeleteHook(ctx, "hook1") },
+	} {
+		resp, err := call()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			t.Fatalf("HTTP %d: %s", resp.StatusCode, b)
+		}
+		// o chamador recebe o corpo original; só a auditoria é mascarada
+		if strings.Contains(string(b), secret) {
+			echoed = true
+		}
+	}
+	if !echoed {
+		t.Fatal("a Focus falsa deveria devolver authorization nas respostas de /v2/hooks")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(entries) != 4 {
+		t.Fatalf("esperava 4 registros de auditoria, veio %d", len(entries))
+	}
+	for _, e := range entries {
+		for _, b := range [][]byte{e.RequestBody, e.ResponseBody} {
+			if strings.Contains(string(b), secret) {
+				t.Errorf("%s %s: segredo gravado na auditoria: %s", e.Method, e.Path, b)
+			}
+		}
+		if !strings.Contains(string(e.RequestBody)+string(e.ResponseBody), `"authorization":"***"`) {
+			t.Errorf("%s %s: authorization deveria aparecer mascarado", e.Method, e.Path)
+		}
+	}
+}
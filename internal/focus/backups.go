@@ -0,0 +1,12 @@
+package focus
+
+import (
+	"context"
+	"net/http"
+)
+
+// ListBackups lista os backups mensais (zip de XMLs e de DANFEs) guardados pela Focus para o CNPJ.
+// Exige o token da empresa (ForCompany) ou o token principal.
+func (c *Client) ListBackups(ctx context.Context, cnpj14 string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, "/v2/backups/"+cnpj14+".json", "", nil)
+}
@@ -0,0 +1,177 @@
+package focus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen indica que o circuit breaker da família de endpoints está aberto
+// (a Focus vem falhando) e a chamada foi recusada sem ir à rede.
+var ErrCircuitOpen = errors.New("Focus indisponível (circuit breaker aberto)")
+
+// CircuitOpenError é devolvido quando o breaker recusa a chamada.
+type CircuitOpenError struct {
+	Family     string
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrCircuitOpen.Error(), e.Family)
+}
+
+func (e *CircuitOpenError) Unwrap() error { return ErrCircuitOpen }
+
+// RetryAfterSeconds arredonda RetryAfter para cima, no formato do header Retry-After.
+func (e *CircuitOpenError) RetryAfterSeconds() int {
+	return max(int(math.Ceil(e.RetryAfter.Seconds())), 1)
+}
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// BreakerConfig: após FailureThreshold falhas consecutivas (erro de rede ou 5xx) o breaker abre;
+// depois de OpenTimeout deixa passar uma chamada de prova (half-open) que decide se fecha ou reabre.
+type BreakerConfig struct {
+	FailureThreshold int
+	OpenTimeout      time.Duration
+}
+
+// WithCircuitBreaker liga um circuit breaker por família de endpoints (/v2/empresas, /v2/cnpjs, ...).
+// Em homologação as famílias são prefixadas com o ambiente (ex: "homologacao/empresas").
+func WithCircuitBreaker(cfg BreakerConfig) Option {
+	return func(c *Client) {
+		c.breakers = newBreakerSet(cfg)
+	}
+}
+
+type breaker struct {
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+type breakerSet struct {
+	mu  sync.Mutex
+	cfg BreakerConfig
+	m   map[string]*breaker
+	now func() time.Time
+}
+
+func newBreakerSet(cfg BreakerConfig) *breakerSet {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	return &breakerSet{cfg: cfg, m: map[string]*breaker{}, now: time.Now}
+}
+
+// endpointFamily extrai a família do path: "/v2/empresas/123" -> "empresas".
+func endpointFamily(path string) string {
+	p := strings.TrimPrefix(strings.TrimPrefix(path, "/v2/"), "/")
+	if i := strings.IndexAny(p, "/?"); i >= 0 {
+		p = p[:i]
+	}
+	return p
+}
+
+func (s *breakerSet) get(family string) *breaker {
+	b, ok := s.m[family]
+	if !ok {
+		b = &breaker{state: BreakerClosed}
+		s.m[family] = b
+	}
+	return b
+}
+
+// allow decide se a chamada pode seguir. Em half-open só uma chamada de prova passa por vez.
+func (s *breakerSet) allow(family string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.get(family)
+	switch b.state {
+	case BreakerOpen:
+		elapsed := s.now().Sub(b.openedAt)
+		if elapsed < s.cfg.OpenTimeout {
+			return &CircuitOpenError{Family: family, RetryAfter: s.cfg.OpenTimeout - elapsed}
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return &CircuitOpenError{Family: family, RetryAfter: time.Second}
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// record contabiliza o resultado de uma chamada liberada por allow.
+func (s *breakerSet) record(family string, resp *http.Response, err error) {
+	failed := err != nil || (resp != nil && resp.StatusCode >= 500)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.get(family)
+	b.probing = false
+
+	// cancelamento pelo chamador (ex: browser fechou a conexão) não diz nada sobre a Focus
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	if !failed {
+		b.state = BreakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state != BreakerOpen && (b.state == BreakerHalfOpen || b.failures >= s.cfg.FailureThreshold) {
+		log.Printf("[focus] circuit breaker %q aberto após %d falha(s) consecutiva(s); nova prova em %s", family, b.failures, s.cfg.OpenTimeout)
+		b.state = BreakerOpen
+		b.openedAt = s.now()
+	}
+}
+
+// states devolve o estado atual de cada família já usada.
+func (s *breakerSet) states() map[string]BreakerState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make(map[string]BreakerState, len(s.m))
+	for family, b := range s.m {
+		state := b.state
+		if state == BreakerOpen && s.now().Sub(b.openedAt) >= s.cfg.OpenTimeout {
+			state = BreakerHalfOpen
+		}
+		out[family] = state
+	}
+	return out
+}
+
+// BreakerStates expõe o estado dos circuit breakers por família (para /health).
+// Devolve nil quando o client não tem breaker configurado.
+func (c *Client) BreakerStates() map[string]BreakerState {
+	if c.breakers == nil {
+		return nil
+	}
+	return c.breakers.states()
+}
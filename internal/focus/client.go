@@ -5,27 +5,43 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"strings"
 	"time"
 )
 
 type Client struct {
-	baseURL string
-	token   string
-	http    *http.Client
+	envs     map[Environment]endpoint
+	http     *http.Client
+	retry    RetryPolicy
+	limiter  *RateLimiter
+	breakers *breakerSet
+
+	resolver TokenResolver
+	// company é preenchido nos Clients devolvidos por ForCompany.
+	company *CompanyCredentials
 }
 
-func NewClient(baseURL, token string) *Client {
+// Option configura o Client em NewClient.
+type Option func(*Client)
+
+func NewClient(baseURL, token string, opts ...Option) *Client {
 	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
 
-	return &Client{
-		baseURL: baseURL,
-		token:   strings.TrimSpace(token),
+	c := &Client{
+		envs: map[Environment]endpoint{
+			EnvProducao: {baseURL: baseURL, token: strings.TrimSpace(token)},
+		},
 		http: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		retry: DefaultRetryPolicy,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func (c *Client) CreateEmpresa(ctx context.Context, body []byte) (*http.Response, error) {
@@ -52,6 +68,10 @@ func (c *Client) GetCNPJ(ctx context.Context, cnpj14 string) (*http.Response, er
 	return c.do(ctx, http.MethodGet, "/v2/cnpjs/"+cnpj14, "", nil)
 }
 
+func (c *Client) GetCEP(ctx context.Context, cep8 string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, "/v2/ceps/"+cep8, "", nil)
+}
+
 func (c *Client) ListMunicipios(ctx context.Context, rawQuery string) (*http.Response, error) {
 	return c.do(ctx, http.MethodGet, "/v2/municipios", rawQuery, nil)
 }
@@ -76,19 +96,117 @@ func (c *Client) GetMunicipioCodigoTributario(ctx context.Context, codigoMunicip
 	return c.do(ctx, http.MethodGet, "/v2/municipios/"+codigoMunicipio+"/codigos_tributarios_municipio/"+codigoTributario, "", nil)
 }
 
+func (c *Client) ListNCMs(ctx context.Context, rawQuery string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, "/v2/ncms", rawQuery, nil)
+}
+
+func (c *Client) GetNCM(ctx context.Context, codigo string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, "/v2/ncms/"+codigo, "", nil)
+}
+
+func (c *Client) ListCFOPs(ctx context.Context, rawQuery string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, "/v2/cfops", rawQuery, nil)
+}
+
+func (c *Client) GetCFOP(ctx context.Context, codigo string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, "/v2/cfops/"+codigo, "", nil)
+}
+
 func (c *Client) do(ctx context.Context, method, path, rawQuery string, body []byte) (*http.Response, error) {
-	if c.baseURL == "" {
+	env := EnvironmentFromContext(ctx)
+	if c.company != nil {
+		if err := checkRequestedEnvironment(ctx, c.company.Environment); err != nil {
+			return nil, err
+		}
+		env = c.company.Environment
+	}
+	ep := c.envs[env]
+	if c.company != nil {
+		ep.token = c.company.Token
+	}
+	if ep.baseURL == "" {
+		if env == EnvHomologacao {
+			return nil, fmt.Errorf("FOCUS_HOMOLOGACAO_URL não definido")
+		}
 		return nil, fmt.Errorf("FOCUS_URL não definido")
 	}
-	if c.token == "" {
+	if ep.token == "" {
+		if env == EnvHomologacao {
+			return nil, fmt.Errorf("FOCUS_HOMOLOGACAO_API_TOKEN não definido")
+		}
 		return nil, fmt.Errorf("FOCUS_API_TOKEN não definido")
 	}
 
-	url := c.baseURL + path
+	url := ep.baseURL + path
 	if rawQuery != "" {
 		url = url + "?" + strings.TrimPrefix(rawQuery, "?")
 	}
 
+	maxAttempts := 1
+	if isRetryable(method, path) && c.retry.MaxAttempts > 1 {
+		maxAttempts = c.retry.MaxAttempts
+	}
+
+	// ambiente/empresa efetivos ficam no context para a auditoria (AuditTransport)
+	ctx = WithEnvironment(ctx, env)
+	if c.company != nil && companyIDFromContext(ctx) == "" {
+		ctx = WithCompanyID(ctx, c.company.CompanyID)
+	}
+
+	family := endpointFamily(path)
+	if env != EnvProducao {
+		family = string(env) + "/" + family
+	}
+
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Acquire(priorityFor(method)); err != nil {
+				log.Printf("[focus] %s %s recusado pelo limitador local: %v", method, path, err)
+				return nil, err
+			}
+		}
+		if c.breakers != nil {
+			if err := c.breakers.allow(family); err != nil {
+				// a chamada não chega à Focus: devolve o orçamento consumido acima
+				if c.limiter != nil {
+					c.limiter.release()
+				}
+				return nil, err
+			}
+		}
+
+		resp, err := c.send(ctx, method, url, ep.token, body)
+		if c.breakers != nil {
+			c.breakers.record(family, resp, err)
+		}
+		if c.limiter != nil {
+			c.limiter.Observe(resp)
+		}
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized && c.company != nil {
+			c.invalidateCompany()
+		}
+
+		if attempt >= maxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+		wait, reason, retry := c.retry.retryDelay(attempt, resp, err)
+		if !retry || !c.retry.fitsDeadline(ctx, wait) {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		log.Printf("[focus] retry %d/%d %s %s em %s (motivo: %s)", attempt+1, maxAttempts, method, path, wait.Round(time.Millisecond), reason)
+
+		if err := sleepCtx(ctx, wait); err != nil {
+			return nil, fmt.Errorf("erro ao enviar requisição para Focus: %w", err)
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, url, token string, body []byte) (*http.Response, error) {
 	var r io.Reader
 	if body != nil {
 		r = bytes.NewReader(body)
@@ -99,7 +217,7 @@ func (c *Client) do(ctx context.Context, method, path, rawQuery string, body []b
 		return nil, fmt.Errorf("erro ao criar requisição: %w", err)
 	}
 
-	req.SetBasicAuth(c.token, "")
+	req.SetBasicAuth(token, "")
 	req.Header.Set("Accept", "application/json")
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
@@ -112,5 +230,3 @@ func (c *Client) do(ctx context.Context, method, path, rawQuery string, body []b
 
 	return resp, nil
 }
-
-
@@ -0,0 +1,154 @@
+package focus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCompanyNotIntegrated indica que a empresa não tem integração (nem token) salva em focus_integration.
+var ErrCompanyNotIntegrated = errors.New("empresa não integrada com a Focus")
+
+// CompanyCredentials são os dados necessários para chamar a Focus "como" uma empresa
+// (emissão de documentos fiscais exige o token da empresa, não o token de revenda).
+type CompanyCredentials struct {
+	CompanyID      string
+	FocusCompanyID string
+	Token          string
+	Environment    Environment
+}
+
+// TokenResolver resolve as credenciais Focus de uma empresa pelo companies.id.
+type TokenResolver interface {
+	ResolveCompany(ctx context.Context, companyID string) (CompanyCredentials, error)
+}
+
+// CompanyLookupFunc busca as credenciais na fonte (Supabase). Deve devolver ErrCompanyNotIntegrated
+// quando não houver integração.
+type CompanyLookupFunc func(ctx context.Context, companyID string) (CompanyCredentials, error)
+
+// CachingTokenResolver guarda em memória, por ttl, as credenciais encontradas por lookup.
+// Ausências (ErrCompanyNotIntegrated) não são cacheadas, para que uma integração recém-criada valha na hora.
+type CachingTokenResolver struct {
+	lookup CompanyLookupFunc
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedCredentials
+}
+
+type cachedCredentials struct {
+	creds     CompanyCredentials
+	expiresAt time.Time
+}
+
+func NewCachingTokenResolver(lookup CompanyLookupFunc, ttl time.Duration) *CachingTokenResolver {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	return &CachingTokenResolver{
+		lookup:  lookup,
+		ttl:     ttl,
+		entries: map[string]cachedCredentials{},
+	}
+}
+
+func (r *CachingTokenResolver) ResolveCompany(ctx context.Context, companyID string) (CompanyCredentials, error) {
+	r.mu.Lock()
+	e, ok := r.entries[companyID]
+	r.mu.Unlock()
+	if ok && time.Now().Before(e.expiresAt) {
+		return e.creds, nil
+	}
+
+	creds, err := r.lookup(ctx, companyID)
+	if err != nil {
+		return CompanyCredentials{}, err
+	}
+	if creds.Token == "" {
+		return CompanyCredentials{}, ErrCompanyNotIntegrated
+	}
+	if creds.Environment == "" {
+		creds.Environment = EnvProducao
+	}
+	creds.CompanyID = companyID
+
+	r.mu.Lock()
+	r.entries[companyID] = cachedCredentials{creds: creds, expiresAt: time.Now().Add(r.ttl)}
+	r.mu.Unlock()
+
+	return creds, nil
+}
+
+// Invalidate descarta as credenciais em cache da empresa (ex: token recusado pela Focus).
+func (r *CachingTokenResolver) Invalidate(companyID string) {
+	r.mu.Lock()
+	delete(r.entries, companyID)
+	r.mu.Unlock()
+}
+
+// WithTokenResolver habilita Client.ForCompany.
+func WithTokenResolver(r TokenResolver) Option {
+	return func(c *Client) {
+		c.resolver = r
+	}
+}
+
+// ForCompany devolve um Client que autentica com o token da empresa, no ambiente em que ela foi
+// integrada. O Client devolvido compartilha transporte, limitador e circuit breakers com c.
+// Se ctx traz um ambiente pedido explicitamente (WithRequestedEnvironment) diferente, devolve *EnvironmentMismatchError.
+func (c *Client) ForCompany(ctx context.Context, companyID string) (*Client, error) {
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, fmt.Errorf("company_id é obrigatório")
+	}
+	if c.resolver == nil {
+		return nil, fmt.Errorf("resolução de token por empresa não configurada")
+	}
+
+	creds, err := c.resolver.ResolveCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRequestedEnvironment(ctx, creds.Environment); err != nil {
+		return nil, err
+	}
+
+	cc := *c
+	cc.company = &creds
+	return &cc, nil
+}
+
+// ResolveCompany devolve as credenciais da empresa pelo TokenResolver do Client (com o cache dele).
+// Empresas sem integração devolvem ErrCompanyNotIntegrated.
+func (c *Client) ResolveCompany(ctx context.Context, companyID string) (CompanyCredentials, error) {
+	if c.resolver == nil {
+		return CompanyCredentials{}, fmt.Errorf("resolução de token por empresa não configurada")
+	}
+	return c.resolver.ResolveCompany(ctx, strings.TrimSpace(companyID))
+}
+
+// Company devolve as credenciais em uso quando o Client foi obtido por ForCompany.
+func (c *Client) Company() (CompanyCredentials, bool) {
+	if c.company == nil {
+		return CompanyCredentials{}, false
+	}
+	return *c.company, true
+}
+
+// invalidateCompany é chamado quando a Focus recusa (401) o token da empresa.
+func (c *Client) invalidateCompany() {
+	if inv, ok := c.resolver.(interface{ Invalidate(string) }); ok && c.company != nil {
+		inv.Invalidate(c.company.CompanyID)
+	}
+}
+
+func checkRequestedEnvironment(ctx context.Context, company Environment) error {
+	if requested, ok := RequestedEnvironment(ctx); ok && requested != company {
+		return &EnvironmentMismatchError{Requested: requested, Company: company}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package focus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/focus/focustest"
+)
+
+func staticResolver(env focus.Environment) focus.TokenResolver {
+	return focus.NewCachingTokenResolver(func(_ context.Context, companyID string) (focus.CompanyCredentials, error) {
+		return focus.CompanyCredentials{CompanyID: companyID, FocusCompanyID: "100000", Token: "token-empresa", Environment: env}, nil
+	}, 0)
+}
+
+func TestForCompanyRejectsRequestedEnvironmentMismatch(t *testing.T) {
+	prod := focustest.NewServer()
+	defer prod.Close()
+	homolog := focustest.NewServer()
+	defer homolog.Close()
+
+	client := focus.NewClient(prod.URL, "token", focus.WithHomologacao(homolog.URL, "token-h"),
+		focus.WithTokenResolver(staticResolver(focus.EnvProducao)))
+
+	ctx := focus.WithRequestedEnvironment(context.Background(), focus.EnvHomologacao)
+	_, err := client.ForCompany(ctx, "company-1")
+	var envErr *focus.EnvironmentMismatchError
+	if !errors.As(err, &envErr) || envErr.Company != focus.EnvProducao || envErr.Requested != focus.EnvHomologacao {
+		t.Fatalf("esperava EnvironmentMismatchError, veio %v", err)
+	}
+
+	// sem pedido explícito, o ambiente da empresa vale
+	fc, err := client.ForCompany(context.Background(), "company-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := fc.GetEmpresa(focus.WithEnvironment(context.Background(), focus.EnvHomologacao), "100000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(prod.Requests()) != 1 || len(homolog.Requests()) != 0 {
+		t.Fatalf("chamada como empresa deveria ir para produção: prod=%v homolog=%v", prod.Requests(), homolog.Requests())
+	}
+
+	// o client da empresa também recusa um context com outro ambiente pedido
+	if _, err := fc.GetEmpresa(ctx, "100000"); !errors.Is(err, focus.ErrEnvironmentMismatch) {
+		t.Fatalf("esperava ErrEnvironmentMismatch, veio %v", err)
+	}
+	if len(prod.Requests()) != 1 {
+		t.Fatalf("nenhuma requisição deveria sair após a recusa: %v", prod.Requests())
+	}
+}
@@ -0,0 +1,87 @@
+package focus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Environment é o ambiente da Focus NFe usado numa chamada.
+type Environment string
+
+const (
+	EnvProducao    Environment = "producao"
+	EnvHomologacao Environment = "homologacao"
+)
+
+// ParseEnvironment aceita "producao"/"homologacao" (e os sinônimos "production"/"sandbox"/"homologation").
+func ParseEnvironment(s string) (Environment, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "producao", "produção", "production", "prod":
+		return EnvProducao, true
+	case "homologacao", "homologação", "homologation", "sandbox":
+		return EnvHomologacao, true
+	}
+	return "", false
+}
+
+type environmentKey struct{}
+
+type requestedEnvironmentKey struct{}
+
+// WithEnvironment devolve um context cujas chamadas ao Client vão para o ambiente informado.
+func WithEnvironment(ctx context.Context, env Environment) context.Context {
+	return context.WithValue(ctx, environmentKey{}, env)
+}
+
+// EnvironmentFromContext devolve o ambiente do context (produção, se nenhum foi definido).
+func EnvironmentFromContext(ctx context.Context) Environment {
+	if env, ok := ctx.Value(environmentKey{}).(Environment); ok && env != "" {
+		return env
+	}
+	return EnvProducao
+}
+
+// WithRequestedEnvironment é o WithEnvironment para o ambiente escolhido explicitamente pelo chamador
+// (header X-Focus-Environment ou ?ambiente=). Chamadas feitas como empresa (ForCompany) recusam um ambiente
+// pedido diferente daquele em que a empresa foi integrada, em vez de trocá-lo em silêncio.
+func WithRequestedEnvironment(ctx context.Context, env Environment) context.Context {
+	return context.WithValue(WithEnvironment(ctx, env), requestedEnvironmentKey{}, env)
+}
+
+// RequestedEnvironment devolve o ambiente pedido explicitamente (ok=false quando o ambiente é o padrão).
+func RequestedEnvironment(ctx context.Context) (Environment, bool) {
+	env, ok := ctx.Value(requestedEnvironmentKey{}).(Environment)
+	return env, ok && env != ""
+}
+
+// ErrEnvironmentMismatch indica que o ambiente pedido não é o ambiente da integração da empresa.
+var ErrEnvironmentMismatch = errors.New("ambiente informado diferente do ambiente da empresa")
+
+// EnvironmentMismatchError é devolvido por ForCompany quando o ambiente pedido difere do da empresa.
+type EnvironmentMismatchError struct {
+	Requested Environment
+	Company   Environment
+}
+
+func (e *EnvironmentMismatchError) Error() string {
+	return fmt.Sprintf("%s: pedido %s, empresa integrada em %s", ErrEnvironmentMismatch.Error(), e.Requested, e.Company)
+}
+
+func (e *EnvironmentMismatchError) Unwrap() error { return ErrEnvironmentMismatch }
+
+type endpoint struct {
+	baseURL string
+	token   string
+}
+
+// WithHomologacao configura a base URL e o token da conta no ambiente de homologação.
+func WithHomologacao(baseURL, token string) Option {
+	return func(c *Client) {
+		c.envs[EnvHomologacao] = endpoint{
+			baseURL: strings.TrimRight(strings.TrimSpace(baseURL), "/"),
+			token:   strings.TrimSpace(token),
+		}
+	}
+}
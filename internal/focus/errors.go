@@ -0,0 +1,102 @@
+package focus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// FieldError representa um item de `erros[]` devolvido pela Focus (validação por campo).
+type FieldError struct {
+	Campo    string `json:"campo"`
+	Mensagem string `json:"mensagem"`
+}
+
+// APIError representa uma resposta de erro (HTTP >= 400) da Focus NFe.
+//
+// Formato típico:
+//
+//	{"codigo": "requisicao_invalida", "mensagem": "...", "erros": [{"campo": "cnpj", "mensagem": "..."}]}
+type APIError struct {
+	StatusCode int          `json:"-"`
+	Codigo     string       `json:"codigo"`
+	Mensagem   string       `json:"mensagem"`
+	Erros      []FieldError `json:"erros,omitempty"`
+
+	// Body guarda o corpo original, para log/proxy sem precisar re-ler a resposta.
+	Body []byte `json:"-"`
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "focus: HTTP %d", e.StatusCode)
+	if e.Codigo != "" {
+		b.WriteString(" " + e.Codigo)
+	}
+	if e.Mensagem != "" {
+		b.WriteString(": " + e.Mensagem)
+	}
+	for _, fe := range e.Erros {
+		fmt.Fprintf(&b, " [%s: %s]", fe.Campo, fe.Mensagem)
+	}
+	return b.String()
+}
+
+// HasFieldError indica se a Focus rejeitou o campo informado (ex: "arquivo_certificado_base64").
+func (e *APIError) HasFieldError(campo string) bool {
+	for _, fe := range e.Erros {
+		if fe.Campo == campo {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrosJSON devolve `erros[]` serializado, no formato salvo em focus_integration_errors.errors.
+func (e *APIError) ErrosJSON() json.RawMessage {
+	if len(e.Erros) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(e.Erros)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// AsAPIError extrai um *APIError de err (inclusive quando embrulhado com %w).
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// ParseAPIError monta um *APIError a partir do status e corpo de uma resposta da Focus.
+// Corpos que não são JSON (ex: páginas HTML de erro do proxy) são preservados em Body
+// e resumidos em Mensagem.
+func ParseAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Body: body}
+
+	if err := json.Unmarshal(body, apiErr); err != nil {
+		// Alguns erros trazem `erros` em formato livre; tenta ao menos codigo/mensagem.
+		var loose struct {
+			Codigo   string `json:"codigo"`
+			Mensagem string `json:"mensagem"`
+		}
+		if json.Unmarshal(body, &loose) == nil {
+			apiErr.Codigo = loose.Codigo
+			apiErr.Mensagem = loose.Mensagem
+		} else {
+			apiErr.Mensagem = strings.TrimSpace(string(body))
+		}
+	}
+	if apiErr.Mensagem == "" {
+		apiErr.Mensagem = http.StatusText(statusCode)
+	}
+
+	return apiErr
+}
@@ -0,0 +1,46 @@
+package focustest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Certificate gera um certificado A1 (PKCS#12 em base64) autoassinado no formato ICP-Brasil
+// (emissor "ICP-Brasil", CN "RAZAO SOCIAL:CNPJ"), válido de notBefore a notAfter, protegido por password.
+// Serve para exercitar a validação local do certificado sem um e-CNPJ real.
+func Certificate(cnpj, password string, notBefore, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	name := pkix.Name{CommonName: "EMPRESA TESTE LTDA:" + cnpj, Organization: []string{"ICP-Brasil"}}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      name,
+		Issuer:       name,
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(err)
+	}
+	pfx, err := pkcs12.Modern.Encode(key, cert, nil, password)
+	if err != nil {
+		panic(err)
+	}
+	return base64.StdEncoding.EncodeToString(pfx)
+}
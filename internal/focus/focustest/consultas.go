@@ -0,0 +1,240 @@
+package focustest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/seuuser/focus-integration-service/internal/model"
+)
+
+func (s *Server) getCnpj(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	c, ok := s.cnpjs[chi.URLParam(r, "cnpj")]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "nao_encontrado", "CNPJ não encontrado", nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) getCep(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	c, ok := s.ceps[chi.URLParam(r, "cep")]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "nao_encontrado", "CEP não encontrado", nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) listMunicipios(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	uf := strings.ToUpper(q.Get("sigla_uf"))
+	nomeExato := q.Get("nome_municipio")
+	trecho := strings.ToLower(q.Get("nome"))
+	status := q.Get("status_nfse")
+
+	s.mu.Lock()
+	out := make([]model.FocusMunicipioResponse, 0, len(s.municipios))
+	for _, k := range sortedKeys(s.municipios) {
+		m := s.municipios[k]
+		switch {
+		case uf != "" && m.SiglaUF != uf,
+			nomeExato != "" && !strings.EqualFold(m.NomeMunicipio, nomeExato),
+			trecho != "" && !strings.Contains(strings.ToLower(m.NomeMunicipio), trecho),
+			status != "" && m.StatusNfse != status:
+			continue
+		}
+		out = append(out, m)
+	}
+	s.mu.Unlock()
+
+	writePage(w, r, out)
+}
+
+func (s *Server) getMunicipio(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	m, ok := s.municipios[chi.URLParam(r, "codigo_municipio")]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "nao_encontrado", "Município não encontrado", nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, m)
+}
+
+// listSubresource lista itens da lista de serviço / códigos tributários de um município
+// (filtros `codigo` e `descricao` por trecho).
+func (s *Server) listSubresource(data map[string][]map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		codigoMunicipio := chi.URLParam(r, "codigo_municipio")
+		codigo := r.URL.Query().Get("codigo")
+		descricao := strings.ToLower(r.URL.Query().Get("descricao"))
+
+		s.mu.Lock()
+		_, known := s.municipios[codigoMunicipio]
+		out := make([]map[string]any, 0)
+		for _, item := range data[codigoMunicipio] {
+			c, _ := item["codigo"].(string)
+			d, _ := item["descricao"].(string)
+			if codigo != "" && !strings.Contains(c, codigo) {
+				continue
+			}
+			if descricao != "" && !strings.Contains(strings.ToLower(d), descricao) {
+				continue
+			}
+			out = append(out, item)
+		}
+		s.mu.Unlock()
+
+		if !known {
+			writeError(w, http.StatusNotFound, "nao_encontrado", "Município não encontrado", nil)
+			return
+		}
+		writePage(w, r, out)
+	}
+}
+
+func (s *Server) getSubresource(data map[string][]map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		codigoMunicipio := chi.URLParam(r, "codigo_municipio")
+		codigo := chi.URLParam(r, "codigo")
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, item := range data[codigoMunicipio] {
+			if item["codigo"] == codigo {
+				writeJSON(w, http.StatusOK, item)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "nao_encontrado", "Código não encontrado", nil)
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+func strPtr(s string) *string { return &s }
+
+// seed carrega alguns municípios, itens de serviço e um CNPJ, suficientes para o fluxo de cadastro.
+func (s *Server) seed() {
+	for _, m := range []model.FocusMunicipioResponse{
+		{CodigoMunicipio: "4106902", NomeMunicipio: "Curitiba", SiglaUF: "PR", NomeUF: "Paraná"},
+		{CodigoMunicipio: "3509502", NomeMunicipio: "Campinas", SiglaUF: "SP", NomeUF: "São Paulo"},
+		{CodigoMunicipio: "3550308", NomeMunicipio: "São Paulo", SiglaUF: "SP", NomeUF: "São Paulo"},
+	} {
+		m.NfseHabilitada = true
+		m.StatusNfse = "ativo"
+		m.RequerCertificadoNfse = boolPtr(true)
+		m.PossuiAmbienteHomologacaoNfse = boolPtr(true)
+		m.PossuiCancelamentoNfse = boolPtr(true)
+		m.ProvedorNfse = strPtr("Fake")
+		m.ItemListaServicoObrigatorioNfse = boolPtr(true)
+		m.CodigoTributarioMunicipioObrigatorioNfse = boolPtr(false)
+		m.CodigoCnaeObrigatorioNfse = boolPtr(false)
+		m.CpfCnpjObrigatorioNfse = boolPtr(true)
+		m.EnderecoObrigatorioNfse = boolPtr(false)
+		s.municipios[m.CodigoMunicipio] = m
+
+		s.itens[m.CodigoMunicipio] = []map[string]any{
+			{"codigo": "01.07", "descricao": "Suporte técnico em informática"},
+			{"codigo": "14.01", "descricao": "Lubrificação, limpeza, lustração, revisão, carga e recarga"},
+			{"codigo": "17.19", "descricao": "Contabilidade, inclusive serviços técnicos e auxiliares"},
+		}
+		s.codigos[m.CodigoMunicipio] = []map[string]any{
+			{"codigo": "171901", "descricao": "Contabilidade"},
+		}
+	}
+
+	s.cnpjs["10964044000164"] = model.FocusCnpjResponse{
+		RazaoSocial:       "EMPRESA DE TESTE LTDA",
+		CNPJ:              "10964044000164",
+		SituacaoCadastral: "ativa",
+		CnaePrincipal:     "6920601",
+		OptanteSimplesNac: true,
+		Endereco: model.FocusCnpjEndereco{
+			CodigoMunicipio: "7535",
+			CodigoIbge:      "4106902",
+			NomeMunicipio:   "Curitiba",
+			Logradouro:      "Rua João da Silva",
+			Numero:          "153",
+			Bairro:          "Vila Isabel",
+			Cep:             "80210000",
+			Uf:              "PR",
+		},
+	}
+}
+
+func (s *Server) listNcms(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	codigo := q.Get("codigo")
+	descricao := strings.ToLower(q.Get("descricao"))
+	capitulo, posicao := q.Get("capitulo"), q.Get("posicao")
+
+	s.mu.Lock()
+	out := make([]model.FocusNcmResponse, 0, len(s.ncms))
+	for _, k := range sortedKeys(s.ncms) {
+		n := s.ncms[k]
+		switch {
+		case codigo != "" && !strings.HasPrefix(n.Codigo, codigo),
+			descricao != "" && !strings.Contains(strings.ToLower(n.DescricaoCompleta), descricao),
+			capitulo != "" && n.Capitulo != capitulo,
+			posicao != "" && n.Posicao != posicao:
+			continue
+		}
+		out = append(out, n)
+	}
+	s.mu.Unlock()
+
+	writePage(w, r, out)
+}
+
+func (s *Server) getNcm(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	n, ok := s.ncms[chi.URLParam(r, "codigo")]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "nao_encontrado", "NCM não encontrado", nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, n)
+}
+
+func (s *Server) listCfops(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	codigo := q.Get("codigo")
+	descricao := strings.ToLower(q.Get("descricao"))
+
+	s.mu.Lock()
+	out := make([]model.FocusCfopResponse, 0, len(s.cfops))
+	for _, k := range sortedKeys(s.cfops) {
+		c := s.cfops[k]
+		if (codigo != "" && !strings.HasPrefix(c.Codigo, codigo)) ||
+			(descricao != "" && !strings.Contains(strings.ToLower(c.Descricao), descricao)) {
+			continue
+		}
+		out = append(out, c)
+	}
+	s.mu.Unlock()
+
+	writePage(w, r, out)
+}
+
+func (s *Server) getCfop(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	c, ok := s.cfops[chi.URLParam(r, "codigo")]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "nao_encontrado", "CFOP não encontrado", nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
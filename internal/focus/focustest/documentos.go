@@ -0,0 +1,121 @@
+package focustest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Tipos de documento emulados em /v2/{tipo}. A NFC-e é síncrona (autorizada na resposta do POST);
+// os demais ficam em processamento até SetDocumentStatus.
+var documentTypes = []string{"nfse", "nfsen", "nfe", "nfce", "cte", "mdfe"}
+
+// Document é um documento emitido na Focus falsa. Payload é o corpo recebido, sem alteração.
+type Document struct {
+	Type    string
+	Ref     string
+	Status  string
+	Payload json.RawMessage
+}
+
+func (s *Server) documentRoutes(r chi.Router) {
+	for _, docType := range documentTypes {
+		r.Route("/v2/"+docType, func(r chi.Router) {
+			r.Post("/", s.emitDocument(docType))
+			r.Get("/{ref}", s.getDocument(docType))
+			r.Delete("/{ref}", s.cancelDocument(docType))
+		})
+	}
+}
+
+func (s *Server) emitDocument(docType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ref := r.URL.Query().Get("ref")
+		b, _ := io.ReadAll(r.Body)
+		if ref == "" || !json.Valid(b) {
+			writeError(w, http.StatusBadRequest, "requisicao_invalida", "ref e payload JSON são obrigatórios", nil)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		key := docType + "/" + ref
+		if _, exists := s.documents[key]; exists {
+			writeError(w, http.StatusUnprocessableEntity, "already_processed", "Nota fiscal já autorizada ou em processamento", nil)
+			return
+		}
+		doc := &Document{Type: docType, Ref: ref, Status: "processando_autorizacao", Payload: b}
+		status := http.StatusAccepted
+		if docType == "nfce" {
+			doc.Status, status = "autorizado", http.StatusCreated
+		}
+		s.documents[key] = doc
+		writeJSON(w, status, documentResponse(doc))
+	}
+}
+
+func (s *Server) getDocument(docType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		doc, ok := s.documents[docType+"/"+chi.URLParam(r, "ref")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "nao_encontrado", "Nota fiscal não encontrada", nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, documentResponse(doc))
+	}
+}
+
+func (s *Server) cancelDocument(docType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		doc, ok := s.documents[docType+"/"+chi.URLParam(r, "ref")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "nao_encontrado", "Nota fiscal não encontrada", nil)
+			return
+		}
+		if doc.Status != "autorizado" {
+			writeError(w, http.StatusUnprocessableEntity, "requisicao_invalida", "Apenas notas autorizadas podem ser canceladas", nil)
+			return
+		}
+		doc.Status = "cancelado"
+		writeJSON(w, http.StatusOK, documentResponse(doc))
+	}
+}
+
+// SetDocumentStatus altera o status de um documento emitido (ex: "autorizado", "erro_autorizacao").
+func (s *Server) SetDocumentStatus(docType, ref, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if doc, ok := s.documents[docType+"/"+ref]; ok {
+		doc.Status = status
+	}
+}
+
+// Documents devolve uma cópia dos documentos emitidos do tipo informado.
+func (s *Server) Documents(docType string) []Document {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Document
+	for _, key := range sortedKeys(s.documents) {
+		if strings.HasPrefix(key, docType+"/") {
+			out = append(out, *s.documents[key])
+		}
+	}
+	return out
+}
+
+func documentResponse(doc *Document) map[string]any {
+	out := map[string]any{"ref": doc.Ref, "status": doc.Status}
+	if doc.Status == "autorizado" || doc.Status == "cancelado" {
+		out["numero"] = "1"
+		out["serie"] = "1"
+		out["caminho_xml_nota_fiscal"] = "/arquivos/" + doc.Type + "/" + doc.Ref + ".xml"
+	}
+	return out
+}
@@ -0,0 +1,245 @@
+package focustest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Campos aceitos no cadastro mas nunca devolvidos pela Focus.
+var writeOnlyFields = []string{"arquivo_certificado_base64", "senha_certificado", "senha_responsavel"}
+
+var requiredCreateFields = []string{"nome", "cnpj", "arquivo_certificado_base64", "senha_certificado"}
+
+func (s *Server) createEmpresa(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+
+	var erros []map[string]string
+	for _, campo := range requiredCreateFields {
+		if v, _ := body[campo].(string); v == "" {
+			erros = append(erros, map[string]string{"campo": campo, "mensagem": "não pode ficar em branco"})
+		}
+	}
+	if len(erros) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "requisicao_invalida", "Parâmetros inválidos", erros)
+		return
+	}
+	if certErr := certificateError(body); certErr != nil {
+		writeError(w, http.StatusUnprocessableEntity, "requisicao_invalida", "Certificado inválido", certErr)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cnpj, _ := body["cnpj"].(string)
+	for _, e := range s.empresas {
+		if sameDocument(e["cnpj"], cnpj) {
+			writeError(w, http.StatusUnprocessableEntity, "requisicao_invalida", "Parâmetros inválidos",
+				[]map[string]string{{"campo": "cnpj", "mensagem": "já está em uso"}})
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, publicEmpresa(s.addEmpresa(body)))
+}
+
+// AddEmpresa cadastra uma empresa diretamente (sem validação) e devolve o id gerado.
+func (s *Server) AddEmpresa(fields map[string]any) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addEmpresa(fields)["id"].(int)
+}
+
+// addEmpresa grava a empresa com os campos gerados pela Focus. Chamar com s.mu travado.
+func (s *Server) addEmpresa(fields map[string]any) map[string]any {
+	s.nextID++
+	empresa := map[string]any{
+		"id":                      s.nextID,
+		"token_producao":          randomToken(),
+		"token_homologacao":       randomToken(),
+		"habilita_nfe":            false,
+		"habilita_nfce":           false,
+		"habilita_nfse":           false,
+		"habilita_nfsen_producao": false,
+		"habilita_cte":            false,
+		"habilita_mdfe":           false,
+	}
+	mergeEmpresa(empresa, fields)
+	s.empresas[s.nextID] = empresa
+	return empresa
+}
+
+func (s *Server) listEmpresas(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	cnpj, cpf := q.Get("cnpj"), q.Get("cpf")
+
+	s.mu.Lock()
+	all := s.sortedEmpresas()
+	s.mu.Unlock()
+
+	out := make([]map[string]any, 0, len(all))
+	for _, e := range all {
+		if cnpj != "" && !sameDocument(e["cnpj"], cnpj) {
+			continue
+		}
+		if cpf != "" && !sameDocument(e["cpf"], cpf) {
+			continue
+		}
+		out = append(out, e)
+	}
+	writePage(w, r, out)
+}
+
+func (s *Server) getEmpresa(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.findEmpresa(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, publicEmpresa(e))
+}
+
+func (s *Server) updateEmpresa(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+	if certErr := certificateError(body); certErr != nil {
+		writeError(w, http.StatusUnprocessableEntity, "requisicao_invalida", "Certificado inválido", certErr)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.findEmpresa(w, r)
+	if !ok {
+		return
+	}
+	mergeEmpresa(e, body)
+	writeJSON(w, http.StatusOK, publicEmpresa(e))
+}
+
+func (s *Server) deleteEmpresa(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.findEmpresa(w, r)
+	if !ok {
+		return
+	}
+	delete(s.empresas, e["id"].(int))
+	writeJSON(w, http.StatusOK, publicEmpresa(e))
+}
+
+// findEmpresa busca a empresa do path {id} ou responde 404. Chamar com s.mu travado.
+func (s *Server) findEmpresa(w http.ResponseWriter, r *http.Request) (map[string]any, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	e, ok := s.empresas[id]
+	if err != nil || !ok {
+		writeError(w, http.StatusNotFound, "nao_encontrado", "Empresa não encontrada", nil)
+		return nil, false
+	}
+	return e, true
+}
+
+// sortedEmpresas devolve cópias públicas das empresas, por id. Chamar com s.mu travado.
+func (s *Server) sortedEmpresas() []map[string]any {
+	ids := make([]int, 0, len(s.empresas))
+	for id := range s.empresas {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	out := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, publicEmpresa(s.empresas[id]))
+	}
+	return out
+}
+
+// mergeEmpresa aplica os campos enviados. Um novo certificado renova as datas de validade,
+// como a Focus faz ao ler o PFX.
+func mergeEmpresa(e, body map[string]any) {
+	for k, v := range body {
+		e[k] = v
+	}
+	if _, ok := body["arquivo_certificado_base64"]; ok {
+		now := time.Now().Truncate(time.Second)
+		e["certificado_valido_de"] = now.Format(time.RFC3339)
+		e["certificado_valido_ate"] = now.AddDate(1, 0, 0).Format(time.RFC3339)
+		e["certificado_cnpj"] = e["cnpj"]
+		e["certificado_especifico"] = false
+	}
+	// a Focus devolve numero/cep como texto
+	for _, k := range []string{"numero", "cep", "regime_tributario"} {
+		if n, ok := e[k].(float64); ok {
+			e[k] = strconv.FormatInt(int64(n), 10)
+		}
+	}
+	if _, ok := e["senha_responsavel"]; ok {
+		e["senha_responsavel_preenchida"] = true
+	}
+}
+
+func publicEmpresa(e map[string]any) map[string]any {
+	out := make(map[string]any, len(e))
+	for k, v := range e {
+		out[k] = v
+	}
+	for _, k := range writeOnlyFields {
+		delete(out, k)
+	}
+	return out
+}
+
+func certificateError(body map[string]any) []map[string]string {
+	if senha, _ := body["senha_certificado"].(string); senha == WrongCertificatePassword {
+		return []map[string]string{{
+			"campo":    "arquivo_certificado_base64",
+			"mensagem": "não foi possível abrir o certificado: senha incorreta",
+		}}
+	}
+	return nil
+}
+
+// sameDocument compara CNPJ/CPF ignorando a pontuação (a Focus guarda só os caracteres do documento).
+func sameDocument(stored any, want string) bool {
+	s, _ := stored.(string)
+	return s != "" && documentDigits(s) == documentDigits(want)
+}
+
+func documentDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '.' || r == '/' || r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func decodeObject(w http.ResponseWriter, r *http.Request) (map[string]any, bool) {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "requisicao_invalida", "Não foi possível ler o corpo", nil)
+		return nil, false
+	}
+	var body map[string]any
+	if err := json.Unmarshal(b, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "requisicao_invalida", "JSON inválido", nil)
+		return nil, false
+	}
+	return body, true
+}
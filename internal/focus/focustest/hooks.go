@@ -0,0 +1,100 @@
+package focustest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (s *Server) createHook(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+
+	var erros []map[string]string
+	for _, campo := range []string{"event", "url"} {
+		if v, _ := body[campo].(string); v == "" {
+			erros = append(erros, map[string]string{"campo": campo, "mensagem": "não pode ficar em branco"})
+		}
+	}
+	if cnpj, _ := body["cnpj"].(string); cnpj == "" {
+		if cpf, _ := body["cpf"].(string); cpf == "" {
+			erros = append(erros, map[string]string{"campo": "cnpj", "mensagem": "não pode ficar em branco"})
+		}
+	}
+	if len(erros) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "requisicao_invalida", "Parâmetros inválidos", erros)
+		return
+	}
+
+	s.mu.Lock()
+	s.nextHook++
+	hook := map[string]any{"id": "hook" + strconv.Itoa(s.nextHook)}
+	for _, k := range []string{"cnpj", "cpf", "event", "url", "authorization", "authorization_header"} {
+		if v, ok := body[k]; ok {
+			hook[k] = v
+		}
+	}
+	s.hooks[hook["id"].(string)] = hook
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, hook)
+}
+
+func (s *Server) listHooks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	out := make([]map[string]any, 0, len(s.hooks))
+	for _, id := range sortedKeys(s.hooks) {
+		out = append(out, s.hooks[id])
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getHook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	hook, ok := s.hooks[chi.URLParam(r, "id")]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "nao_encontrado", "Gatilho não encontrado", nil)
+		return
+	}
+	writeJSON(w, http.StatusOK, hook)
+}
+
+func (s *Server) deleteHook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	hook, ok := s.hooks[chi.URLParam(r, "id")]
+	delete(s.hooks, chi.URLParam(r, "id"))
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "nao_encontrado", "Gatilho não encontrado", nil)
+		return
+	}
+	out := copyHook(hook)
+	out["deleted"] = true
+	writeJSON(w, http.StatusOK, out)
+}
+
+// Hooks devolve uma cópia dos gatilhos cadastrados (inclusive authorization), ordenados por id.
+func (s *Server) Hooks() []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]map[string]any, 0, len(s.hooks))
+	for _, id := range sortedKeys(s.hooks) {
+		out = append(out, copyHook(s.hooks[id]))
+	}
+	return out
+}
+
+// Como a Focus, as respostas de /v2/hooks devolvem authorization (o segredo) junto com o gatilho.
+func copyHook(h map[string]any) map[string]any {
+	out := make(map[string]any, len(h))
+	for k, v := range h {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,360 @@
+// Package focustest implementa uma Focus NFe falsa (em memória) para testes de handlers
+// e para rodar o serviço offline (FOCUS_URL apontando para o servidor falso; ver cmd/focusfake).
+//
+// Endpoints emulados:
+//   - /v2/empresas (CRUD, geração de tokens, datas do certificado, paginação com X-Total-Count)
+//   - /v2/cnpjs/{cnpj}
+//   - /v2/ceps/{cep}
+//   - /v2/municipios, /v2/municipios/{codigo}, itens_lista_servico e codigos_tributarios_municipio
+//   - /v2/ncms e /v2/cfops (listagem com filtros por trecho e consulta por código)
+//   - /v2/hooks (cadastro, listagem, consulta e remoção de gatilhos)
+//   - /v2/nfse, /v2/nfsen, /v2/nfe, /v2/nfce, /v2/cte e /v2/mdfe (emissão, consulta e cancelamento por ref)
+//
+// Erros podem ser injetados com InjectFault (4xx, 429 com Rate-Limit-Reset, 5xx).
+package focustest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/seuuser/focus-integration-service/internal/model"
+)
+
+// PageSize é o tamanho de página das listagens (a Focus pagina com offset).
+const PageSize = 50
+
+// WrongCertificatePassword: senha_certificado que faz o servidor recusar o certificado,
+// como a Focus faz com senha incorreta (erro no campo arquivo_certificado_base64).
+const WrongCertificatePassword = "senha_errada"
+
+// Fault é um erro injetado. Vale para requisições cujo método (vazio = qualquer) e prefixo de path
+// coincidem; Times limita quantas vezes ele é aplicado (0 = sempre, até ClearFaults).
+type Fault struct {
+	Method     string
+	PathPrefix string
+	Status     int
+	Body       string
+	Header     http.Header
+	Times      int
+}
+
+// Server é a Focus falsa. Use NewServer (httptest, para testes) ou NewHandler (servidor real, cmd/focusfake).
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	empresas   map[int]map[string]any
+	nextID     int
+	cnpjs      map[string]model.FocusCnpjResponse
+	ceps       map[string]model.FocusCepResponse
+	municipios map[string]model.FocusMunicipioResponse
+	ncms       map[string]model.FocusNcmResponse
+	cfops      map[string]model.FocusCfopResponse
+	itens      map[string][]map[string]any
+	codigos    map[string][]map[string]any
+	hooks      map[string]map[string]any
+	nextHook   int
+	documents  map[string]*Document
+	faults     []*Fault
+	requests   []string
+	handler    http.Handler
+
+	// RateLimit > 0 emula o limite por minuto da conta (headers Rate-Limit-* e 429).
+	RateLimit   int
+	windowStart time.Time
+	windowCount int
+}
+
+// NewServer sobe a Focus falsa num httptest.Server. Chame Close ao final.
+func NewServer() *Server {
+	s := NewHandler()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// NewHandler cria a Focus falsa sem subir servidor (para http.ListenAndServe).
+func NewHandler() *Server {
+	s := &Server{
+		empresas:   map[int]map[string]any{},
+		nextID:     100000,
+		cnpjs:      map[string]model.FocusCnpjResponse{},
+		ceps:       map[string]model.FocusCepResponse{},
+		municipios: map[string]model.FocusMunicipioResponse{},
+		ncms:       map[string]model.FocusNcmResponse{},
+		cfops:      map[string]model.FocusCfopResponse{},
+		itens:      map[string][]map[string]any{},
+		codigos:    map[string][]map[string]any{},
+		hooks:      map[string]map[string]any{},
+		documents:  map[string]*Document{},
+	}
+	s.seed()
+	s.handler = s.routes()
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+func (s *Server) routes() http.Handler {
+	r := chi.NewRouter()
+	r.Use(s.middleware)
+
+	r.Route("/v2/empresas", func(r chi.Router) {
+		r.Post("/", s.createEmpresa)
+		r.Get("/", s.listEmpresas)
+		r.Get("/{id}", s.getEmpresa)
+		r.Put("/{id}", s.updateEmpresa)
+		r.Delete("/{id}", s.deleteEmpresa)
+	})
+	r.Get("/v2/cnpjs/{cnpj}", s.getCnpj)
+	r.Get("/v2/ceps/{cep}", s.getCep)
+	r.Route("/v2/municipios", func(r chi.Router) {
+		r.Get("/", s.listMunicipios)
+		r.Get("/{codigo_municipio}", s.getMunicipio)
+		r.Get("/{codigo_municipio}/itens_lista_servico", s.listSubresource(s.itens))
+		r.Get("/{codigo_municipio}/itens_lista_servico/{codigo}", s.getSubresource(s.itens))
+		r.Get("/{codigo_municipio}/codigos_tributarios_municipio", s.listSubresource(s.codigos))
+		r.Get("/{codigo_municipio}/codigos_tributarios_municipio/{codigo}", s.getSubresource(s.codigos))
+	})
+	r.Get("/v2/ncms", s.listNcms)
+	r.Get("/v2/ncms/{codigo}", s.getNcm)
+	r.Get("/v2/cfops", s.listCfops)
+	r.Get("/v2/cfops/{codigo}", s.getCfop)
+	r.Route("/v2/hooks", func(r chi.Router) {
+		r.Post("/", s.createHook)
+		r.Get("/", s.listHooks)
+		r.Get("/{id}", s.getHook)
+		r.Delete("/{id}", s.deleteHook)
+	})
+	s.documentRoutes(r)
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "nao_encontrado", "Endpoint não encontrado", nil)
+	})
+	return r
+}
+
+// InjectFault registra um erro a ser devolvido pelas próximas requisições que casarem com f.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// FailNext faz as próximas n requisições (method + prefixo de path) devolverem status.
+func (s *Server) FailNext(method, pathPrefix string, status, n int) {
+	s.InjectFault(Fault{Method: method, PathPrefix: pathPrefix, Status: status, Times: n})
+}
+
+// RateLimitNext faz as próximas n requisições devolverem 429 com Rate-Limit-Reset = reset.
+func (s *Server) RateLimitNext(n int, reset time.Duration) {
+	h := http.Header{}
+	h.Set("Rate-Limit-Limit", "100")
+	h.Set("Rate-Limit-Remaining", "0")
+	h.Set("Rate-Limit-Reset", strconv.Itoa(int(reset.Seconds())))
+	s.InjectFault(Fault{Status: http.StatusTooManyRequests, Header: h, Times: n})
+}
+
+// ClearFaults remove todos os erros injetados.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests devolve as requisições recebidas ("METHOD /path?query"), em ordem.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// AddCNPJ registra um CNPJ consultável em /v2/cnpjs/{cnpj}.
+func (s *Server) AddCNPJ(c model.FocusCnpjResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cnpjs[c.CNPJ] = c
+}
+
+// AddCEP registra um CEP consultável em /v2/ceps/{cep}.
+func (s *Server) AddCEP(c model.FocusCepResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ceps[c.CEP] = c
+}
+
+// AddNCM registra (ou substitui) um NCM.
+func (s *Server) AddNCM(n model.FocusNcmResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ncms[n.Codigo] = n
+}
+
+// AddCFOP registra (ou substitui) um CFOP.
+func (s *Server) AddCFOP(c model.FocusCfopResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfops[c.Codigo] = c
+}
+
+// AddMunicipio registra (ou substitui) um município.
+func (s *Server) AddMunicipio(m model.FocusMunicipioResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.municipios[m.CodigoMunicipio] = m
+}
+
+// Empresas devolve uma cópia das empresas cadastradas, ordenadas por id.
+func (s *Server) Empresas() []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedEmpresas()
+}
+
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		line := r.Method + " " + r.URL.Path
+		if r.URL.RawQuery != "" {
+			line += "?" + r.URL.RawQuery
+		}
+		s.requests = append(s.requests, line)
+		fault := s.matchFault(r)
+		limited, reset := s.countRequest(w)
+		s.mu.Unlock()
+
+		if token, _, ok := r.BasicAuth(); !ok || token == "" {
+			writeError(w, http.StatusUnauthorized, "nao_autorizado", "Token de acesso inválido ou não informado", nil)
+			return
+		}
+		if fault != nil {
+			for k, vs := range fault.Header {
+				for _, v := range vs {
+					w.Header().Add(k, v)
+				}
+			}
+			body := fault.Body
+			if body == "" {
+				body = faultBody(fault.Status)
+			}
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(fault.Status)
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		if limited {
+			w.Header().Set("Rate-Limit-Reset", strconv.Itoa(reset))
+			writeError(w, http.StatusTooManyRequests, "limite_excedido", "Limite de requisições atingido", nil)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// matchFault consome (Times) e devolve o primeiro erro injetado que casa com r. Chamar com s.mu travado.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.PathPrefix) {
+			continue
+		}
+		out := *f
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return &out
+	}
+	return nil
+}
+
+// countRequest aplica o RateLimit por minuto. Chamar com s.mu travado.
+func (s *Server) countRequest(w http.ResponseWriter) (limited bool, resetSeconds int) {
+	if s.RateLimit <= 0 {
+		return false, 0
+	}
+	now := time.Now()
+	if now.Sub(s.windowStart) >= time.Minute {
+		s.windowStart = now
+		s.windowCount = 0
+	}
+	s.windowCount++
+
+	resetSeconds = int(time.Minute.Seconds() - now.Sub(s.windowStart).Seconds())
+	w.Header().Set("Rate-Limit-Limit", strconv.Itoa(s.RateLimit))
+	w.Header().Set("Rate-Limit-Remaining", strconv.Itoa(max(s.RateLimit-s.windowCount, 0)))
+	w.Header().Set("Rate-Limit-Reset", strconv.Itoa(resetSeconds))
+	return s.windowCount > s.RateLimit, resetSeconds
+}
+
+func faultBody(status int) string {
+	codigo := "erro_interno"
+	switch {
+	case status == http.StatusTooManyRequests:
+		codigo = "limite_excedido"
+	case status == http.StatusNotFound:
+		codigo = "nao_encontrado"
+	case status == http.StatusUnauthorized:
+		codigo = "nao_autorizado"
+	case status < 500:
+		codigo = "requisicao_invalida"
+	}
+	b, _ := json.Marshal(map[string]any{"codigo": codigo, "mensagem": http.StatusText(status)})
+	return string(b)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, codigo, mensagem string, erros []map[string]string) {
+	body := map[string]any{"codigo": codigo, "mensagem": mensagem}
+	if len(erros) > 0 {
+		body["erros"] = erros
+	}
+	writeJSON(w, status, body)
+}
+
+// writePage aplica offset/PageSize a items e escreve X-Total-Count.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	offset = min(max(offset, 0), len(items))
+	end := min(offset+PageSize, len(items))
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(len(items)))
+	writeJSON(w, http.StatusOK, items[offset:end])
+}
+
+func randomToken() string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	for i := range b {
+		b[i] = alphabet[int(b[i])%len(alphabet)]
+	}
+	return string(b)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
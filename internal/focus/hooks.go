@@ -0,0 +1,27 @@
+package focus
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Gatilhos (webhooks) cadastrados na Focus: a cada mudança de status de um documento do CNPJ
+// e do tipo (event) informados, a Focus faz um POST na url cadastrada.
+
+// CreateHook: body = {"cnpj", "event", "url", "authorization", "authorization_header"}
+func (c *Client) CreateHook(ctx context.Context, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, "/v2/hooks", "", body)
+}
+
+func (c *Client) ListHooks(ctx context.Context) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, "/v2/hooks", "", nil)
+}
+
+func (c *Client) GetHook(ctx context.Context, id string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, "/v2/hooks/"+url.PathEscape(id), "", nil)
+}
+
+func (c *Client) DeleteHook(ctx context.Context, id string) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, "/v2/hooks/"+url.PathEscape(id), "", nil)
+}
@@ -0,0 +1,28 @@
+package focus
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// NFC-e (modelo 65). Sempre síncrona: a resposta da emissão já traz o resultado da SEFAZ
+// (ou a emissão em contingência offline, quando a SEFAZ está indisponível). Exige o token da empresa (ForCompany).
+
+func (c *Client) EmitNFCe(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, "/v2/nfce", "ref="+url.QueryEscape(ref), body)
+}
+
+// GetNFCe consulta a NFC-e; completa=true inclui o conteúdo completo da nota.
+func (c *Client) GetNFCe(ctx context.Context, ref string, completa bool) (*http.Response, error) {
+	rawQuery := ""
+	if completa {
+		rawQuery = "completa=1"
+	}
+	return c.do(ctx, http.MethodGet, "/v2/nfce/"+url.PathEscape(ref), rawQuery, nil)
+}
+
+// CancelNFCe: body = {"justificativa": "..."} (15 a 255 caracteres)
+func (c *Client) CancelNFCe(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, "/v2/nfce/"+url.PathEscape(ref), "", body)
+}
@@ -0,0 +1,39 @@
+package focus
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// NF-e (modelo 55). Exige o token da empresa (ForCompany).
+
+// EmitNFe envia a NF-e. Por padrão a Focus processa de forma assíncrona (status processando_autorizacao);
+// empresas com nfe_sincrono recebem o resultado da SEFAZ na própria resposta.
+func (c *Client) EmitNFe(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, "/v2/nfe", "ref="+url.QueryEscape(ref), body)
+}
+
+// GetNFe consulta a NF-e; completa=true inclui o conteúdo completo da nota e dos eventos.
+func (c *Client) GetNFe(ctx context.Context, ref string, completa bool) (*http.Response, error) {
+	rawQuery := ""
+	if completa {
+		rawQuery = "completa=1"
+	}
+	return c.do(ctx, http.MethodGet, "/v2/nfe/"+url.PathEscape(ref), rawQuery, nil)
+}
+
+// CancelNFe: body = {"justificativa": "..."} (15 a 255 caracteres)
+func (c *Client) CancelNFe(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, "/v2/nfe/"+url.PathEscape(ref), "", body)
+}
+
+// CartaCorrecaoNFe: body = {"correcao": "..."} (15 a 1000 caracteres)
+func (c *Client) CartaCorrecaoNFe(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, "/v2/nfe/"+url.PathEscape(ref)+"/carta_correcao", "", body)
+}
+
+// InutilizarNFe inutiliza uma faixa de numeração: body = {"cnpj", "serie", "numero_inicial", "numero_final", "justificativa"}
+func (c *Client) InutilizarNFe(ctx context.Context, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, "/v2/nfe/inutilizacao", "", body)
+}
@@ -0,0 +1,27 @@
+package focus
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// NFS-e (municipal). Estas chamadas exigem o token da empresa: use um Client obtido por ForCompany.
+
+func (c *Client) EmitNFSe(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, "/v2/nfse", "ref="+url.QueryEscape(ref), body)
+}
+
+func (c *Client) GetNFSe(ctx context.Context, ref string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, "/v2/nfse/"+url.PathEscape(ref), "", nil)
+}
+
+// CancelNFSe: body = {"justificativa": "..."}
+func (c *Client) CancelNFSe(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, "/v2/nfse/"+url.PathEscape(ref), "", body)
+}
+
+// ResendNFSeEmail: body = {"emails": ["..."]}
+func (c *Client) ResendNFSeEmail(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, "/v2/nfse/"+url.PathEscape(ref)+"/email", "", body)
+}
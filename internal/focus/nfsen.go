@@ -0,0 +1,22 @@
+package focus
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// NFS-e Nacional (padrão nacional / DPS). Também exige o token da empresa (ForCompany).
+
+func (c *Client) EmitNFSeN(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, "/v2/nfsen", "ref="+url.QueryEscape(ref), body)
+}
+
+func (c *Client) GetNFSeN(ctx context.Context, ref string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, "/v2/nfsen/"+url.PathEscape(ref), "", nil)
+}
+
+// CancelNFSeN: body = {"justificativa": "..."}
+func (c *Client) CancelNFSeN(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, "/v2/nfsen/"+url.PathEscape(ref), "", body)
+}
@@ -0,0 +1,80 @@
+package focus
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+
+	"github.com/seuuser/focus-integration-service/internal/model"
+)
+
+// EmpresasPage é uma página de GET /v2/empresas. Total vem do header X-Total-Count (-1 se ausente).
+type EmpresasPage struct {
+	Empresas []model.FocusEmpresaResponse
+	Offset   int
+	Total    int
+}
+
+// EmpresasPages percorre GET /v2/empresas avançando `offset` até atingir X-Total-Count
+// (ou até uma página vazia). Os demais filtros de rawQuery (cnpj, cpf...) são mantidos.
+// O iterador para no primeiro erro, que é entregue como segundo valor.
+func (c *Client) EmpresasPages(ctx context.Context, rawQuery string) iter.Seq2[EmpresasPage, error] {
+	return func(yield func(EmpresasPage, error) bool) {
+		q, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			q = url.Values{}
+		}
+		offset, _ := strconv.Atoi(q.Get("offset"))
+
+		for {
+			q.Set("offset", strconv.Itoa(offset))
+
+			resp, err := c.ListEmpresas(ctx, q.Encode())
+			if err != nil {
+				yield(EmpresasPage{Offset: offset}, err)
+				return
+			}
+
+			total := -1
+			if n, ok := headerInt(resp.Header, "X-Total-Count"); ok {
+				total = n
+			}
+
+			var empresas []model.FocusEmpresaResponse
+			if _, err := DecodeResponse(resp, &empresas); err != nil {
+				yield(EmpresasPage{Offset: offset, Total: total}, err)
+				return
+			}
+
+			if len(empresas) == 0 {
+				return
+			}
+			if !yield(EmpresasPage{Empresas: empresas, Offset: offset, Total: total}, nil) {
+				return
+			}
+
+			offset += len(empresas)
+			if total >= 0 && offset >= total {
+				return
+			}
+		}
+	}
+}
+
+// AllEmpresas é EmpresasPages achatado: entrega empresa por empresa.
+func (c *Client) AllEmpresas(ctx context.Context, rawQuery string) iter.Seq2[model.FocusEmpresaResponse, error] {
+	return func(yield func(model.FocusEmpresaResponse, error) bool) {
+		for page, err := range c.EmpresasPages(ctx, rawQuery) {
+			if err != nil {
+				yield(model.FocusEmpresaResponse{}, err)
+				return
+			}
+			for _, e := range page.Empresas {
+				if !yield(e, nil) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package focus
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited indica que o limitador local recusou a chamada (orçamento da conta Focus esgotado).
+var ErrRateLimited = errors.New("limite de requisições à Focus atingido")
+
+// RateLimitError é devolvido quando o limitador local recusa uma chamada.
+// RetryAfter é a estimativa de quando haverá orçamento novamente.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s; tente novamente em %ds", ErrRateLimited.Error(), e.RetryAfterSeconds())
+}
+
+func (e *RateLimitError) Unwrap() error { return ErrRateLimited }
+
+// RetryAfterSeconds arredonda RetryAfter para cima, no formato do header Retry-After.
+func (e *RateLimitError) RetryAfterSeconds() int {
+	return max(int(math.Ceil(e.RetryAfter.Seconds())), 1)
+}
+
+// Priority define a prioridade de uma chamada no limitador.
+type Priority int
+
+const (
+	// PriorityRead: consultas (GET). Não podem consumir a reserva de escrita.
+	PriorityRead Priority = iota
+	// PriorityWrite: criação/alteração/exclusão (POST/PUT/DELETE).
+	PriorityWrite
+)
+
+func priorityFor(method string) Priority {
+	if method == http.MethodGet || method == http.MethodHead {
+		return PriorityRead
+	}
+	return PriorityWrite
+}
+
+// RateLimiter é um token bucket compartilhado por todas as chamadas do Client (um único token de conta).
+//
+// O orçamento inicial vem da configuração e é reajustado a cada resposta da Focus
+// pelos headers Rate-Limit-Limit / Rate-Limit-Remaining / Rate-Limit-Reset.
+// Uma fração do bucket (WriteReserve) fica reservada para escritas, para que rajadas
+// de consultas (municípios, CNPJ) não impeçam o cadastro de empresas.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	capacity     float64
+	tokens       float64
+	window       time.Duration
+	writeReserve float64 // fração [0,1) de capacity reservada para PriorityWrite
+	last         time.Time
+
+	// blockedUntil é preenchido quando a Focus informa Remaining=0 (ou 429): nada passa até o reset,
+	// e depois dele o bucket volta cheio.
+	blockedUntil time.Time
+
+	now func() time.Time
+}
+
+// NewRateLimiter cria um limitador com limit requisições por window.
+// writeReserve é a fração do orçamento reservada para escritas (ex: 0.2).
+func NewRateLimiter(limit int, window time.Duration, writeReserve float64) *RateLimiter {
+	if limit <= 0 {
+		limit = 1
+	}
+	if window <= 0 {
+		window = time.Minute
+	}
+	writeReserve = min(max(writeReserve, 0), 0.9)
+
+	return &RateLimiter{
+		capacity:     float64(limit),
+		tokens:       float64(limit),
+		window:       window,
+		writeReserve: writeReserve,
+		last:         time.Now(),
+		now:          time.Now,
+	}
+}
+
+// WithRateLimiter liga o limitador local no Client.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = l
+	}
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last)
+	if elapsed > 0 {
+		l.tokens = min(l.capacity, l.tokens+elapsed.Seconds()*l.capacity/l.window.Seconds())
+		l.last = now
+	}
+}
+
+// Acquire consome uma unidade do orçamento ou devolve *RateLimitError, sem bloquear.
+func (l *RateLimiter) Acquire(p Priority) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Before(l.blockedUntil) {
+		return &RateLimitError{RetryAfter: l.blockedUntil.Sub(now)}
+	}
+	if !l.blockedUntil.IsZero() {
+		// a janela da Focus reiniciou no Rate-Limit-Reset: o orçamento volta inteiro, e não aos poucos
+		// pelo refill (que deixaria as consultas abaixo da reserva de escrita por vários segundos).
+		l.blockedUntil = time.Time{}
+		l.tokens = l.capacity
+		l.last = now
+	}
+	l.refill(now)
+
+	floor := 0.0
+	if p == PriorityRead {
+		floor = l.capacity * l.writeReserve
+	}
+	if l.tokens-1 >= floor {
+		l.tokens--
+		return nil
+	}
+
+	missing := floor + 1 - l.tokens
+	return &RateLimitError{RetryAfter: time.Duration(missing / l.capacity * float64(l.window))}
+}
+
+// release devolve a unidade consumida por Acquire quando a chamada não chegou a ser enviada.
+func (l *RateLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = min(l.capacity, l.tokens+1)
+}
+
+// Observe reajusta o orçamento a partir dos headers de rate limit da Focus.
+func (l *RateLimiter) Observe(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	limit, hasLimit := headerInt(resp.Header, "Rate-Limit-Limit")
+	remaining, hasRemaining := headerInt(resp.Header, "Rate-Limit-Remaining")
+	if !hasLimit && !hasRemaining && resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	reset, hasReset := rateLimitReset(resp.Header, now)
+	l.refill(now)
+
+	if hasLimit && limit > 0 {
+		l.capacity = float64(limit)
+	}
+	if hasRemaining {
+		// confia no servidor quando ele diz que resta menos do que achamos
+		l.tokens = min(l.tokens, float64(remaining), l.capacity)
+	}
+	if (hasRemaining && remaining <= 0) || resp.StatusCode == http.StatusTooManyRequests {
+		l.tokens = 0
+		if hasReset {
+			l.blockedUntil = now.Add(reset)
+		}
+	}
+}
+
+func headerInt(h http.Header, key string) (int, bool) {
+	v := strings.TrimSpace(h.Get(key))
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
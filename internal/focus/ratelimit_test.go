@@ -0,0 +1,94 @@
+package focus_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/focus/focustest"
+)
+
+func TestRateLimit429RetriedAfterReset(t *testing.T) {
+	srv := focustest.NewServer()
+	defer srv.Close()
+
+	// configuração padrão do serviço: 100/min com 20% reservado para escritas
+	client := focus.NewClient(srv.URL, "token",
+		focus.WithRateLimiter(focus.NewRateLimiter(100, time.Minute, 0.2)),
+		focus.WithRetryPolicy(focus.RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Millisecond, MaxDelay: 3 * time.Second}),
+	)
+
+	srv.RateLimitNext(1, time.Second)
+	resp, err := client.ListEmpresas(context.Background(), "")
+	if err != nil {
+		t.Fatalf("o retry após o Rate-Limit-Reset foi recusado: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("esperava 200 após o 429, veio %d", resp.StatusCode)
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Fatalf("esperava 2 requisições (429 e retry), veio %d", n)
+	}
+}
+
+func TestRateLimitReservesWrites(t *testing.T) {
+	l := focus.NewRateLimiter(10, time.Hour, 0.2)
+
+	reads := 0
+	for l.Acquire(focus.PriorityRead) == nil {
+		reads++
+	}
+	if reads != 8 {
+		t.Fatalf("consultas deveriam parar na reserva de escrita: %d passaram", reads)
+	}
+	if err := l.Acquire(focus.PriorityWrite); err != nil {
+		t.Fatalf("escrita deveria usar a reserva: %v", err)
+	}
+
+	var rlErr *focus.RateLimitError
+	if err := l.Acquire(focus.PriorityRead); !errors.As(err, &rlErr) || rlErr.RetryAfter <= 0 {
+		t.Fatalf("esperava RateLimitError com RetryAfter, veio %v", err)
+	}
+}
+
+func TestRateLimitNotSpentWhileCircuitOpen(t *testing.T) {
+	srv := focustest.NewServer()
+	defer srv.Close()
+
+	l := focus.NewRateLimiter(10, time.Hour, 0)
+	client := focus.NewClient(srv.URL, "token",
+		focus.WithRateLimiter(l),
+		focus.WithRetryPolicy(focus.RetryPolicy{MaxAttempts: 1}),
+		focus.WithCircuitBreaker(focus.BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour}),
+	)
+
+	srv.FailNext(http.MethodGet, "/v2/empresas", http.StatusServiceUnavailable, 1)
+	if resp, err := client.ListEmpresas(context.Background(), ""); err == nil {
+		resp.Body.Close()
+	}
+
+	var open *focus.CircuitOpenError
+	for range 20 {
+		if _, err := client.ListEmpresas(context.Background(), ""); !errors.As(err, &open) {
+			t.Fatalf("esperava CircuitOpenError, veio %v", err)
+		}
+	}
+
+	// só a chamada que chegou à Focus consumiu orçamento
+	for i := range 9 {
+		if err := l.Acquire(focus.PriorityRead); err != nil {
+			t.Fatalf("as recusas do breaker consumiram o orçamento: %d/9 disponíveis (%v)", i, err)
+		}
+	}
+}
+
+func TestRateLimitRetryAfterIsAtLeastOneSecond(t *testing.T) {
+	err := &focus.RateLimitError{RetryAfter: 10 * time.Millisecond}
+	if got := err.RetryAfterSeconds(); got != 1 {
+		t.Fatalf("Retry-After deveria ser ao menos 1s, veio %d", got)
+	}
+}
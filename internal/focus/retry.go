@@ -0,0 +1,125 @@
+package focus
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controla as novas tentativas de chamadas idempotentes (GET/PUT/DELETE) nas famílias de
+// retryableFamilies.
+//
+// - Erros de transporte e HTTP 5xx: backoff exponencial (BaseDelay * 2^n, com jitter, limitado a MaxDelay).
+// - HTTP 429: aguarda o tempo indicado em Rate-Limit-Reset (ou Retry-After).
+//
+// Em ambos os casos a espera é limitada pelo deadline do context da requisição; quando
+// o context não tem deadline, MaxDelay também limita a espera do 429.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy é usada quando NewClient não recebe WithRetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// WithRetryPolicy substitui a política de retry do client. MaxAttempts <= 1 desliga o retry.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// retryableFamilies são as famílias de endpoints em que o retry é seguro. Nos documentos fiscais um DELETE é
+// um cancelamento na SEFAZ: repetir depois de um timeout pode registrar o evento duas vezes.
+var retryableFamilies = map[string]bool{
+	"empresas":   true,
+	"cnpjs":      true,
+	"municipios": true,
+}
+
+func isRetryable(method, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+		return retryableFamilies[endpointFamily(path)]
+	}
+	return false
+}
+
+// backoff devolve a espera antes da tentativa attempt+1 (attempt começa em 1).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	// jitter entre 50% e 100% do valor calculado, para não sincronizar rajadas.
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryDelay decide se a resposta/erro merece nova tentativa e quanto esperar.
+func (p RetryPolicy) retryDelay(attempt int, resp *http.Response, err error) (time.Duration, string, bool) {
+	switch {
+	case err != nil:
+		return p.backoff(attempt), err.Error(), true
+	case resp.StatusCode == http.StatusTooManyRequests:
+		wait, ok := rateLimitReset(resp.Header, time.Now())
+		if !ok {
+			wait = p.backoff(attempt)
+		}
+		return wait, "HTTP 429", true
+	case resp.StatusCode >= 500:
+		return p.backoff(attempt), "HTTP " + strconv.Itoa(resp.StatusCode), true
+	}
+	return 0, "", false
+}
+
+// fitsDeadline informa se é possível esperar d sem estourar o deadline do context
+// (ou MaxDelay, quando não há deadline).
+func (p RetryPolicy) fitsDeadline(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline) > d
+	}
+	return p.MaxDelay <= 0 || d <= p.MaxDelay
+}
+
+// rateLimitReset interpreta Rate-Limit-Reset (segundos até o reset, ou epoch em segundos)
+// com fallback para Retry-After.
+func rateLimitReset(h http.Header, now time.Time) (time.Duration, bool) {
+	for _, key := range []string{"Rate-Limit-Reset", "Retry-After"} {
+		v := strings.TrimSpace(h.Get(key))
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || n < 0 {
+			if t, err := http.ParseTime(v); err == nil {
+				return max(t.Sub(now), 0), true
+			}
+			continue
+		}
+		// valores muito grandes são epoch (segundos), não duração
+		if n > 1e9 {
+			return max(time.Unix(int64(n), 0).Sub(now), 0), true
+		}
+		return time.Duration(n * float64(time.Second)), true
+	}
+	return 0, false
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,58 @@
+package focus_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/focus/focustest"
+)
+
+var fastRetry = focus.WithRetryPolicy(focus.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond})
+
+func TestRetryLookupOn5xx(t *testing.T) {
+	srv := focustest.NewServer()
+	defer srv.Close()
+	client := focus.NewClient(srv.URL, "token", fastRetry)
+
+	srv.FailNext(http.MethodGet, "/v2/empresas", http.StatusServiceUnavailable, 2)
+	resp, err := client.ListEmpresas(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("esperava 200 após o retry, veio %d", resp.StatusCode)
+	}
+	if n := len(srv.Requests()); n != 3 {
+		t.Fatalf("esperava 3 tentativas, veio %d", n)
+	}
+}
+
+func TestNoRetryOnDocumentCancellation(t *testing.T) {
+	srv := focustest.NewServer()
+	defer srv.Close()
+	client := focus.NewClient(srv.URL, "token", fastRetry)
+
+	for _, call := range []func() (*http.Response, error){
+		func() (*http.Response, error) { return client.CancelNFe(context.Background(), "ref1", []byte(`{}`)) },
+		func() (*http.Response, error) { return client.CancelNFSe(context.Background(), "ref1", []byte(`{}`)) },
+		func() (*http.Response, error) { return client.CancelMDFe(context.Background(), "ref1", []byte(`{}`)) },
+	} {
+		srv.FailNext("", "/v2/", http.StatusBadGateway, 1)
+		before := len(srv.Requests())
+		resp, err := call()
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadGateway {
+			t.Fatalf("esperava o 502 repassado, veio %d", resp.StatusCode)
+		}
+		if n := len(srv.Requests()) - before; n != 1 {
+			t.Fatalf("cancelamento não pode ser repetido: %d requisições", n)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package focus
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// CT-e (modelo 57) e MDF-e (modelo 58). Exigem o token da empresa (ForCompany).
+
+func (c *Client) EmitCTe(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, "/v2/cte", "ref="+url.QueryEscape(ref), body)
+}
+
+func (c *Client) GetCTe(ctx context.Context, ref string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, "/v2/cte/"+url.PathEscape(ref), "", nil)
+}
+
+// CancelCTe: body = {"justificativa": "..."} (15 a 255 caracteres)
+func (c *Client) CancelCTe(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, "/v2/cte/"+url.PathEscape(ref), "", body)
+}
+
+func (c *Client) EmitMDFe(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, "/v2/mdfe", "ref="+url.QueryEscape(ref), body)
+}
+
+func (c *Client) GetMDFe(ctx context.Context, ref string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, "/v2/mdfe/"+url.PathEscape(ref), "", nil)
+}
+
+// CancelMDFe: body = {"justificativa": "..."} (15 a 255 caracteres)
+func (c *Client) CancelMDFe(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, "/v2/mdfe/"+url.PathEscape(ref), "", body)
+}
+
+// EncerrarMDFe: body = {"data": "AAAA-MM-DD", "sigla_uf": "..", "nome_municipio": "..."}
+func (c *Client) EncerrarMDFe(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, "/v2/mdfe/"+url.PathEscape(ref)+"/encerrar", "", body)
+}
+
+// IncluirCondutorMDFe: body = {"nome": "...", "cpf": "..."}
+func (c *Client) IncluirCondutorMDFe(ctx context.Context, ref string, body []byte) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, "/v2/mdfe/"+url.PathEscape(ref)+"/inclusao_condutor", "", body)
+}
@@ -0,0 +1,114 @@
+package focus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/seuuser/focus-integration-service/internal/model"
+)
+
+// Métodos tipados: mesma chamada dos métodos "proxy" (que devolvem *http.Response),
+// mas já decodificam o sucesso no model correspondente e o erro em *APIError.
+// Usados por handlers que precisam inspecionar a resposta e por jobs em background.
+
+func (c *Client) CreateEmpresaTyped(ctx context.Context, body []byte) (*model.FocusEmpresaResponse, error) {
+	return decodeResponse[*model.FocusEmpresaResponse](c.CreateEmpresa(ctx, body))
+}
+
+func (c *Client) ListEmpresasTyped(ctx context.Context, rawQuery string) ([]model.FocusEmpresaResponse, error) {
+	return decodeResponse[[]model.FocusEmpresaResponse](c.ListEmpresas(ctx, rawQuery))
+}
+
+func (c *Client) GetEmpresaTyped(ctx context.Context, id string) (*model.FocusEmpresaResponse, error) {
+	return decodeResponse[*model.FocusEmpresaResponse](c.GetEmpresa(ctx, id))
+}
+
+func (c *Client) UpdateEmpresaTyped(ctx context.Context, id string, body []byte) (*model.FocusEmpresaResponse, error) {
+	return decodeResponse[*model.FocusEmpresaResponse](c.UpdateEmpresa(ctx, id, body))
+}
+
+func (c *Client) GetCNPJTyped(ctx context.Context, cnpj14 string) (*model.FocusCnpjResponse, error) {
+	return decodeResponse[*model.FocusCnpjResponse](c.GetCNPJ(ctx, cnpj14))
+}
+
+func (c *Client) GetCEPTyped(ctx context.Context, cep8 string) (*model.FocusCepResponse, error) {
+	return decodeResponse[*model.FocusCepResponse](c.GetCEP(ctx, cep8))
+}
+
+func (c *Client) ListMunicipiosTyped(ctx context.Context, rawQuery string) ([]model.FocusMunicipioResponse, error) {
+	return decodeResponse[[]model.FocusMunicipioResponse](c.ListMunicipios(ctx, rawQuery))
+}
+
+func (c *Client) GetMunicipioTyped(ctx context.Context, codigoMunicipio string) (*model.FocusMunicipioResponse, error) {
+	return decodeResponse[*model.FocusMunicipioResponse](c.GetMunicipio(ctx, codigoMunicipio))
+}
+
+func (c *Client) ListNCMsTyped(ctx context.Context, rawQuery string) ([]model.FocusNcmResponse, error) {
+	return decodeResponse[[]model.FocusNcmResponse](c.ListNCMs(ctx, rawQuery))
+}
+
+func (c *Client) GetNCMTyped(ctx context.Context, codigo string) (*model.FocusNcmResponse, error) {
+	return decodeResponse[*model.FocusNcmResponse](c.GetNCM(ctx, codigo))
+}
+
+func (c *Client) ListCFOPsTyped(ctx context.Context, rawQuery string) ([]model.FocusCfopResponse, error) {
+	return decodeResponse[[]model.FocusCfopResponse](c.ListCFOPs(ctx, rawQuery))
+}
+
+func (c *Client) GetCFOPTyped(ctx context.Context, codigo string) (*model.FocusCfopResponse, error) {
+	return decodeResponse[*model.FocusCfopResponse](c.GetCFOP(ctx, codigo))
+}
+
+// DecodeResponse lê e fecha resp.Body. Status >= 400 vira *APIError; sucesso é decodificado em out
+// (out pode ser nil para ignorar o corpo). Devolve também os bytes lidos, para quem precisa proxiar.
+func DecodeResponse(resp *http.Response, out any) ([]byte, error) {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler resposta da Focus: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return body, ParseAPIError(resp.StatusCode, body)
+	}
+
+	if out != nil && len(body) > 0 {
+		if err := json.Unmarshal(body, out); err != nil {
+			return body, fmt.Errorf("erro ao decodificar resposta da Focus: %w", err)
+		}
+	}
+
+	return body, nil
+}
+
+func decodeResponse[T any](resp *http.Response, err error) (T, error) {
+	var out T
+	if err != nil {
+		return out, err
+	}
+	if _, err := DecodeResponse(resp, &out); err != nil {
+		var zero T
+		return zero, err
+	}
+	return out, nil
+}
+
+func (c *Client) CreateHookTyped(ctx context.Context, body []byte) (*model.FocusHookResponse, error) {
+	return decodeResponse[*model.FocusHookResponse](c.CreateHook(ctx, body))
+}
+
+func (c *Client) ListHooksTyped(ctx context.Context) ([]model.FocusHookResponse, error) {
+	return decodeResponse[[]model.FocusHookResponse](c.ListHooks(ctx))
+}
+
+func (c *Client) GetHookTyped(ctx context.Context, id string) (*model.FocusHookResponse, error) {
+	return decodeResponse[*model.FocusHookResponse](c.GetHook(ctx, id))
+}
+
+func (c *Client) ListBackupsTyped(ctx context.Context, cnpj14 string) (*model.FocusBackupsResponse, error) {
+	return decodeResponse[*model.FocusBackupsResponse](c.ListBackups(ctx, cnpj14))
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/model"
+	"github.com/seuuser/focus-integration-service/internal/supabase"
+)
+
+var backupMes = regexp.MustCompile(`^\d{4}-\d{2}$`)
+
+// Validade (segundos) das URLs assinadas devolvidas após arquivar um backup.
+const backupSignedURLTTL = 3600
+
+type BackupsHandler struct {
+	focus  *focus.Client
+	bucket string
+}
+
+func NewBackupsHandler(focusClient *focus.Client, bucket string) *BackupsHandler {
+	return &BackupsHandler{focus: focusClient, bucket: bucket}
+}
+
+// ListBackups godoc
+// @Summary      Lista backups mensais de XML da empresa
+// @Description  Proxy para Focus: GET /v2/backups/{cnpj}.json com o token da empresa. O CNPJ é lido de companies (Supabase).
+// @Tags         Backups
+// @Produce      json
+// @Param        company_id  path      string  true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Success      200         {object}  model.FocusBackupsResponse
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/companies/{company_id}/backups [get]
+func (h *BackupsHandler) ListBackups(w http.ResponseWriter, r *http.Request) {
+	fc, _, cnpj, ok := h.company(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.ListBackups(r.Context(), cnpj)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyResponse(w, resp)
+}
+
+// ArchiveBackup godoc
+// @Summary      Arquiva backup mensal de XML no Supabase Storage
+// @Description  Baixa da Focus os arquivos (zip de XMLs e de DANFEs) do mês informado e grava no bucket de arquivamento (FOCUS_ARCHIVE_BUCKET),
+// @Description  em {company_id}/backups/{ambiente}/{mes}/. Devolve os caminhos e URLs assinadas válidas por 1 hora.
+// @Tags         Backups
+// @Produce      json
+// @Param        company_id  path      string  true  "ID da empresa (companies.id)"
+// @Param        mes         path      string  true  "Mês do backup (AAAA-MM)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Success      200         {object}  model.CompanyBackupArchiveResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      502         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/companies/{company_id}/backups/{mes} [post]
+func (h *BackupsHandler) ArchiveBackup(w http.ResponseWriter, r *http.Request) {
+	mes := chi.URLParam(r, "mes")
+	if !backupMes.MatchString(mes) {
+		writeJSONError(w, http.StatusBadRequest, "mes inválido: use o formato AAAA-MM")
+		return
+	}
+
+	fc, creds, cnpj, ok := h.company(w, r)
+	if !ok {
+		return
+	}
+
+	backups, err := fc.ListBackupsTyped(r.Context(), cnpj)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	var backup *model.FocusBackup
+	for i := range backups.Backups {
+		// a Focus já devolveu o mês com e sem hífen
+		if strings.ReplaceAll(backups.Backups[i].Mes, "-", "") == strings.ReplaceAll(mes, "-", "") {
+			backup = &backups.Backups[i]
+			break
+		}
+	}
+	if backup == nil {
+		writeJSONError(w, http.StatusNotFound, "backup do mês "+mes+" não encontrado na Focus")
+		return
+	}
+
+	out := model.CompanyBackupArchiveResponse{Mes: mes, Bucket: h.bucket}
+	dir := path.Join(creds.CompanyID, "backups", string(creds.Environment), mes)
+
+	for _, f := range []struct {
+		url, name   string
+		storagePath *string
+		signedURL   *string
+	}{
+		{backup.XMLs, "xmls.zip", &out.XMLsStoragePath, &out.XMLsURL},
+		{backup.Danfes, "danfes.zip", &out.DanfesStoragePath, &out.DanfesURL},
+	} {
+		if f.url == "" {
+			continue
+		}
+		objectPath := path.Join(dir, f.name)
+		if err := h.copy(r.Context(), fc, f.url, objectPath); err != nil {
+			log.Printf("[backups] erro ao arquivar %s (company_id=%s mes=%s): %v", f.name, creds.CompanyID, mes, err)
+			writeFocusClientError(w, http.StatusBadGateway, err)
+			return
+		}
+		*f.storagePath = objectPath
+		if signed, err := supabase.SignedURL(h.bucket, objectPath, backupSignedURLTTL); err == nil {
+			*f.signedURL = signed
+		} else {
+			log.Printf("[backups] %v", err)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+// copy baixa o zip da Focus e o envia ao Storage sem carregá-lo inteiro em memória.
+func (h *BackupsHandler) copy(ctx context.Context, fc *focus.Client, url, objectPath string) error {
+	resp, err := fc.DownloadArquivo(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return focus.ParseAPIError(resp.StatusCode, body)
+	}
+	return supabase.UploadFile(h.bucket, objectPath, "application/zip", resp.Body)
+}
+
+// company resolve o token da empresa do path {company_id} e o CNPJ gravado em companies.
+func (h *BackupsHandler) company(w http.ResponseWriter, r *http.Request) (*focus.Client, focus.CompanyCredentials, string, bool) {
+	companyID := chi.URLParam(r, "company_id")
+
+	fc, creds, ok := companyFocusClientFor(w, r, h.focus, companyID)
+	if !ok {
+		return nil, focus.CompanyCredentials{}, "", false
+	}
+
+	cnpj, _, err := supabase.GetCompanyCNPJ(companyID)
+	if err != nil {
+		log.Printf("[supabase] erro ao buscar CNPJ da empresa (company_id=%s): %v", companyID, err)
+		writeJSONError(w, http.StatusInternalServerError, "erro ao buscar CNPJ da empresa")
+		return nil, focus.CompanyCredentials{}, "", false
+	}
+	if !cnpjDigits14.MatchString(cnpj) {
+		writeJSONError(w, http.StatusUnprocessableEntity, "Empresa sem CNPJ válido cadastrado: os backups da Focus são organizados por CNPJ.")
+		return nil, focus.CompanyCredentials{}, "", false
+	}
+	return fc, creds, cnpj, true
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/model"
+)
+
+var cepDigits8 = regexp.MustCompile(`^\d{8}$`)
+
+// O mesmo CEP costuma ser consultado várias vezes enquanto o usuário preenche o cadastro.
+const cepCacheTTL = 10 * time.Minute
+
+type CepsHandler struct {
+	focus *focus.Client
+	ceps  *ttlCache[[]byte]
+}
+
+func NewCepsHandler(focusClient *focus.Client) *CepsHandler {
+	return &CepsHandler{
+		focus: focusClient,
+		ceps:  newTTLCache[[]byte](cepCacheTTL),
+	}
+}
+
+// GetCep godoc
+// @Summary      Consulta endereço por CEP
+// @Description  Proxy para Focus: GET /v2/ceps/{cep}. Informe 8 dígitos (somente números).
+// @Description  Respostas encontradas ficam em cache por alguns minutos; use para preencher logradouro, bairro, município e UF do cadastro da empresa.
+// @Tags         CEPs
+// @Produce      json
+// @Param        cep  path      string  true  "CEP (8 dígitos, somente números)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
+// @Success      200  {object}  model.FocusCepResponse
+// @Failure      400  {object}  RawPayload
+// @Failure      401  {object}  RawPayload
+// @Failure      404  {object}  RawPayload
+// @Failure      429  {object}  RawPayload
+// @Failure      500  {object}  RawPayload
+// @Failure      503  {object}  RawPayload
+// @Router       /v2/ceps/{cep} [get]
+func (h *CepsHandler) GetCep(w http.ResponseWriter, r *http.Request) {
+	cep := chi.URLParam(r, "cep")
+	if cep == "" {
+		writeJSONError(w, http.StatusBadRequest, "cep é obrigatório")
+		return
+	}
+	if !cepDigits8.MatchString(cep) {
+		writeJSONError(w, http.StatusBadRequest, "cep inválido: informe 8 dígitos numéricos (somente números)")
+		return
+	}
+
+	key := string(focus.EnvironmentFromContext(r.Context())) + "/" + cep
+	if body, ok := h.ceps.Get(key); ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+		return
+	}
+
+	resp, err := h.focus.GetCEP(r.Context(), cep)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "erro ao ler resposta da Focus")
+		return
+	}
+	if resp.StatusCode == http.StatusOK {
+		h.ceps.Set(key, body)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	proxyResponse(w, resp)
+}
+
+// keep model import used for swag (avoid unused if build tags differ)
+var _ = model.FocusCepResponse{}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Identificadores da ICP-Brasil (DOC-ICP-04): políticas de certificado (2.16.76.1.2.*) e campos
+// otherName do SAN (2.16.76.1.3.*). O CNPJ do titular de um e-CNPJ fica em 2.16.76.1.3.3.
+var (
+	oidICPBrasilPolicies = asn1.ObjectIdentifier{2, 16, 76, 1, 2}
+	oidICPBrasilSAN      = asn1.ObjectIdentifier{2, 16, 76, 1, 3}
+	oidICPBrasilCNPJ     = asn1.ObjectIdentifier{2, 16, 76, 1, 3, 3}
+	oidSubjectAltName    = asn1.ObjectIdentifier{2, 5, 29, 17}
+)
+
+// Datas do certificado são exibidas no horário de Brasília.
+var brasilia = time.FixedZone("BRT", -3*60*60)
+
+// validateCertificate abre o certificado A1 (PKCS#12) com a senha antes do envio para a Focus, para que
+// senha errada, certificado vencido ou de outra empresa voltem como erro por campo sem a ida e volta na Focus.
+// cnpj vazio pula a conferência do titular. Formatos que a biblioteca não suporta não bloqueiam: a Focus decide.
+func validateCertificate(arquivoBase64, senha, cnpj string, now time.Time) []focus.FieldError {
+	pfx, err := decodeCertificateBase64(arquivoBase64)
+	if err != nil {
+		return []focus.FieldError{{Campo: "arquivo_certificado_base64", Mensagem: "não está em base64 válido"}}
+	}
+
+	key, cert, caCerts, err := pkcs12.DecodeChain(pfx, senha)
+	if err != nil {
+		var notImplemented pkcs12.NotImplementedError
+		switch {
+		case errors.Is(err, pkcs12.ErrIncorrectPassword), errors.Is(err, pkcs12.ErrDecryption):
+			return []focus.FieldError{{Campo: "senha_certificado", Mensagem: "senha incorreta para o certificado informado"}}
+		case errors.As(err, &notImplemented):
+			log.Printf("[certificado] validação local ignorada: %v", err)
+			return nil
+		default:
+			return []focus.FieldError{{Campo: "arquivo_certificado_base64", Mensagem: "não é um certificado A1 (PKCS#12/PFX) válido"}}
+		}
+	}
+	cert, chain := leafCertificate(key, cert, caCerts)
+
+	var erros []focus.FieldError
+	if now.Before(cert.NotBefore) {
+		erros = append(erros, focus.FieldError{
+			Campo:    "arquivo_certificado_base64",
+			Mensagem: "certificado válido somente a partir de " + cert.NotBefore.In(brasilia).Format("02/01/2006 15:04"),
+		})
+	}
+	if now.After(cert.NotAfter) {
+		erros = append(erros, focus.FieldError{
+			Campo:    "arquivo_certificado_base64",
+			Mensagem: "certificado vencido em " + cert.NotAfter.In(brasilia).Format("02/01/2006"),
+		})
+	}
+	if !isICPBrasil(cert, chain) {
+		erros = append(erros, focus.FieldError{
+			Campo:    "arquivo_certificado_base64",
+			Mensagem: "certificado não foi emitido por uma AC da ICP-Brasil (exigência da SEFAZ)",
+		})
+	}
+
+	// A SEFAZ aceita o certificado da matriz para as filiais: basta a raiz (8 primeiros caracteres) coincidir.
+	want := normalizeCNPJ(cnpj)
+	if got := certificateCNPJ(cert); len(want) == 14 && got != "" && got[:8] != want[:8] {
+		erros = append(erros, focus.FieldError{
+			Campo:    "arquivo_certificado_base64",
+			Mensagem: fmt.Sprintf("certificado pertence ao CNPJ %s, que não corresponde ao CNPJ %s da empresa", got, want),
+		})
+	}
+	return erros
+}
+
+func decodeCertificateBase64(s string) ([]byte, error) {
+	s = strings.Join(strings.Fields(s), "")
+	if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	return base64.RawStdEncoding.DecodeString(s)
+}
+
+// leafCertificate devolve o certificado da chave privada e os demais da cadeia: nem todo PFX exportado
+// (ex: pelo Windows) traz o certificado do titular antes das ACs.
+func leafCertificate(key any, cert *x509.Certificate, caCerts []*x509.Certificate) (*x509.Certificate, []*x509.Certificate) {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return cert, caCerts
+	}
+	pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok {
+		return cert, caCerts
+	}
+
+	all := append([]*x509.Certificate{cert}, caCerts...)
+	for i, c := range all {
+		if pub.Equal(c.PublicKey) {
+			chain := make([]*x509.Certificate, 0, len(all)-1)
+			chain = append(chain, all[:i]...)
+			return c, append(chain, all[i+1:]...)
+		}
+	}
+	return cert, caCerts
+}
+
+// isICPBrasil procura os indícios de um certificado ICP-Brasil: política 2.16.76.1.2.*, campos otherName
+// 2.16.76.1.3.* ou "ICP-Brasil" na organização do emissor / das ACs da cadeia. A cadeia não é verificada
+// até a raiz (muitos PFX não trazem as ACs).
+func isICPBrasil(cert *x509.Certificate, chain []*x509.Certificate) bool {
+	for _, p := range cert.PolicyIdentifiers {
+		if hasOIDPrefix(p, oidICPBrasilPolicies) {
+			return true
+		}
+	}
+	for _, on := range otherNames(cert) {
+		if hasOIDPrefix(on.ID, oidICPBrasilSAN) {
+			return true
+		}
+	}
+	for _, c := range append([]*x509.Certificate{cert}, chain...) {
+		for _, org := range c.Issuer.Organization {
+			if strings.Contains(strings.ToUpper(org), "ICP-BRASIL") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// certificateCNPJ lê o CNPJ do titular: otherName 2.16.76.1.3.3 do SAN ou, na falta dele, o sufixo do CN
+// ("RAZAO SOCIAL:00000000000000"). Vazio se o certificado não identifica um CNPJ (ex: e-CPF).
+func certificateCNPJ(cert *x509.Certificate) string {
+	for _, on := range otherNames(cert) {
+		if on.ID.Equal(oidICPBrasilCNPJ) {
+			if cnpj := normalizeCNPJ(on.Value); len(cnpj) == 14 {
+				return cnpj
+			}
+		}
+	}
+	if i := strings.LastIndex(cert.Subject.CommonName, ":"); i >= 0 {
+		if cnpj := normalizeCNPJ(cert.Subject.CommonName[i+1:]); len(cnpj) == 14 {
+			return cnpj
+		}
+	}
+	return ""
+}
+
+// normalizeCNPJ remove a pontuação e mantém letras (CNPJ alfanumérico) e números.
+func normalizeCNPJ(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if (r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+type otherName struct {
+	ID    asn1.ObjectIdentifier
+	Value string
+}
+
+// otherNames extrai os otherName do SAN, que a biblioteca padrão não expõe.
+func otherNames(cert *x509.Certificate) []otherName {
+	var out []otherName
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidSubjectAltName) {
+			continue
+		}
+		var names asn1.RawValue
+		if _, err := asn1.Unmarshal(ext.Value, &names); err != nil {
+			return nil
+		}
+		rest := names.Bytes
+		for len(rest) > 0 {
+			var gn asn1.RawValue
+			var err error
+			if rest, err = asn1.Unmarshal(rest, &gn); err != nil {
+				return out
+			}
+			if gn.Class != asn1.ClassContextSpecific || gn.Tag != 0 {
+				continue
+			}
+			var on struct {
+				ID    asn1.ObjectIdentifier
+				Value asn1.RawValue `asn1:"explicit,tag:0"`
+			}
+			if _, err := asn1.UnmarshalWithParams(gn.FullBytes, &on, "tag:0"); err != nil {
+				continue
+			}
+			out = append(out, otherName{ID: on.ID, Value: string(on.Value.Bytes)})
+		}
+	}
+	return out
+}
+
+func hasOIDPrefix(oid, prefix asn1.ObjectIdentifier) bool {
+	return len(oid) >= len(prefix) && prefix.Equal(oid[:len(prefix)])
+}
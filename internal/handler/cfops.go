@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/model"
+)
+
+var cfopDigits4 = regexp.MustCompile(`^\d{4}$`)
+
+type CfopsHandler struct {
+	focus *focus.Client
+}
+
+func NewCfopsHandler(focusClient *focus.Client) *CfopsHandler {
+	return &CfopsHandler{focus: focusClient}
+}
+
+// ListCfops godoc
+// @Summary      Lista CFOPs
+// @Description  Proxy para Focus: GET /v2/cfops. Suporta filtros via querystring (codigo, descricao, offset).
+// @Tags         CFOPs
+// @Produce      json
+// @Param        codigo     query     string  false  "Trecho inicial do código (ex: 51)"
+// @Param        descricao  query     string  false  "Trecho da descrição"
+// @Param        offset     query     int     false  "Paginação (offset)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
+// @Success      200        {array}   model.FocusCfopResponse
+// @Failure      401        {object}  RawPayload
+// @Failure      429        {object}  RawPayload
+// @Failure      500        {object}  RawPayload
+// @Failure      503        {object}  RawPayload
+// @Router       /v2/cfops [get]
+func (h *CfopsHandler) ListCfops(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.focus.ListCFOPs(r.Context(), r.URL.RawQuery)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyResponse(w, resp)
+}
+
+// GetCfop godoc
+// @Summary      Busca CFOP por código
+// @Description  Proxy para Focus: GET /v2/cfops/{codigo}. Informe 4 dígitos (somente números).
+// @Tags         CFOPs
+// @Produce      json
+// @Param        codigo  path      string  true  "CFOP (4 dígitos, somente números)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
+// @Success      200     {object}  model.FocusCfopResponse
+// @Failure      400     {object}  RawPayload
+// @Failure      401     {object}  RawPayload
+// @Failure      404     {object}  RawPayload
+// @Failure      429     {object}  RawPayload
+// @Failure      500     {object}  RawPayload
+// @Failure      503     {object}  RawPayload
+// @Router       /v2/cfops/{codigo} [get]
+func (h *CfopsHandler) GetCfop(w http.ResponseWriter, r *http.Request) {
+	codigo := chi.URLParam(r, "codigo")
+	if codigo == "" {
+		writeJSONError(w, http.StatusBadRequest, "codigo é obrigatório")
+		return
+	}
+	if !cfopDigits4.MatchString(codigo) {
+		writeJSONError(w, http.StatusBadRequest, "codigo inválido: informe 4 dígitos numéricos (somente números)")
+		return
+	}
+
+	resp, err := h.focus.GetCFOP(r.Context(), codigo)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyResponse(w, resp)
+}
+
+// keep model import used for swag (avoid unused if build tags differ)
+var _ = model.FocusCfopResponse{}
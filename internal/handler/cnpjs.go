@@ -25,12 +25,14 @@ func NewCnpjsHandler(focusClient *focus.Client) *CnpjsHandler {
 // @Tags         CNPJs
 // @Produce      json
 // @Param        cnpj  path      string  true  "CNPJ (14 dígitos, somente números)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
 // @Success      200   {object}  model.FocusCnpjResponse
 // @Failure      400   {object}  RawPayload
 // @Failure      401   {object}  RawPayload
 // @Failure      404   {object}  RawPayload
 // @Failure      429   {object}  RawPayload
 // @Failure      500   {object}  RawPayload
+// @Failure      503   {object}  RawPayload
 // @Router       /v2/cnpjs/{cnpj} [get]
 func (h *CnpjsHandler) GetCnpj(w http.ResponseWriter, r *http.Request) {
 	cnpj := chi.URLParam(r, "cnpj")
@@ -45,7 +47,7 @@ func (h *CnpjsHandler) GetCnpj(w http.ResponseWriter, r *http.Request) {
 
 	resp, err := h.focus.GetCNPJ(r.Context(), cnpj)
 	if err != nil {
-		writeJSONError(w, http.StatusBadGateway, err.Error())
+		writeFocusClientError(w, http.StatusBadGateway, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/model"
+)
+
+type CteHandler struct {
+	focus *focus.Client
+}
+
+func NewCteHandler(focusClient *focus.Client) *CteHandler {
+	return &CteHandler{focus: focusClient}
+}
+
+// EmitCTe godoc
+// @Summary      Emite CT-e
+// @Description  Proxy para Focus: POST /v2/cte?ref=REF usando o token da empresa (focus_integration).
+// @Description  A empresa precisa estar com habilita_cte na Focus. A emissão e o status são registrados em focus_documents.
+// @Tags         CT-e
+// @Accept       json
+// @Produce      json
+// @Param        company_id  query     string            true  "ID da empresa (companies.id)"
+// @Param        ref         query     string            true  "Referência única do documento (letras, números, '-', '_' e '.')"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.CTeRequest  true  "Payload do CT-e (campos adicionais da Focus são repassados)"
+// @Success      202         {object}  model.CTeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/cte [post]
+func (h *CteHandler) EmitCTe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.CTeRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "payload inválido para CT-e")
+		return
+	}
+	if erros := validateCTe(req); len(erros) > 0 {
+		writeValidationErrors(w, "Payload de CT-e incompleto", erros)
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.EmitCTe(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docCTe, ref, eventEmissao, body)
+}
+
+// GetCTe godoc
+// @Summary      Consulta CT-e
+// @Description  Proxy para Focus: GET /v2/cte/{ref} usando o token da empresa. Atualiza o status em focus_documents.
+// @Tags         CT-e
+// @Produce      json
+// @Param        ref         path      string  true  "Referência do documento"
+// @Param        company_id  query     string  true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Success      200         {object}  model.CTeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/cte/{ref} [get]
+func (h *CteHandler) GetCTe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.GetCTe(r.Context(), ref)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docCTe, ref, eventConsulta, nil)
+}
+
+// CancelCTe godoc
+// @Summary      Cancela CT-e
+// @Description  Proxy para Focus: DELETE /v2/cte/{ref} usando o token da empresa. A justificativa deve ter de 15 a 255 caracteres.
+// @Tags         CT-e
+// @Accept       json
+// @Produce      json
+// @Param        ref         path      string                  true  "Referência do documento"
+// @Param        company_id  query     string                  true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.NFeCancelRequest  true  "Justificativa do cancelamento"
+// @Success      200         {object}  model.CTeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/cte/{ref} [delete]
+func (h *CteHandler) CancelCTe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.NFeCancelRequest
+	if err := json.Unmarshal(body, &req); err != nil || !textLenBetween(req.Justificativa, 15, 255) {
+		writeJSONError(w, http.StatusBadRequest, "justificativa é obrigatória e deve ter entre 15 e 255 caracteres")
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.CancelCTe(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docCTe, ref, eventCancelamento, body)
+}
+
+func validateCTe(req model.CTeRequest) []focus.FieldError {
+	var erros []focus.FieldError
+	required := func(campo, valor string) {
+		if strings.TrimSpace(valor) == "" {
+			erros = append(erros, focus.FieldError{Campo: campo, Mensagem: "obrigatório"})
+		}
+	}
+
+	required("modal", req.Modal)
+	required("cfop", req.Cfop)
+	required("natureza_operacao", req.NaturezaOperacao)
+	required("data_emissao", req.DataEmissao)
+	required("cnpj_emitente", req.CnpjEmitente)
+	required("codigo_municipio_envio", req.CodigoMunicipioEnvio)
+	required("codigo_municipio_inicio", req.CodigoMunicipioInicio)
+	required("codigo_municipio_fim", req.CodigoMunicipioFim)
+	if v, ok := req.ValorTotal.Float(); !ok || v <= 0 {
+		erros = append(erros, focus.FieldError{Campo: "valor_total", Mensagem: "deve ser um número maior que zero"})
+	}
+	erros = append(erros, invalidNumbers(
+		numberField{"tipo_documento", req.TipoDocumento, true},
+		numberField{"tipo_servico", req.TipoServico, true},
+		numberField{"indicador_tomador", req.IndicadorTomador, true},
+		numberField{"valor_receber", req.ValorReceber, false},
+		numberField{"valor_total_carga", req.ValorTotalCarga, false},
+	)...)
+	return erros
+}
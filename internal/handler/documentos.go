@@ -0,0 +1,353 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/model"
+	"github.com/seuuser/focus-integration-service/internal/supabase"
+)
+
+// Tipos de documento fiscal (focus_documents.document_type).
+const (
+	docNFSe  = "nfse"
+	docNFSeN = "nfsen"
+	docNFe   = "nfe"
+	docNFCe  = "nfce"
+	docCTe   = "cte"
+	docMDFe  = "mdfe"
+)
+
+// A Focus aceita como referência letras, números, "-", "_" e ".".
+var documentRefPattern = regexp.MustCompile(`^[A-Za-z0-9_.\-]{1,50}$`)
+
+// companyFocusClient devolve um client Focus autenticado com o token da empresa do company_id (querystring).
+func companyFocusClient(w http.ResponseWriter, r *http.Request, base *focus.Client) (*focus.Client, focus.CompanyCredentials, bool) {
+	companyID := r.URL.Query().Get("company_id")
+	if companyID == "" {
+		writeJSONError(w, http.StatusBadRequest, "company_id é obrigatório")
+		return nil, focus.CompanyCredentials{}, false
+	}
+	return companyFocusClientFor(w, r, base, companyID)
+}
+
+// companyFocusClientFor é o companyFocusClient para rotas que recebem a empresa no path (/v2/companies/{company_id}/...).
+func companyFocusClientFor(w http.ResponseWriter, r *http.Request, base *focus.Client, companyID string) (*focus.Client, focus.CompanyCredentials, bool) {
+	fc, err := base.ForCompany(r.Context(), companyID)
+	if errors.Is(err, focus.ErrCompanyNotIntegrated) {
+		writeJSONError(w, http.StatusUnprocessableEntity, "Empresa não integrada com a Focus. Conclua o cadastro da empresa antes de emitir ou consultar documentos.")
+		return nil, focus.CompanyCredentials{}, false
+	}
+	// Nunca troca o ambiente pedido pelo da empresa: uma emissão de teste iria para produção (e vice-versa).
+	var envErr *focus.EnvironmentMismatchError
+	if errors.As(err, &envErr) {
+		writeJSONError(w, http.StatusConflict, fmt.Sprintf("Empresa integrada em %s, mas a requisição pediu o ambiente %s. Omita X-Focus-Environment/ambiente ou use o ambiente da empresa.", envErr.Company, envErr.Requested))
+		return nil, focus.CompanyCredentials{}, false
+	}
+	if err != nil {
+		log.Printf("[focus] erro ao resolver token da empresa (company_id=%s): %v", companyID, err)
+		writeJSONError(w, http.StatusInternalServerError, "erro ao obter credenciais Focus da empresa")
+		return nil, focus.CompanyCredentials{}, false
+	}
+
+	creds, _ := fc.Company()
+	return fc, creds, true
+}
+
+// documentRef lê a referência do documento do path ({ref}) ou, na emissão, da querystring (?ref=).
+func documentRef(w http.ResponseWriter, r *http.Request) (string, bool) {
+	ref := chi.URLParam(r, "ref")
+	if ref == "" {
+		ref = r.URL.Query().Get("ref")
+	}
+	if ref == "" {
+		writeJSONError(w, http.StatusBadRequest, "ref é obrigatório")
+		return "", false
+	}
+	if !documentRefPattern.MatchString(ref) {
+		writeJSONError(w, http.StatusBadRequest, "ref inválido: use até 50 caracteres entre letras, números, '-', '_' e '.'")
+		return "", false
+	}
+	return ref, true
+}
+
+// invalidNumber confere um campo numérico opcional do payload: ausente é aceito; presente, precisa ser número
+// (ou texto numérico, como a Focus aceita) e, se integer, sem casas decimais.
+func invalidNumber(campo string, n model.Numero, integer bool) []focus.FieldError {
+	if n.Vazio() {
+		return nil
+	}
+	if integer {
+		if _, ok := n.Int(); !ok {
+			return []focus.FieldError{{Campo: campo, Mensagem: "deve ser um número inteiro"}}
+		}
+		return nil
+	}
+	if _, ok := n.Float(); !ok {
+		return []focus.FieldError{{Campo: campo, Mensagem: "deve ser numérico"}}
+	}
+	return nil
+}
+
+// numberField é um campo numérico do payload conferido por invalidNumbers.
+type numberField struct {
+	campo   string
+	valor   model.Numero
+	integer bool
+}
+
+// invalidNumbers aplica invalidNumber a cada campo, na ordem.
+func invalidNumbers(fields ...numberField) []focus.FieldError {
+	var erros []focus.FieldError
+	for _, f := range fields {
+		erros = append(erros, invalidNumber(f.campo, f.valor, f.integer)...)
+	}
+	return erros
+}
+
+// Eventos gravados em focus_document_events.
+const (
+	eventEmissao          = "emissao"
+	eventConsulta         = "consulta"
+	eventCancelamento     = "cancelamento"
+	eventCartaCorrecao    = "carta_correcao"
+	eventInutilizacao     = "inutilizacao"
+	eventContingencia     = "contingencia_offline"
+	eventEncerramento     = "encerramento"
+	eventInclusaoCondutor = "inclusao_condutor"
+)
+
+// proxyDocumentResponse grava o estado do documento em focus_documents (e o evento em focus_document_events)
+// e repassa a resposta da Focus. payload é o corpo enviado à Focus (nil nas consultas).
+// Devolve o documento gravado (ok=false se a resposta da Focus não pôde ser lida).
+func proxyDocumentResponse(w http.ResponseWriter, resp *http.Response, creds focus.CompanyCredentials, docType, ref, event string, payload []byte) (supabase.FocusDocument, bool) {
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "erro ao ler resposta da Focus")
+		return supabase.FocusDocument{}, false
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBytes))
+
+	doc := documentFromResponse(creds, docType, ref, resp.StatusCode, respBytes)
+	if event == eventEmissao {
+		doc.Payload = payload
+	}
+	_ = recordDocument(doc, event, payload)
+
+	proxyResponse(w, resp)
+	return doc, true
+}
+
+// Status finais: o documento já passou pela SEFAZ e uma emissão recusada depois (reenvio da mesma ref,
+// 429...) não o altera.
+var finalDocumentStatuses = map[string]bool{"autorizado": true, "cancelado": true, "denegado": true}
+
+// recordDocument grava o documento e registra o evento. Consultas só viram evento quando o status muda.
+// Falhas de gravação são logadas; o erro da gravação do documento também é devolvido (o webhook refaz
+// a entrega). Nas respostas da API ele é ignorado: a resposta da Focus já é a fonte da verdade para o cliente.
+func recordDocument(doc supabase.FocusDocument, event string, payload []byte) error {
+	var anterior string
+	if prev, err := supabase.GetFocusDocument(doc.CompanyID, doc.DocumentType, doc.Ref); err == nil && prev != nil {
+		anterior = prev.Status
+	}
+
+	// Uma operação recusada pela Focus (consulta 404, cancelamento negado...) não altera o documento:
+	// só a emissão recusada grava status "erro", e só se a ref ainda não tem um documento em status final.
+	// Cancelamentos/cartas e reenvios recusados ficam apenas como evento.
+	rejected := doc.Status == "erro"
+	if rejected && event == eventConsulta {
+		return nil
+	}
+	if !rejected || (event == eventEmissao && !finalDocumentStatuses[anterior]) {
+		if err := supabase.UpsertFocusDocument(doc); err != nil {
+			log.Printf("[supabase] erro ao gravar focus_documents (%s ref=%s): %v", doc.DocumentType, doc.Ref, err)
+			return fmt.Errorf("erro ao gravar focus_documents: %w", err)
+		}
+	}
+
+	if event == eventConsulta && doc.Status == anterior {
+		return nil
+	}
+	ev := supabase.FocusDocumentEvent{
+		CompanyID:      doc.CompanyID,
+		DocumentType:   doc.DocumentType,
+		Ref:            doc.Ref,
+		Event:          event,
+		StatusAnterior: anterior,
+		Status:         doc.Status,
+		Mensagem:       doc.Mensagem,
+	}
+	if event != eventEmissao && json.Valid(payload) {
+		ev.Payload = payload
+	}
+	if err := supabase.InsertFocusDocumentEvent(ev); err != nil {
+		log.Printf("[supabase] erro ao gravar focus_document_events (%s ref=%s): %v", doc.DocumentType, doc.Ref, err)
+	}
+	return nil
+}
+
+// documentFromResponse extrai os campos comuns do retorno da Focus (as chaves variam por tipo de documento).
+func documentFromResponse(creds focus.CompanyCredentials, docType, ref string, statusCode int, body []byte) supabase.FocusDocument {
+	doc := supabase.FocusDocument{
+		CompanyID:    creds.CompanyID,
+		DocumentType: docType,
+		Ref:          ref,
+		Environment:  string(creds.Environment),
+	}
+	if json.Valid(body) {
+		doc.Response = body
+	}
+
+	if statusCode >= 400 {
+		apiErr := focus.ParseAPIError(statusCode, body)
+		doc.Status = "erro"
+		doc.Mensagem = apiErr.Error()
+		return doc
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(body, &m); err != nil {
+		return doc
+	}
+	doc.Status = firstString(m, "status")
+	doc.Numero = firstString(m, "numero")
+	doc.Serie = firstString(m, "serie")
+	doc.Chave = firstString(m, "chave_nfe", "chave_nfse", "chave_cte", "chave_mdfe", "chave")
+	doc.CodigoVerificacao = firstString(m, "codigo_verificacao")
+	doc.Mensagem = firstString(m, "mensagem_sefaz", "mensagem")
+	doc.CaminhoXML = firstString(m, "caminho_xml_nota_fiscal", "caminho_xml")
+	doc.CaminhoPDF = firstString(m, "caminho_danfe", "url_danfse", "caminho_dacte", "caminho_damdfe", "url")
+	return doc
+}
+
+func firstString(m map[string]any, keys ...string) string {
+	for _, k := range keys {
+		switch v := m[k].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return strconv.FormatInt(int64(v), 10)
+		}
+	}
+	return ""
+}
+
+// writeValidationErrors responde 422 com erros por campo, no mesmo formato `erros[]` da Focus.
+func writeValidationErrors(w http.ResponseWriter, message string, erros []focus.FieldError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"error": message,
+		"erros": erros,
+	})
+}
+
+// listDocuments responde o histórico de documentos de um tipo para a empresa do company_id (lido do Supabase).
+// Aceita ?status=, ?limit= (padrão 50, máx 100) e ?offset=; o total vai em X-Total-Count.
+func listDocuments(w http.ResponseWriter, r *http.Request, docType string) {
+	q := r.URL.Query()
+	companyID := q.Get("company_id")
+	if companyID == "" {
+		writeJSONError(w, http.StatusBadRequest, "company_id é obrigatório")
+		return
+	}
+
+	limit, err := queryInt(q.Get("limit"), 50)
+	if err != nil || limit < 1 || limit > 100 {
+		writeJSONError(w, http.StatusBadRequest, "limit inválido: informe um número entre 1 e 100")
+		return
+	}
+	offset, err := queryInt(q.Get("offset"), 0)
+	if err != nil || offset < 0 {
+		writeJSONError(w, http.StatusBadRequest, "offset inválido")
+		return
+	}
+
+	docs, total, err := supabase.ListFocusDocuments(supabase.FocusDocumentsFilter{
+		CompanyID:    companyID,
+		DocumentType: docType,
+		Status:       q.Get("status"),
+		Limit:        limit,
+		Offset:       offset,
+	})
+	if err != nil {
+		log.Printf("[supabase] erro ao listar focus_documents (%s company_id=%s): %v", docType, companyID, err)
+		writeJSONError(w, http.StatusInternalServerError, "erro ao listar documentos")
+		return
+	}
+	if docs == nil {
+		docs = []supabase.FocusDocument{}
+	}
+
+	w.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+	writeJSON(w, http.StatusOK, docs)
+}
+
+// documentEvents responde a linha do tempo (focus_document_events) de um documento.
+func documentEvents(w http.ResponseWriter, r *http.Request, docType string) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	companyID := r.URL.Query().Get("company_id")
+	if companyID == "" {
+		writeJSONError(w, http.StatusBadRequest, "company_id é obrigatório")
+		return
+	}
+
+	events, err := supabase.ListFocusDocumentEvents(companyID, docType, ref)
+	if err != nil {
+		log.Printf("[supabase] erro ao listar focus_document_events (%s ref=%s): %v", docType, ref, err)
+		writeJSONError(w, http.StatusInternalServerError, "erro ao listar eventos do documento")
+		return
+	}
+	if events == nil {
+		events = []supabase.FocusDocumentEvent{}
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
+func queryInt(v string, def int) (int, error) {
+	if v == "" {
+		return def, nil
+	}
+	return strconv.Atoi(v)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// logDocumentError registra em focus_integration_errors (mesma tabela usada pelo CreateEmpresa) a recusa de um documento:
+// erro da API da Focus (HTTP >= 400) ou rejeição da SEFAZ (erro_autorizacao / denegado).
+func logDocumentError(doc supabase.FocusDocument, statusCode int, label string) {
+	var code, message string
+	var erros json.RawMessage
+
+	switch {
+	case statusCode >= 400:
+		apiErr := focus.ParseAPIError(statusCode, doc.Response)
+		code, message, erros = apiErr.Codigo, apiErr.Mensagem, apiErr.ErrosJSON()
+	case doc.Status == "erro_autorizacao" || doc.Status == "denegado":
+		code, message = doc.Status, doc.Mensagem
+	default:
+		return
+	}
+
+	if err := supabase.InsertFocusIntegrationError(doc.CompanyID, code, label+" "+doc.Ref+": "+message, erros, ""); err != nil {
+		log.Printf("[supabase] erro ao salvar log de integração Focus: %v", err)
+	}
+}
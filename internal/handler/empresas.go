@@ -1,10 +1,15 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"iter"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 	"bytes"
@@ -12,6 +17,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/seuuser/focus-integration-service/internal/focus"
 	"github.com/seuuser/focus-integration-service/internal/model"
+	"github.com/seuuser/focus-integration-service/internal/outbox"
 	"github.com/seuuser/focus-integration-service/internal/supabase"
 )
 
@@ -20,26 +26,42 @@ import (
 type RawPayload map[string]any
 
 type EmpresasHandler struct {
-	focus *focus.Client
+	focus   *focus.Client
+	webhook WebhookConfig
+	outbox  *outbox.Outbox
 }
 
-func NewEmpresasHandler(focusClient *focus.Client) *EmpresasHandler {
-	return &EmpresasHandler{focus: focusClient}
+func NewEmpresasHandler(focusClient *focus.Client, webhook WebhookConfig, ob *outbox.Outbox) *EmpresasHandler {
+	return &EmpresasHandler{focus: focusClient, webhook: webhook, outbox: ob}
 }
 
 // CreateEmpresa godoc
 // @Summary      Cria uma nova empresa na Focus
 // @Description  Proxy para Focus: POST /v2/empresas
+// @Description  Com FOCUS_WEBHOOK_URL configurada, cadastra também os gatilhos (FOCUS_WEBHOOK_EVENTS) apontando para /webhooks/focus.
+// @Description  Se a empresa já está integrada (focus_integration) ou o CNPJ/CPF já existe na Focus, a empresa existente é atualizada
+// @Description  (PUT) em vez de criada de novo: a resposta é 200 e X-Integration-Status = adopted (created no cadastro novo).
+// @Description  Se a verificação não puder ser feita (Supabase ou Focus indisponível), responde 503 sem cadastrar.
+// @Description  Se, depois do cadastro, nem a integração nem o outbox puderem ser gravados no Supabase, responde 503: repetir adota a empresa.
+// @Description  Com o header Idempotency-Key, repetições com o mesmo payload devolvem a resposta original (Idempotent-Replayed: true).
+// @Description  O certificado (arquivo_certificado_base64 + senha_certificado) é aberto localmente antes do envio: senha incorreta,
+// @Description  certificado fora da validade, sem indícios de ICP-Brasil ou de outro CNPJ (raiz) respondem 422 com erros por campo.
 // @Tags         Empresas
 // @Accept       json
 // @Produce      json
 // @Param        company_id  query  string  true  "ID da empresa (companies.id)"
 // @Param        payload  body      model.FocusEmpresaCreateRequest  true  "Dados da empresa"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
+// @Param        Idempotency-Key      header  string  false  "Chave única da submissão (até 255 caracteres), válida por 24h"
 // @Success      201      {object}  model.FocusEmpresaResponse
+// @Success      200      {object}  model.FocusEmpresaResponse
 // @Failure      400      {object}  RawPayload
 // @Failure      401      {object}  RawPayload
+// @Failure      409      {object}  RawPayload
+// @Failure      422      {object}  RawPayload
 // @Failure      429      {object}  RawPayload
 // @Failure      500      {object}  RawPayload
+// @Failure      503      {object}  RawPayload
 // @Router       /v2/empresas [post]
 func (h *EmpresasHandler) CreateEmpresa(w http.ResponseWriter, r *http.Request) {
 	companyID := r.URL.Query().Get("company_id")
@@ -53,13 +75,45 @@ func (h *EmpresasHandler) CreateEmpresa(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Senha errada, certificado vencido ou de outro CNPJ são recusados antes de chamar a Focus.
+	var certReq struct {
+		CNPJ                       string `json:"cnpj"`
+		ArquivoCertBase64          string `json:"arquivo_certificado_base64"`
+		SenhaCertificado           string `json:"senha_certificado"`
+		DatabaseLocalCertificateID string `json:"database_local_certificate_id"`
+	}
+	if json.Unmarshal(body, &certReq) == nil && certReq.ArquivoCertBase64 != "" {
+		if erros := validateCertificate(certReq.ArquivoCertBase64, certReq.SenhaCertificado, certReq.CNPJ, time.Now()); len(erros) > 0 {
+			apiErr := &focus.APIError{Erros: erros}
+			if logErr := supabase.InsertFocusIntegrationError(
+				companyID,
+				"certificado_invalido",
+				"Certificado digital recusado na validação local",
+				apiErr.ErrosJSON(),
+				certReq.DatabaseLocalCertificateID,
+			); logErr != nil {
+				log.Printf("[supabase] erro ao salvar log de integração Focus: %v", logErr)
+			}
+			writeValidationErrors(w, "Certificado digital inválido", erros)
+			return
+		}
+	}
+
+	withIdempotency(w, r, companyID, "create_empresa", body, func(w http.ResponseWriter) {
+		h.createEmpresa(w, r, companyID, body)
+	})
+}
+
+func (h *EmpresasHandler) createEmpresa(w http.ResponseWriter, r *http.Request, companyID string, body []byte) {
 	// Extrai o database_local_certificate_id (para salvar logs em caso de erro de certificado),
 	// e remove esse campo antes de enviar para a Focus.
-	var databaseLocalCertificateID string
+	var databaseLocalCertificateID, cnpj, cpf string
 	var focusBodyBytes = body
 	{
 		var m map[string]any
 		if err := json.Unmarshal(body, &m); err == nil {
+			cnpj, _ = m["cnpj"].(string)
+			cpf, _ = m["cpf"].(string)
 			if v, exists := m["database_local_certificate_id"]; exists {
 				if s, ok := v.(string); ok && s != "" {
 					databaseLocalCertificateID = s
@@ -72,7 +126,33 @@ func (h *EmpresasHandler) CreateEmpresa(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	resp, err := h.focus.CreateEmpresa(r.Context(), focusBodyBytes)
+	// Um retry do front (ex: após timeout) não pode criar outra empresa nem outra linha em focus_integration:
+	// se a empresa já existe na Focus, ela é atualizada com o payload recebido.
+	existingFocusID, alreadyIntegrated, err := h.existingEmpresa(r.Context(), companyID, cnpj, cpf)
+	if err != nil {
+		log.Printf("[focus] não foi possível verificar se a empresa já existe (company_id=%s): %v", companyID, err)
+		w.Header().Set("Retry-After", "5")
+		writeJSONError(w, http.StatusServiceUnavailable, "Não foi possível verificar se a empresa já está cadastrada na Focus. Tente novamente.")
+		return
+	}
+
+	var resp *http.Response
+	if existingFocusID != "" {
+		resp, err = h.focus.UpdateEmpresa(r.Context(), existingFocusID, focusBodyBytes)
+		if err == nil && resp.StatusCode == http.StatusNotFound {
+			// removida na Focus depois da integração: cadastra de novo
+			log.Printf("[focus] empresa %s de focus_integration não existe mais na Focus (company_id=%s); cadastrando novamente", existingFocusID, companyID)
+			resp.Body.Close()
+			existingFocusID, alreadyIntegrated = "", false
+		}
+	}
+	if existingFocusID == "" {
+		resp, err = h.focus.CreateEmpresa(r.Context(), focusBodyBytes)
+	}
+	if errors.Is(err, focus.ErrRateLimited) || errors.Is(err, focus.ErrCircuitOpen) {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
 	if err != nil {
 		writeJSONError(w, http.StatusBadGateway, "Empresa cadastrada, mas houve um problema ao integrar com a Focus. Tente novamente mais tarde.")
 		return
@@ -88,43 +168,23 @@ func (h *EmpresasHandler) CreateEmpresa(w http.ResponseWriter, r *http.Request)
 
 	// Se a Focus devolveu erro, salva no log e devolve mensagem amigável (empresa já foi criada no Supabase).
 	if resp.StatusCode >= 400 {
-		// Parseia o erro da Focus para salvar no banco
-		var focusError struct {
-			Codigo   string `json:"codigo"`
-			Mensagem string `json:"mensagem"`
-			Erros    []struct {
-				Campo string `json:"campo"`
-			} `json:"erros"`
-		}
-		
+		apiErr := focus.ParseAPIError(resp.StatusCode, respBytes)
+
 		// Verifica se é erro de certificado para incluir o company_certificates_access_id
 		var certificateAccessID string
-		if err := json.Unmarshal(respBytes, &focusError); err == nil {
-			for _, e := range focusError.Erros {
-				if e.Campo == "arquivo_certificado_base64" && databaseLocalCertificateID != "" {
-					certificateAccessID = databaseLocalCertificateID
-					break
-				}
-			}
+		if apiErr.HasFieldError("arquivo_certificado_base64") && databaseLocalCertificateID != "" {
+			certificateAccessID = databaseLocalCertificateID
 		}
 
-		// Re-parseia para pegar o array de erros como RawMessage
-		var focusErrorRaw struct {
-			Codigo   string          `json:"codigo"`
-			Mensagem string          `json:"mensagem"`
-			Erros    json.RawMessage `json:"erros"`
-		}
-		if err := json.Unmarshal(respBytes, &focusErrorRaw); err == nil {
-			// Salva o erro na tabela focus_integration_errors
-			if logErr := supabase.InsertFocusIntegrationError(
-				companyID,
-				focusErrorRaw.Codigo,
-				focusErrorRaw.Mensagem,
-				focusErrorRaw.Erros,
-				certificateAccessID,
-			); logErr != nil {
-				log.Printf("[supabase] erro ao salvar log de integração Focus: %v", logErr)
-			}
+		// Salva o erro na tabela focus_integration_errors
+		if logErr := supabase.InsertFocusIntegrationError(
+			companyID,
+			apiErr.Codigo,
+			apiErr.Mensagem,
+			apiErr.ErrosJSON(),
+			certificateAccessID,
+		); logErr != nil {
+			log.Printf("[supabase] erro ao salvar log de integração Focus: %v", logErr)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -140,12 +200,21 @@ func (h *EmpresasHandler) CreateEmpresa(w http.ResponseWriter, r *http.Request)
 	// Extrai dados para persistência no Supabase.
 	var focusResp struct {
 		ID                  any    `json:"id"`
+		CNPJ                string `json:"cnpj"`
 		TokenProducao       string `json:"token_producao"`
+		TokenHomologacao    string `json:"token_homologacao"`
 		CertificadoValidoAte string `json:"certificado_valido_ate"`
 		CertificadoValidoDe  string `json:"certificado_valido_de"`
 	}
 	_ = json.Unmarshal(respBytes, &focusResp)
 
+	// Empresas integradas em homologação usam o token de homologação nas chamadas seguintes.
+	env := focus.EnvironmentFromContext(r.Context())
+	companyToken := focusResp.TokenProducao
+	if env == focus.EnvHomologacao {
+		companyToken = focusResp.TokenHomologacao
+	}
+
 	focusCompanyID := ""
 	switch v := focusResp.ID.(type) {
 	case float64:
@@ -173,37 +242,45 @@ func (h *EmpresasHandler) CreateEmpresa(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	// Persiste integração no Supabase; se falhar, não quebra o retorno da Focus,
-	// mas sinaliza via header para o front tratar.
+	// Persiste integração no Supabase via outbox: passos que falham agora são refeitos pelo worker.
+	// Não quebra o retorno da Focus, mas sinaliza via header para o front tratar. Se nem o outbox puder
+	// ser gravado, responde 503: repetir o cadastro adota a empresa já criada na Focus.
 	var warn string
-	if focusCompanyID != "" && focusResp.TokenProducao != "" {
-		if err := supabase.InsertFocusIntegration(companyID, focusCompanyID, focusResp.TokenProducao); err != nil {
-			warn = "Cadastro realizado na Focus, mas não foi possível salvar os dados de integração no Supabase."
-			log.Printf("[supabase] insert focus_integration failed: %v", err)
+	if focusCompanyID != "" && companyToken != "" {
+		var jobs []outbox.Job
+		if !alreadyIntegrated {
+			jobs = append(jobs, outbox.InsertFocusIntegration(focusCompanyID, companyToken, string(env)))
 		}
-		if err := supabase.UpdateCompanyFocusIntegrated(companyID, true); err != nil {
-			if warn == "" {
-				warn = "Cadastro realizado na Focus, mas não foi possível atualizar o status de integração no Supabase."
-			}
-			log.Printf("[supabase] update companies.focus_integrated failed: %v", err)
+		jobs = append(jobs, outbox.MarkFocusIntegrated(), outbox.UpdateCertificateDates(effDate, expDate))
+		// Remove erros antigos do certificado (se houver) após sucesso
+		if databaseLocalCertificateID != "" {
+			jobs = append(jobs, outbox.DeleteCertificateErrors(databaseLocalCertificateID))
 		}
-		if err := supabase.UpdateCertificateDatesForCompany(companyID, effDate, expDate); err != nil {
-			if warn == "" {
-				warn = "Cadastro realizado na Focus, mas não foi possível atualizar as datas do certificado no Supabase."
-			}
-			log.Printf("[supabase] update certificates_access dates failed: %v", err)
-		} else {
+
+		failed, err := h.outbox.Dispatch(companyID, jobs...)
+		if err != nil {
+			log.Printf("[supabase] integração não gravada (company_id=%s, focus_id=%s): %v", companyID, focusCompanyID, err)
+			w.Header().Set("Retry-After", "5")
+			writeJSONError(w, http.StatusServiceUnavailable, "Cadastro realizado na Focus, mas não foi possível salvar a integração no Supabase. Tente novamente.")
+			return
+		}
+		switch {
+		case failed[outbox.StepInsertFocusIntegration] != nil:
+			warn = "Cadastro realizado na Focus; os dados de integração ainda não foram salvos no Supabase e serão gravados automaticamente."
+		case failed[outbox.StepMarkFocusIntegrated] != nil:
+			warn = "Cadastro realizado na Focus; o status de integração ainda não foi atualizado no Supabase e será atualizado automaticamente."
+		case failed[outbox.StepUpdateCertificateDates] != nil:
+			warn = "Cadastro realizado na Focus; as datas do certificado ainda não foram atualizadas no Supabase e serão atualizadas automaticamente."
+		}
+		if failed[outbox.StepUpdateCertificateDates] == nil {
 			log.Printf("[supabase] certificate dates updated (company_id=%s, effective_date=%v, expiration_date=%v)", companyID, effDate, expDate)
 		}
-		
-		// Remove erros antigos do certificado (se houver) após sucesso
-		if databaseLocalCertificateID != "" {
-			if err := supabase.DeleteFocusIntegrationErrorsByCertificate(companyID, databaseLocalCertificateID); err != nil {
-				log.Printf("[supabase] erro ao remover erros antigos do certificado: %v", err)
-				// Não adiciona warning pois não é crítico
-			} else {
-				log.Printf("[supabase] erros antigos do certificado removidos com sucesso")
+
+		if err := h.registerHooks(r.Context(), focusResp.CNPJ, companyID); err != nil {
+			if warn == "" {
+				warn = "Cadastro realizado na Focus, mas não foi possível cadastrar os gatilhos de webhook."
 			}
+			log.Printf("[focus] cadastro de gatilhos falhou (company_id=%s): %v", companyID, err)
 		}
 	} else {
 		warn = "Cadastro realizado na Focus, mas não foi possível identificar id/token/datas para persistir no Supabase."
@@ -217,10 +294,61 @@ func (h *EmpresasHandler) CreateEmpresa(w http.ResponseWriter, r *http.Request)
 	if warn != "" {
 		w.Header().Set("X-Integration-Warning", warn)
 	}
+	if existingFocusID != "" {
+		w.Header().Set("X-Integration-Status", "adopted")
+	} else {
+		w.Header().Set("X-Integration-Status", "created")
+	}
 	w.WriteHeader(resp.StatusCode)
 	_, _ = w.Write(respBytes)
 }
 
+// existingEmpresa procura a empresa já cadastrada na Focus: primeiro pela integração salva (focus_integration,
+// no mesmo ambiente), depois pelo CNPJ/CPF na Focus. alreadyIntegrated indica que focus_integration já tem a linha.
+// Se alguma das buscas falhar, devolve erro: cadastrar sem saber se a empresa existe criaria uma duplicata.
+func (h *EmpresasHandler) existingEmpresa(ctx context.Context, companyID, cnpj, cpf string) (focusID string, alreadyIntegrated bool, err error) {
+	fi, err := supabase.GetFocusIntegration(companyID)
+	if err != nil {
+		return "", false, fmt.Errorf("erro ao consultar focus_integration: %w", err)
+	}
+	if fi != nil && fi.FocusCompanyID != "" && fi.Environment == string(focus.EnvironmentFromContext(ctx)) {
+		return fi.FocusCompanyID, true, nil
+	}
+
+	q := url.Values{}
+	switch {
+	case normalizeCNPJ(cnpj) != "":
+		q.Set("cnpj", normalizeCNPJ(cnpj))
+	case normalizeCNPJ(cpf) != "":
+		q.Set("cpf", normalizeCNPJ(cpf))
+	default:
+		return "", false, nil
+	}
+	empresas, err := h.focus.ListEmpresasTyped(ctx, q.Encode())
+	if err != nil {
+		return "", false, fmt.Errorf("erro ao buscar a empresa na Focus: %w", err)
+	}
+	if len(empresas) > 0 {
+		return strconv.Itoa(empresas[0].ID), false, nil
+	}
+	return "", false, nil
+}
+
+// registerHooks cadastra na Focus os gatilhos de FOCUS_WEBHOOK_EVENTS da empresa recém-integrada.
+// Sem configuração de webhook (ou empresa sem CNPJ) não faz nada; continua nos eventos seguintes após uma falha.
+func (h *EmpresasHandler) registerHooks(ctx context.Context, cnpj, companyID string) error {
+	if !h.webhook.Enabled() || cnpj == "" {
+		return nil
+	}
+	var errs []error
+	for _, event := range h.webhook.Events {
+		if _, _, err := registerHook(ctx, h.focus, h.webhook, cnpj, companyID, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", event, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // ListEmpresas godoc
 // @Summary      Lista empresas na Focus
 // @Description  Proxy para Focus: GET /v2/empresas (suporta cnpj, cpf, offset)
@@ -229,15 +357,17 @@ func (h *EmpresasHandler) CreateEmpresa(w http.ResponseWriter, r *http.Request)
 // @Param        cnpj    query     string  false  "CNPJ (somente números)"
 // @Param        cpf     query     string  false  "CPF (somente números)"
 // @Param        offset  query     int     false  "Paginação (offset)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
 // @Success      200     {array}   model.FocusEmpresaResponse
 // @Failure      401     {object}  RawPayload
 // @Failure      429     {object}  RawPayload
 // @Failure      500     {object}  RawPayload
+// @Failure      503     {object}  RawPayload
 // @Router       /v2/empresas [get]
 func (h *EmpresasHandler) ListEmpresas(w http.ResponseWriter, r *http.Request) {
 	resp, err := h.focus.ListEmpresas(r.Context(), r.URL.RawQuery)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		writeFocusClientError(w, http.StatusInternalServerError, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -245,17 +375,115 @@ func (h *EmpresasHandler) ListEmpresas(w http.ResponseWriter, r *http.Request) {
 	proxyResponse(w, resp)
 }
 
+// ExportEmpresas godoc
+// @Summary      Exporta todas as empresas da conta na Focus
+// @Description  Percorre GET /v2/empresas (offset) até X-Total-Count e devolve a lista completa, em streaming.
+// @Description  format=json (padrão) devolve um array JSON; format=ndjson devolve uma empresa por linha.
+// @Tags         Empresas
+// @Produce      json
+// @Produce      application/x-ndjson
+// @Param        format  query     string  false  "json (padrão) ou ndjson"
+// @Param        cnpj    query     string  false  "CNPJ (somente números)"
+// @Param        cpf     query     string  false  "CPF (somente números)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
+// @Success      200     {array}   model.FocusEmpresaResponse
+// @Failure      400     {object}  RawPayload
+// @Failure      401     {object}  RawPayload
+// @Failure      429     {object}  RawPayload
+// @Failure      500     {object}  RawPayload
+// @Failure      503     {object}  RawPayload
+// @Router       /v2/empresas/export [get]
+func (h *EmpresasHandler) ExportEmpresas(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format := q.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "ndjson" {
+		writeJSONError(w, http.StatusBadRequest, "format inválido: use json ou ndjson")
+		return
+	}
+	q.Del("format")
+	q.Del("offset")
+
+	next, stop := iter.Pull2(h.focus.EmpresasPages(r.Context(), q.Encode()))
+	defer stop()
+
+	// A primeira página é buscada antes de escrever a resposta, para que erros da Focus
+	// (401, 429, indisponibilidade) ainda possam ser devolvidos com o status correto.
+	page, err, ok := next()
+	if ok && err != nil {
+		if apiErr, isAPI := focus.AsAPIError(err); isAPI {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(apiErr.StatusCode)
+			_, _ = w.Write(apiErr.Body)
+			return
+		}
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	if format == "ndjson" {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	if ok && page.Total >= 0 {
+		w.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
+	}
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	count := 0
+
+	if format == "json" {
+		_, _ = io.WriteString(w, "[")
+	}
+	for ok {
+		if err != nil {
+			// Cabeçalhos já enviados: registra e encerra. Em JSON o array fica sem "]",
+			// de propósito, para o consumidor perceber a lista incompleta.
+			log.Printf("[focus] export de empresas interrompido após %d registros: %v", count, err)
+			if format == "ndjson" {
+				_ = enc.Encode(map[string]any{"error": err.Error()})
+			}
+			return
+		}
+
+		for _, e := range page.Empresas {
+			if format == "json" && count > 0 {
+				_, _ = io.WriteString(w, ",")
+			}
+			if encErr := enc.Encode(e); encErr != nil {
+				return
+			}
+			count++
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		page, err, ok = next()
+	}
+	if format == "json" {
+		_, _ = io.WriteString(w, "]")
+	}
+}
+
 // GetEmpresa godoc
 // @Summary      Consulta uma empresa por ID na Focus
 // @Description  Proxy para Focus: GET /v2/empresas/{id}
 // @Tags         Empresas
 // @Produce      json
 // @Param        id   path      string  true  "ID da empresa na Focus"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
 // @Success      200  {object}  model.FocusEmpresaResponse
 // @Failure      401  {object}  RawPayload
 // @Failure      404  {object}  RawPayload
 // @Failure      429  {object}  RawPayload
 // @Failure      500  {object}  RawPayload
+// @Failure      503  {object}  RawPayload
 // @Router       /v2/empresas/{id} [get]
 func (h *EmpresasHandler) GetEmpresa(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -266,7 +494,7 @@ func (h *EmpresasHandler) GetEmpresa(w http.ResponseWriter, r *http.Request) {
 
 	resp, err := h.focus.GetEmpresa(r.Context(), id)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		writeFocusClientError(w, http.StatusInternalServerError, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -277,6 +505,9 @@ func (h *EmpresasHandler) GetEmpresa(w http.ResponseWriter, r *http.Request) {
 // UpdateEmpresa godoc
 // @Summary      Altera uma empresa específica na Focus
 // @Description  Proxy para Focus: PUT /v2/empresas/{id}. Apenas os campos enviados serão atualizados. Se atualizar certificado com sucesso, erros antigos serão removidos.
+// @Description  Um novo certificado é validado localmente (senha, validade, ICP-Brasil, CNPJ) antes do envio; problemas respondem 422 com erros por campo
+// @Description  e, com company_id, são registrados em focus_integration_errors.
+// @Description  Se as datas do novo certificado não puderem ser gravadas no Supabase (nem no outbox), responde 503.
 // @Tags         Empresas
 // @Accept       json
 // @Produce      json
@@ -284,12 +515,15 @@ func (h *EmpresasHandler) GetEmpresa(w http.ResponseWriter, r *http.Request) {
 // @Param        company_id    query     string      false  "ID da empresa no Supabase (para limpeza de erros)"
 // @Param        certificate_id query    string      false  "ID do certificado no Supabase (para limpeza de erros)"
 // @Param        payload       body      model.FocusEmpresaUpdateRequest  true  "Dados para atualização (campos opcionais)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
 // @Success      200           {object}  model.FocusEmpresaResponse
 // @Failure      400           {object}  RawPayload
 // @Failure      401           {object}  RawPayload
 // @Failure      404           {object}  RawPayload
+// @Failure      422           {object}  RawPayload
 // @Failure      429           {object}  RawPayload
 // @Failure      500           {object}  RawPayload
+// @Failure      503           {object}  RawPayload
 // @Router       /v2/empresas/{id} [put]
 func (h *EmpresasHandler) UpdateEmpresa(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -334,9 +568,44 @@ func (h *EmpresasHandler) UpdateEmpresa(w http.ResponseWriter, r *http.Request)
 
 	log.Printf("[focus] PUT /v2/empresas/%s -> campos a atualizar: %d (certificado: %v)", id, len(checkEmpty), hasCertificateUpdate)
 
+	// Novo certificado: confere senha, validade e titular antes de chamar a Focus. Sem cnpj no payload,
+	// o titular é comparado com o CNPJ da empresa no Supabase (quando company_id é informado).
+	if updatePayload.ArquivoCertBase64 != nil && *updatePayload.ArquivoCertBase64 != "" {
+		var senha, cnpj string
+		if updatePayload.SenhaCertificado != nil {
+			senha = *updatePayload.SenhaCertificado
+		}
+		if updatePayload.CNPJ != nil {
+			cnpj = *updatePayload.CNPJ
+		} else if companyID != "" {
+			if companyCNPJ, _, err := supabase.GetCompanyCNPJ(companyID); err != nil {
+				log.Printf("[supabase] erro ao ler CNPJ da empresa para validar certificado (company_id=%s): %v", companyID, err)
+			} else {
+				cnpj = companyCNPJ
+			}
+		}
+		if erros := validateCertificate(*updatePayload.ArquivoCertBase64, senha, cnpj, time.Now()); len(erros) > 0 {
+			// mesmo registro do cadastro: a recusa aparece em focus_integration_errors para a empresa
+			if companyID != "" {
+				apiErr := &focus.APIError{Erros: erros}
+				if logErr := supabase.InsertFocusIntegrationError(
+					companyID,
+					"certificado_invalido",
+					"Certificado digital recusado na validação local",
+					apiErr.ErrosJSON(),
+					certificateID,
+				); logErr != nil {
+					log.Printf("[supabase] erro ao salvar log de integração Focus: %v", logErr)
+				}
+			}
+			writeValidationErrors(w, "Certificado digital inválido", erros)
+			return
+		}
+	}
+
 	resp, err := h.focus.UpdateEmpresa(r.Context(), id, cleanBody)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		writeFocusClientError(w, http.StatusInternalServerError, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -377,19 +646,22 @@ func (h *EmpresasHandler) UpdateEmpresa(w http.ResponseWriter, r *http.Request)
 			}
 		}
 
-		if err := supabase.UpdateCertificateDatesForCompany(companyID, effDate, expDate); err != nil {
-			log.Printf("[supabase] update certificates_access dates failed (update flow): %v", err)
-		} else {
-			log.Printf("[supabase] certificate dates updated (update flow) (company_id=%s, effective_date=%v, expiration_date=%v)", companyID, effDate, expDate)
+		jobs := []outbox.Job{outbox.UpdateCertificateDates(effDate, expDate)}
+		// If we have the certificate ID, clean old certificate errors
+		if certificateID != "" {
+			jobs = append(jobs, outbox.DeleteCertificateErrors(certificateID))
 		}
-	}
-
-	// If success and we have IDs, clean old certificate errors
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 && hasCertificateUpdate && companyID != "" && certificateID != "" {
-		if err := supabase.DeleteFocusIntegrationErrorsByCertificate(companyID, certificateID); err != nil {
-			log.Printf("[supabase] erro ao remover erros antigos do certificado no update: %v", err)
+		failed, err := h.outbox.Dispatch(companyID, jobs...)
+		if err != nil {
+			log.Printf("[supabase] update flow: datas do certificado não gravadas (company_id=%s): %v", companyID, err)
+			w.Header().Set("Retry-After", "5")
+			writeJSONError(w, http.StatusServiceUnavailable, "Empresa atualizada na Focus, mas não foi possível salvar as datas do certificado no Supabase. Tente novamente.")
+			return
+		}
+		if len(failed) > 0 {
+			log.Printf("[supabase] update flow: %d passo(s) pendente(s) no outbox (company_id=%s)", len(failed), companyID)
 		} else {
-			log.Printf("[supabase] erros antigos do certificado removidos com sucesso no update")
+			log.Printf("[supabase] certificate dates updated (update flow) (company_id=%s, effective_date=%v, expiration_date=%v)", companyID, effDate, expDate)
 		}
 	}
 
@@ -402,11 +674,13 @@ func (h *EmpresasHandler) UpdateEmpresa(w http.ResponseWriter, r *http.Request)
 // @Tags         Empresas
 // @Produce      json
 // @Param        id   path      string  true  "ID da empresa na Focus"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
 // @Success      200  {object}  model.FocusEmpresaResponse
 // @Failure      401  {object}  RawPayload
 // @Failure      404  {object}  RawPayload
 // @Failure      429  {object}  RawPayload
 // @Failure      500  {object}  RawPayload
+// @Failure      503  {object}  RawPayload
 // @Router       /v2/empresas/{id} [delete]
 func (h *EmpresasHandler) DeleteEmpresa(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -417,7 +691,7 @@ func (h *EmpresasHandler) DeleteEmpresa(w http.ResponseWriter, r *http.Request)
 
 	resp, err := h.focus.DeleteEmpresa(r.Context(), id)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		writeFocusClientError(w, http.StatusInternalServerError, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -462,6 +736,25 @@ func copyHeaderIfPresent(w http.ResponseWriter, resp *http.Response, key string)
 	}
 }
 
+// writeFocusClientError responde erros do focus.Client (antes de haver resposta HTTP da Focus).
+// Recusas do limitador local e do circuit breaker viram 503 + Retry-After; demais erros usam o status informado.
+func writeFocusClientError(w http.ResponseWriter, status int, err error) {
+	var rlErr *focus.RateLimitError
+	if errors.As(err, &rlErr) {
+		w.Header().Set("Retry-After", strconv.Itoa(rlErr.RetryAfterSeconds()))
+		writeJSONError(w, http.StatusServiceUnavailable, "Limite de requisições à Focus atingido. Tente novamente em instantes.")
+		return
+	}
+	var cbErr *focus.CircuitOpenError
+	if errors.As(err, &cbErr) {
+		w.Header().Set("Retry-After", strconv.Itoa(cbErr.RetryAfterSeconds()))
+		writeJSONError(w, http.StatusServiceUnavailable, "A Focus está indisponível no momento. Tente novamente em instantes.")
+		return
+	}
+
+	writeJSONError(w, status, err.Error())
+}
+
 func writeJSONError(w http.ResponseWriter, status int, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -4,23 +4,43 @@ import (
 	"encoding/json"
 	"net/http"
 	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
 )
 
 type HealthResponse struct {
 	Status string    `json:"status"`
 	Time   time.Time `json:"time"`
+	// Estado dos circuit breakers da Focus por família de endpoints (closed, open, half_open).
+	Focus map[string]focus.BreakerState `json:"focus,omitempty"`
+}
+
+type HealthHandler struct {
+	focus *focus.Client
+}
+
+func NewHealthHandler(focusClient *focus.Client) *HealthHandler {
+	return &HealthHandler{focus: focusClient}
 }
 
 // Health godoc
 // @Summary      Health check
-// @Description  Check the health of the service
+// @Description  Check the health of the service. Status "degraded" indica circuit breaker da Focus aberto.
 // @Tags         status
 // @Success      200  {object}  HealthResponse
 // @Router       /health [get]
-func Health(w http.ResponseWriter, r *http.Request) {
+func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
 	resp := HealthResponse{Status: "ok", Time: time.Now()}
+
+	if h.focus != nil {
+		resp.Focus = h.focus.BreakerStates()
+		for _, state := range resp.Focus {
+			if state != focus.BreakerClosed {
+				resp.Status = "degraded"
+			}
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(resp)
 }
-
-
@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/model"
+)
+
+// WebhookConfig descreve como a Focus deve chamar o nosso POST /webhooks/focus.
+type WebhookConfig struct {
+	URL    string // URL pública do /webhooks/focus
+	Secret string // enviado pela Focus no header Header (authorization / authorization_header do gatilho)
+	Header string
+	Events []string // eventos cadastrados automaticamente no CreateEmpresa
+}
+
+// Enabled indica se há URL e segredo para cadastrar gatilhos (sem segredo o /webhooks/focus responde 503).
+func (c WebhookConfig) Enabled() bool {
+	return c.URL != "" && c.Secret != ""
+}
+
+// callbackURL monta a URL do gatilho com ?evento= e ?company_id=, usados pelo /webhooks/focus.
+func (c WebhookConfig) callbackURL(event, companyID string) string {
+	q := url.Values{}
+	q.Set("evento", event)
+	q.Set("company_id", companyID)
+
+	sep := "?"
+	if strings.Contains(c.URL, "?") {
+		sep = "&"
+	}
+	return c.URL + sep + q.Encode()
+}
+
+// registerHook cadastra na Focus o gatilho do evento para o CNPJ da empresa. Se já existe um gatilho
+// com o mesmo CNPJ, evento e URL, ele é devolvido sem novo cadastro (created=false).
+// Gatilhos são gerenciados com o token principal, no ambiente do context.
+func registerHook(ctx context.Context, client *focus.Client, cfg WebhookConfig, cnpj, companyID, event string) (hook *model.FocusHookResponse, created bool, err error) {
+	callback := cfg.callbackURL(event, companyID)
+
+	hooks, err := client.ListHooksTyped(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range hooks {
+		if hooks[i].CNPJ == cnpj && hooks[i].Event == event && hooks[i].URL == callback {
+			return &hooks[i], false, nil
+		}
+	}
+
+	body, err := json.Marshal(model.FocusHookRequest{
+		CNPJ:                cnpj,
+		Event:               event,
+		URL:                 callback,
+		Authorization:       cfg.Secret,
+		AuthorizationHeader: cfg.Header,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	hook, err = client.CreateHookTyped(ctx, body)
+	return hook, err == nil, err
+}
+
+// Eventos aceitos no cadastro de gatilhos (os mesmos tipos aceitos pelo /webhooks/focus).
+func validHookEvent(event string) bool {
+	return webhookDocumentTypes[event]
+}
+
+type HooksHandler struct {
+	focus   *focus.Client
+	webhook WebhookConfig
+}
+
+func NewHooksHandler(focusClient *focus.Client, webhook WebhookConfig) *HooksHandler {
+	return &HooksHandler{focus: focusClient, webhook: webhook}
+}
+
+// CreateHook godoc
+// @Summary      Cadastra gatilho (webhook) da Focus para a empresa
+// @Description  Proxy para Focus: POST /v2/hooks com o CNPJ da empresa, apontando para o nosso /webhooks/focus (FOCUS_WEBHOOK_URL).
+// @Description  Idempotente: se o gatilho do evento já existe, ele é devolvido com 200.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        company_id  path      string                    true  "ID da empresa (companies.id)"
+// @Param        payload     body      model.CompanyHookRequest  true  "Evento (tipo de documento)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Success      201         {object}  model.FocusHookResponse
+// @Success      200         {object}  model.FocusHookResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/companies/{company_id}/hooks [post]
+func (h *HooksHandler) CreateHook(w http.ResponseWriter, r *http.Request) {
+	if !h.webhook.Enabled() {
+		writeJSONError(w, http.StatusServiceUnavailable, "cadastro de gatilhos não configurado (FOCUS_WEBHOOK_URL / FOCUS_WEBHOOK_SECRET)")
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+	var req model.CompanyHookRequest
+	if err := json.Unmarshal(body, &req); err != nil || !validHookEvent(req.Event) {
+		writeJSONError(w, http.StatusBadRequest, "event inválido: use nfse, nfsen, nfe, nfce, cte ou mdfe")
+		return
+	}
+
+	ctx, creds, cnpj, ok := h.companyCNPJ(w, r)
+	if !ok {
+		return
+	}
+
+	hook, created, err := registerHook(ctx, h.focus, h.webhook, cnpj, creds.CompanyID, req.Event)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, hook)
+}
+
+// ListHooks godoc
+// @Summary      Lista gatilhos (webhooks) da Focus da empresa
+// @Description  Proxy para Focus: GET /v2/hooks, filtrado pelo CNPJ da empresa.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        company_id  path      string  true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Success      200         {array}   model.FocusHookResponse
+// @Failure      401         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/companies/{company_id}/hooks [get]
+func (h *HooksHandler) ListHooks(w http.ResponseWriter, r *http.Request) {
+	ctx, _, cnpj, ok := h.companyCNPJ(w, r)
+	if !ok {
+		return
+	}
+
+	hooks, err := h.focus.ListHooksTyped(ctx)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	out := make([]model.FocusHookResponse, 0, len(hooks))
+	for _, hook := range hooks {
+		if hook.CNPJ == cnpj {
+			out = append(out, hook)
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// DeleteHook godoc
+// @Summary      Remove gatilho (webhook) da Focus da empresa
+// @Description  Proxy para Focus: DELETE /v2/hooks/{hook_id}. O gatilho precisa pertencer ao CNPJ da empresa.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        company_id  path      string  true  "ID da empresa (companies.id)"
+// @Param        hook_id     path      string  true  "ID do gatilho na Focus"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Success      200         {object}  model.FocusHookResponse
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/companies/{company_id}/hooks/{hook_id} [delete]
+func (h *HooksHandler) DeleteHook(w http.ResponseWriter, r *http.Request) {
+	ctx, _, cnpj, ok := h.companyCNPJ(w, r)
+	if !ok {
+		return
+	}
+	hookID := chi.URLParam(r, "hook_id")
+
+	hook, err := h.focus.GetHookTyped(ctx, hookID)
+	if apiErr, isAPI := focus.AsAPIError(err); isAPI && apiErr.StatusCode == http.StatusNotFound {
+		writeJSONError(w, http.StatusNotFound, "gatilho não encontrado")
+		return
+	}
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	if hook.CNPJ != cnpj {
+		writeJSONError(w, http.StatusNotFound, "gatilho não encontrado")
+		return
+	}
+
+	resp, err := h.focus.DeleteHook(ctx, hookID)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		proxyResponse(w, resp)
+		return
+	}
+
+	// A Focus devolve o gatilho com authorization (o segredo do /webhooks/focus): responde só os campos públicos.
+	var deleted struct {
+		model.FocusHookResponse
+		Deleted bool `json:"deleted"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&deleted); err != nil {
+		writeJSONError(w, http.StatusBadGateway, "resposta inválida da Focus ao remover o gatilho")
+		return
+	}
+	writeJSON(w, resp.StatusCode, deleted)
+}
+
+// companyCNPJ resolve a empresa do path {company_id} e lê o CNPJ dela na Focus.
+// O context devolvido está no ambiente em que a empresa foi integrada.
+func (h *HooksHandler) companyCNPJ(w http.ResponseWriter, r *http.Request) (context.Context, focus.CompanyCredentials, string, bool) {
+	companyID := chi.URLParam(r, "company_id")
+
+	_, creds, ok := companyFocusClientFor(w, r, h.focus, companyID)
+	if !ok {
+		return nil, focus.CompanyCredentials{}, "", false
+	}
+
+	ctx := focus.WithEnvironment(r.Context(), creds.Environment)
+	empresa, err := h.focus.GetEmpresaTyped(ctx, creds.FocusCompanyID)
+	if apiErr, isAPI := focus.AsAPIError(err); isAPI && apiErr.StatusCode == http.StatusNotFound {
+		writeJSONError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Empresa integrada não encontrada na Focus (focus_company_id=%s).", creds.FocusCompanyID))
+		return nil, focus.CompanyCredentials{}, "", false
+	}
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return nil, focus.CompanyCredentials{}, "", false
+	}
+	if empresa.CNPJ == "" {
+		writeJSONError(w, http.StatusUnprocessableEntity, "Empresa sem CNPJ na Focus: gatilhos são cadastrados por CNPJ.")
+		return nil, focus.CompanyCredentials{}, "", false
+	}
+	return ctx, creds, empresa.CNPJ, true
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/supabase"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	// Depois desse prazo a chave pode ser reutilizada.
+	idempotencyTTL = 24 * time.Hour
+	// Chave "pending" mais antiga que isso foi abandonada (processo reiniciado no meio da requisição).
+	idempotencyPendingTimeout = 5 * time.Minute
+)
+
+// Headers da resposta original que são repetidos no replay.
+var idempotencyReplayHeaders = []string{"Content-Type", "X-Integration-Warning", "X-Integration-Status"}
+
+// Campos da resposta que não são gravados em focus_idempotency_keys: os tokens da empresa ficam só em
+// focus_integration e são devolvidos de lá no replay (ver restoreIdempotentTokens).
+var idempotencyRedactedFields = []string{"token_producao", "token_homologacao"}
+
+// withIdempotency executa handle uma única vez por (company_id, Idempotency-Key). Repetições com o mesmo
+// payload e ambiente Focus recebem a resposta gravada (header Idempotent-Replayed: true); o mesmo key com outro
+// payload ou ambiente é recusado (422) e, enquanto a requisição original não termina, a resposta é 409.
+// Respostas 5xx liberam a chave para que o cliente possa tentar de novo. Sem o header, só chama handle.
+func withIdempotency(w http.ResponseWriter, r *http.Request, companyID, operation string, body []byte, handle func(http.ResponseWriter)) {
+	key := strings.TrimSpace(r.Header.Get(idempotencyKeyHeader))
+	if key == "" {
+		handle(w)
+		return
+	}
+	if len(key) > 255 {
+		writeJSONError(w, http.StatusBadRequest, "Idempotency-Key inválida: use até 255 caracteres")
+		return
+	}
+
+	// o ambiente faz parte da requisição: a mesma chave em homologação não pode repetir a resposta de produção
+	env := focus.EnvironmentFromContext(r.Context())
+	sum := sha256.Sum256(append([]byte(operation+"\n"+string(env)+"\n"), body...))
+	claim := supabase.IdempotencyKey{
+		CompanyID:   companyID,
+		Key:         key,
+		Operation:   operation,
+		RequestHash: hex.EncodeToString(sum[:]),
+	}
+
+	existing, err := supabase.ClaimIdempotencyKey(claim)
+	if err == nil && existing != nil && idempotencyExpired(existing) {
+		if err = supabase.ReleaseIdempotencyKey(companyID, key); err == nil {
+			existing, err = supabase.ClaimIdempotencyKey(claim)
+		}
+	}
+	if err != nil {
+		log.Printf("[supabase] erro ao registrar Idempotency-Key (company_id=%s): %v", companyID, err)
+		writeJSONError(w, http.StatusInternalServerError, "erro ao registrar Idempotency-Key")
+		return
+	}
+
+	if existing != nil {
+		switch {
+		case existing.Operation != operation || existing.RequestHash != claim.RequestHash:
+			writeJSONError(w, http.StatusUnprocessableEntity, "Idempotency-Key já utilizada com outro payload")
+		case existing.Status != supabase.IdempotencyCompleted:
+			w.Header().Set("Retry-After", "5")
+			writeJSONError(w, http.StatusConflict, "Requisição com esta Idempotency-Key ainda em processamento")
+		default:
+			for k, v := range existing.ResponseHeaders {
+				w.Header().Set(k, v)
+			}
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(existing.ResponseStatus)
+			_, _ = w.Write(restoreIdempotentTokens(companyID, existing.ResponseBody))
+		}
+		return
+	}
+
+	rec := &capturingWriter{ResponseWriter: w}
+	handle(rec)
+
+	if rec.status >= 500 {
+		if err := supabase.ReleaseIdempotencyKey(companyID, key); err != nil {
+			log.Printf("[supabase] erro ao liberar Idempotency-Key (company_id=%s): %v", companyID, err)
+		}
+		return
+	}
+
+	headers := map[string]string{}
+	for _, k := range idempotencyReplayHeaders {
+		if v := w.Header().Get(k); v != "" {
+			headers[k] = v
+		}
+	}
+	respBody := redactIdempotentBody(rec.body.Bytes())
+	if err := supabase.CompleteIdempotencyKey(companyID, key, rec.status, respBody, headers); err != nil {
+		log.Printf("[supabase] erro ao gravar resultado da Idempotency-Key (company_id=%s): %v", companyID, err)
+	}
+}
+
+// redactIdempotentBody prepara a resposta para gravação: JSON válido, sem os campos de idempotencyRedactedFields.
+func redactIdempotentBody(b []byte) json.RawMessage {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(b, &obj); err != nil {
+		if json.Valid(b) {
+			return b
+		}
+		out, _ := json.Marshal(string(b))
+		return out
+	}
+
+	redacted := false
+	for _, k := range idempotencyRedactedFields {
+		if _, ok := obj[k]; ok {
+			delete(obj, k)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return b
+	}
+	out, err := json.Marshal(obj)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// restoreIdempotentTokens devolve ao replay o token da empresa, lido de focus_integration, quando a resposta
+// gravada é da mesma empresa da Focus (id). Sem integração, o replay sai sem os tokens.
+func restoreIdempotentTokens(companyID string, b []byte) []byte {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(b, &obj); err != nil || obj["id"] == nil {
+		return b
+	}
+	fi, err := supabase.GetFocusIntegration(companyID)
+	if err != nil {
+		log.Printf("[supabase] erro ao ler focus_integration para o replay (company_id=%s): %v", companyID, err)
+		return b
+	}
+	if fi == nil || fi.TokenFocusCompany == "" || strings.Trim(string(obj["id"]), `"`) != fi.FocusCompanyID {
+		return b
+	}
+
+	field := "token_producao"
+	if fi.Environment == string(focus.EnvHomologacao) {
+		field = "token_homologacao"
+	}
+	obj[field], _ = json.Marshal(fi.TokenFocusCompany)
+	out, err := json.Marshal(obj)
+	if err != nil {
+		return b
+	}
+	return out
+}
+
+func idempotencyExpired(k *supabase.IdempotencyKey) bool {
+	if k.CreatedAt == nil {
+		return false
+	}
+	age := time.Since(*k.CreatedAt)
+	return age > idempotencyTTL || (k.Status != supabase.IdempotencyCompleted && age > idempotencyPendingTimeout)
+}
+
+// capturingWriter repassa a resposta e guarda uma cópia (status e corpo).
+type capturingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (c *capturingWriter) WriteHeader(status int) {
+	if c.status == 0 {
+		c.status = status
+	}
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *capturingWriter) Write(b []byte) (int, error) {
+	if c.status == 0 {
+		c.status = http.StatusOK
+	}
+	c.body.Write(b)
+	return c.ResponseWriter.Write(b)
+}
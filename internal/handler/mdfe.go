@@ -0,0 +1,343 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/model"
+)
+
+var cpfDigits11 = regexp.MustCompile(`^\d{11}$`)
+
+type MdfeHandler struct {
+	focus *focus.Client
+}
+
+func NewMdfeHandler(focusClient *focus.Client) *MdfeHandler {
+	return &MdfeHandler{focus: focusClient}
+}
+
+// EmitMDFe godoc
+// @Summary      Emite MDF-e
+// @Description  Proxy para Focus: POST /v2/mdfe?ref=REF usando o token da empresa (focus_integration).
+// @Description  A empresa precisa estar com habilita_mdfe na Focus. A emissão e o status são registrados em focus_documents.
+// @Tags         MDF-e
+// @Accept       json
+// @Produce      json
+// @Param        company_id  query     string             true  "ID da empresa (companies.id)"
+// @Param        ref         query     string             true  "Referência única do documento (letras, números, '-', '_' e '.')"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.MDFeRequest  true  "Payload do MDF-e (campos adicionais da Focus são repassados)"
+// @Success      202         {object}  model.MDFeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/mdfe [post]
+func (h *MdfeHandler) EmitMDFe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.MDFeRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "payload inválido para MDF-e")
+		return
+	}
+	if erros := validateMDFe(req); len(erros) > 0 {
+		writeValidationErrors(w, "Payload de MDF-e incompleto", erros)
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.EmitMDFe(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docMDFe, ref, eventEmissao, body)
+}
+
+// GetMDFe godoc
+// @Summary      Consulta MDF-e
+// @Description  Proxy para Focus: GET /v2/mdfe/{ref} usando o token da empresa. Atualiza o status em focus_documents.
+// @Tags         MDF-e
+// @Produce      json
+// @Param        ref         path      string  true  "Referência do documento"
+// @Param        company_id  query     string  true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Success      200         {object}  model.MDFeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/mdfe/{ref} [get]
+func (h *MdfeHandler) GetMDFe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.GetMDFe(r.Context(), ref)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docMDFe, ref, eventConsulta, nil)
+}
+
+// CancelMDFe godoc
+// @Summary      Cancela MDF-e
+// @Description  Proxy para Focus: DELETE /v2/mdfe/{ref} usando o token da empresa. A justificativa deve ter de 15 a 255 caracteres.
+// @Tags         MDF-e
+// @Accept       json
+// @Produce      json
+// @Param        ref         path      string                  true  "Referência do documento"
+// @Param        company_id  query     string                  true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.NFeCancelRequest  true  "Justificativa do cancelamento"
+// @Success      200         {object}  model.MDFeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/mdfe/{ref} [delete]
+func (h *MdfeHandler) CancelMDFe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.NFeCancelRequest
+	if err := json.Unmarshal(body, &req); err != nil || !textLenBetween(req.Justificativa, 15, 255) {
+		writeJSONError(w, http.StatusBadRequest, "justificativa é obrigatória e deve ter entre 15 e 255 caracteres")
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.CancelMDFe(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docMDFe, ref, eventCancelamento, body)
+}
+
+// EncerrarMDFe godoc
+// @Summary      Encerra MDF-e
+// @Description  Proxy para Focus: POST /v2/mdfe/{ref}/encerrar usando o token da empresa. Informe a data e o município de encerramento.
+// @Tags         MDF-e
+// @Accept       json
+// @Produce      json
+// @Param        ref         path      string                         true  "Referência do documento"
+// @Param        company_id  query     string                         true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.MDFeEncerramentoRequest  true  "Dados do encerramento"
+// @Success      200         {object}  model.MDFeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/mdfe/{ref}/encerrar [post]
+func (h *MdfeHandler) EncerrarMDFe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.MDFeEncerramentoRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "payload inválido para encerramento")
+		return
+	}
+	var erros []focus.FieldError
+	if req.Data == "" {
+		erros = append(erros, focus.FieldError{Campo: "data", Mensagem: "obrigatório"})
+	}
+	if len(req.SiglaUF) != 2 {
+		erros = append(erros, focus.FieldError{Campo: "sigla_uf", Mensagem: "informe a sigla da UF (2 letras)"})
+	}
+	if strings.TrimSpace(req.NomeMunicipio) == "" {
+		erros = append(erros, focus.FieldError{Campo: "nome_municipio", Mensagem: "obrigatório"})
+	}
+	if len(erros) > 0 {
+		writeValidationErrors(w, "Pedido de encerramento inválido", erros)
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.EncerrarMDFe(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docMDFe, ref, eventEncerramento, body)
+}
+
+// IncluirCondutorMDFe godoc
+// @Summary      Inclui condutor no MDF-e
+// @Description  Proxy para Focus: POST /v2/mdfe/{ref}/inclusao_condutor usando o token da empresa (MDF-e autorizado e não encerrado).
+// @Tags         MDF-e
+// @Accept       json
+// @Produce      json
+// @Param        ref         path      string              true  "Referência do documento"
+// @Param        company_id  query     string              true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.MDFeCondutor  true  "Condutor"
+// @Success      200         {object}  model.MDFeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/mdfe/{ref}/inclusao_condutor [post]
+func (h *MdfeHandler) IncluirCondutorMDFe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.MDFeCondutor
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "payload inválido para inclusão de condutor")
+		return
+	}
+	if erros := validateCondutor("", req); len(erros) > 0 {
+		writeValidationErrors(w, "Condutor inválido", erros)
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.IncluirCondutorMDFe(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docMDFe, ref, eventInclusaoCondutor, body)
+}
+
+func validateMDFe(req model.MDFeRequest) []focus.FieldError {
+	var erros []focus.FieldError
+	required := func(campo, valor string) {
+		if strings.TrimSpace(valor) == "" {
+			erros = append(erros, focus.FieldError{Campo: campo, Mensagem: "obrigatório"})
+		}
+	}
+
+	required("cnpj_emitente", req.CnpjEmitente)
+	required("data_emissao", req.DataEmissao)
+	required("uf_inicio", req.UFInicio)
+	required("uf_fim", req.UFFim)
+	if len(req.MunicipiosCarregamento) == 0 {
+		erros = append(erros, focus.FieldError{Campo: "municipios_carregamento", Mensagem: "informe ao menos um município"})
+	}
+	if len(req.MunicipiosDescarregamento) == 0 {
+		erros = append(erros, focus.FieldError{Campo: "municipios_descarregamento", Mensagem: "informe ao menos um município"})
+	}
+	for i, m := range req.MunicipiosDescarregamento {
+		if len(m.Nfes) == 0 && len(m.Ctes) == 0 {
+			erros = append(erros, focus.FieldError{
+				Campo:    "municipios_descarregamento[" + strconv.Itoa(i) + "]",
+				Mensagem: "informe ao menos um documento (nfes ou ctes)",
+			})
+		}
+	}
+	if v, ok := req.ValorTotalCarga.Float(); !ok || v <= 0 {
+		erros = append(erros, focus.FieldError{Campo: "valor_total_carga", Mensagem: "deve ser um número maior que zero"})
+	}
+	erros = append(erros, invalidNumbers(
+		numberField{"tipo_emitente", req.TipoEmitente, true},
+		numberField{"peso_bruto", req.PesoBruto, false},
+	)...)
+
+	if rod := req.ModalRodoviario; rod != nil {
+		erros = append(erros, invalidNumber("modal_rodoviario.veiculo_tracao.tara", rod.VeiculoTracao.Tara, true)...)
+		if len(rod.VeiculoTracao.Condutores) == 0 {
+			erros = append(erros, focus.FieldError{Campo: "modal_rodoviario.veiculo_tracao.condutores", Mensagem: "informe ao menos um condutor"})
+		}
+		for i, c := range rod.VeiculoTracao.Condutores {
+			erros = append(erros, validateCondutor("modal_rodoviario.veiculo_tracao.condutores["+strconv.Itoa(i)+"].", c)...)
+		}
+	}
+	return erros
+}
+
+func validateCondutor(prefix string, c model.MDFeCondutor) []focus.FieldError {
+	var erros []focus.FieldError
+	if strings.TrimSpace(c.Nome) == "" {
+		erros = append(erros, focus.FieldError{Campo: prefix + "nome", Mensagem: "obrigatório"})
+	}
+	if !cpfDigits11.MatchString(c.CPF) {
+		erros = append(erros, focus.FieldError{Campo: prefix + "cpf", Mensagem: "informe 11 dígitos, somente números"})
+	}
+	return erros
+}
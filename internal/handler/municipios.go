@@ -29,15 +29,17 @@ func NewMunicipiosHandler(focusClient *focus.Client) *MunicipiosHandler {
 // @Param        nome            query     string  false  "Trecho do nome do município"
 // @Param        status_nfse     query     string  false  "Status NFSe (ativo, fora_do_ar, pausado, em_implementacao, em_reimplementacao, inativo, nao_implementado)"
 // @Param        offset          query     int     false  "Paginação (offset)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
 // @Success      200             {array}   model.FocusMunicipioResponse
 // @Failure      401             {object}  RawPayload
 // @Failure      429             {object}  RawPayload
 // @Failure      500             {object}  RawPayload
+// @Failure      503             {object}  RawPayload
 // @Router       /v2/municipios [get]
 func (h *MunicipiosHandler) ListMunicipios(w http.ResponseWriter, r *http.Request) {
 	resp, err := h.focus.ListMunicipios(r.Context(), r.URL.RawQuery)
 	if err != nil {
-		writeJSONError(w, http.StatusBadGateway, err.Error())
+		writeFocusClientError(w, http.StatusBadGateway, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -51,12 +53,14 @@ func (h *MunicipiosHandler) ListMunicipios(w http.ResponseWriter, r *http.Reques
 // @Tags         Municípios
 // @Produce      json
 // @Param        codigo_municipio  path      string  true  "Código do município (IBGE)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
 // @Success      200               {object}  model.FocusMunicipioResponse
 // @Failure      400               {object}  RawPayload
 // @Failure      401               {object}  RawPayload
 // @Failure      404               {object}  RawPayload
 // @Failure      429               {object}  RawPayload
 // @Failure      500               {object}  RawPayload
+// @Failure      503               {object}  RawPayload
 // @Router       /v2/municipios/{codigo_municipio} [get]
 func (h *MunicipiosHandler) GetMunicipio(w http.ResponseWriter, r *http.Request) {
 	codigo := chi.URLParam(r, "codigo_municipio")
@@ -71,7 +75,7 @@ func (h *MunicipiosHandler) GetMunicipio(w http.ResponseWriter, r *http.Request)
 
 	resp, err := h.focus.GetMunicipio(r.Context(), codigo)
 	if err != nil {
-		writeJSONError(w, http.StatusBadGateway, err.Error())
+		writeFocusClientError(w, http.StatusBadGateway, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -88,12 +92,14 @@ func (h *MunicipiosHandler) GetMunicipio(w http.ResponseWriter, r *http.Request)
 // @Param        codigo            query     string  false  "Trecho do código (ex: 14.)"
 // @Param        descricao         query     string  false  "Trecho da descrição"
 // @Param        offset            query     int     false  "Paginação (offset)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
 // @Success      200               {array}   RawPayload
 // @Failure      400               {object}  RawPayload
 // @Failure      401               {object}  RawPayload
 // @Failure      404               {object}  RawPayload
 // @Failure      429               {object}  RawPayload
 // @Failure      500               {object}  RawPayload
+// @Failure      503               {object}  RawPayload
 // @Router       /v2/municipios/{codigo_municipio}/itens_lista_servico [get]
 func (h *MunicipiosHandler) ListItensListaServico(w http.ResponseWriter, r *http.Request) {
 	codigoMunicipio := chi.URLParam(r, "codigo_municipio")
@@ -108,7 +114,7 @@ func (h *MunicipiosHandler) ListItensListaServico(w http.ResponseWriter, r *http
 
 	resp, err := h.focus.ListMunicipioItensListaServico(r.Context(), codigoMunicipio, r.URL.RawQuery)
 	if err != nil {
-		writeJSONError(w, http.StatusBadGateway, err.Error())
+		writeFocusClientError(w, http.StatusBadGateway, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -123,12 +129,14 @@ func (h *MunicipiosHandler) ListItensListaServico(w http.ResponseWriter, r *http
 // @Produce      json
 // @Param        codigo_municipio  path      string  true  "Código do município (IBGE)"
 // @Param        codigo            path      string  true  "Código do item (ex: 14.01)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
 // @Success      200               {object}  RawPayload
 // @Failure      400               {object}  RawPayload
 // @Failure      401               {object}  RawPayload
 // @Failure      404               {object}  RawPayload
 // @Failure      429               {object}  RawPayload
 // @Failure      500               {object}  RawPayload
+// @Failure      503               {object}  RawPayload
 // @Router       /v2/municipios/{codigo_municipio}/itens_lista_servico/{codigo} [get]
 func (h *MunicipiosHandler) GetItemListaServico(w http.ResponseWriter, r *http.Request) {
 	codigoMunicipio := chi.URLParam(r, "codigo_municipio")
@@ -144,7 +152,7 @@ func (h *MunicipiosHandler) GetItemListaServico(w http.ResponseWriter, r *http.R
 
 	resp, err := h.focus.GetMunicipioItemListaServico(r.Context(), codigoMunicipio, codigoItem)
 	if err != nil {
-		writeJSONError(w, http.StatusBadGateway, err.Error())
+		writeFocusClientError(w, http.StatusBadGateway, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -161,12 +169,14 @@ func (h *MunicipiosHandler) GetItemListaServico(w http.ResponseWriter, r *http.R
 // @Param        codigo            query     string  false  "Trecho do código (ex: 14.)"
 // @Param        descricao         query     string  false  "Trecho da descrição"
 // @Param        offset            query     int     false  "Paginação (offset)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
 // @Success      200               {array}   RawPayload
 // @Failure      400               {object}  RawPayload
 // @Failure      401               {object}  RawPayload
 // @Failure      404               {object}  RawPayload
 // @Failure      429               {object}  RawPayload
 // @Failure      500               {object}  RawPayload
+// @Failure      503               {object}  RawPayload
 // @Router       /v2/municipios/{codigo_municipio}/codigos_tributarios_municipio [get]
 func (h *MunicipiosHandler) ListCodigosTributarios(w http.ResponseWriter, r *http.Request) {
 	codigoMunicipio := chi.URLParam(r, "codigo_municipio")
@@ -181,7 +191,7 @@ func (h *MunicipiosHandler) ListCodigosTributarios(w http.ResponseWriter, r *htt
 
 	resp, err := h.focus.ListMunicipioCodigosTributarios(r.Context(), codigoMunicipio, r.URL.RawQuery)
 	if err != nil {
-		writeJSONError(w, http.StatusBadGateway, err.Error())
+		writeFocusClientError(w, http.StatusBadGateway, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -196,12 +206,14 @@ func (h *MunicipiosHandler) ListCodigosTributarios(w http.ResponseWriter, r *htt
 // @Produce      json
 // @Param        codigo_municipio  path      string  true  "Código do município (IBGE)"
 // @Param        codigo            path      string  true  "Código tributário municipal"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
 // @Success      200               {object}  RawPayload
 // @Failure      400               {object}  RawPayload
 // @Failure      401               {object}  RawPayload
 // @Failure      404               {object}  RawPayload
 // @Failure      429               {object}  RawPayload
 // @Failure      500               {object}  RawPayload
+// @Failure      503               {object}  RawPayload
 // @Router       /v2/municipios/{codigo_municipio}/codigos_tributarios_municipio/{codigo} [get]
 func (h *MunicipiosHandler) GetCodigoTributario(w http.ResponseWriter, r *http.Request) {
 	codigoMunicipio := chi.URLParam(r, "codigo_municipio")
@@ -217,7 +229,7 @@ func (h *MunicipiosHandler) GetCodigoTributario(w http.ResponseWriter, r *http.R
 
 	resp, err := h.focus.GetMunicipioCodigoTributario(r.Context(), codigoMunicipio, codigo)
 	if err != nil {
-		writeJSONError(w, http.StatusBadGateway, err.Error())
+		writeFocusClientError(w, http.StatusBadGateway, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/model"
+)
+
+var ncmDigits8 = regexp.MustCompile(`^\d{8}$`)
+
+type NcmsHandler struct {
+	focus *focus.Client
+}
+
+func NewNcmsHandler(focusClient *focus.Client) *NcmsHandler {
+	return &NcmsHandler{focus: focusClient}
+}
+
+// ListNcms godoc
+// @Summary      Lista NCMs
+// @Description  Proxy para Focus: GET /v2/ncms. Suporta filtros via querystring (codigo, descricao, capitulo, posicao, subposicao1, subposicao2, item1, item2, offset).
+// @Tags         NCMs
+// @Produce      json
+// @Param        codigo       query     string  false  "Trecho inicial do código (ex: 8471)"
+// @Param        descricao    query     string  false  "Trecho da descrição"
+// @Param        capitulo     query     string  false  "Capítulo (2 dígitos)"
+// @Param        posicao      query     string  false  "Posição (2 dígitos)"
+// @Param        subposicao1  query     string  false  "Subposição 1"
+// @Param        subposicao2  query     string  false  "Subposição 2"
+// @Param        item1        query     string  false  "Item 1"
+// @Param        item2        query     string  false  "Item 2"
+// @Param        offset       query     int     false  "Paginação (offset)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
+// @Success      200          {array}   model.FocusNcmResponse
+// @Failure      401          {object}  RawPayload
+// @Failure      429          {object}  RawPayload
+// @Failure      500          {object}  RawPayload
+// @Failure      503          {object}  RawPayload
+// @Router       /v2/ncms [get]
+func (h *NcmsHandler) ListNcms(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.focus.ListNCMs(r.Context(), r.URL.RawQuery)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyResponse(w, resp)
+}
+
+// GetNcm godoc
+// @Summary      Busca NCM por código
+// @Description  Proxy para Focus: GET /v2/ncms/{codigo}. Informe 8 dígitos (somente números).
+// @Tags         NCMs
+// @Produce      json
+// @Param        codigo  path      string  true  "Código NCM (8 dígitos, somente números)"
+// @Param        X-Focus-Environment  header  string  false  "Ambiente da Focus: producao (padrão) ou homologacao"
+// @Success      200     {object}  model.FocusNcmResponse
+// @Failure      400     {object}  RawPayload
+// @Failure      401     {object}  RawPayload
+// @Failure      404     {object}  RawPayload
+// @Failure      429     {object}  RawPayload
+// @Failure      500     {object}  RawPayload
+// @Failure      503     {object}  RawPayload
+// @Router       /v2/ncms/{codigo} [get]
+func (h *NcmsHandler) GetNcm(w http.ResponseWriter, r *http.Request) {
+	codigo := chi.URLParam(r, "codigo")
+	if codigo == "" {
+		writeJSONError(w, http.StatusBadRequest, "codigo é obrigatório")
+		return
+	}
+	if !ncmDigits8.MatchString(codigo) {
+		writeJSONError(w, http.StatusBadRequest, "codigo inválido: informe 8 dígitos numéricos (somente números)")
+		return
+	}
+
+	resp, err := h.focus.GetNCM(r.Context(), codigo)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyResponse(w, resp)
+}
+
+// keep model import used for swag (avoid unused if build tags differ)
+var _ = model.FocusNcmResponse{}
@@ -0,0 +1,309 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/model"
+	"github.com/seuuser/focus-integration-service/internal/supabase"
+)
+
+// Códigos aceitos em formas_pagamento[].forma_pagamento (tabela da SEFAZ).
+var nfceFormasPagamento = map[string]bool{
+	"01": true, "02": true, "03": true, "04": true, "05": true, "10": true, "11": true, "12": true,
+	"13": true, "15": true, "16": true, "17": true, "18": true, "19": true, "90": true, "99": true,
+}
+
+type NfceHandler struct {
+	focus *focus.Client
+}
+
+func NewNfceHandler(focusClient *focus.Client) *NfceHandler {
+	return &NfceHandler{focus: focusClient}
+}
+
+// EmitNFCe godoc
+// @Summary      Emite NFC-e
+// @Description  Proxy para Focus: POST /v2/nfce?ref=REF usando o token da empresa (focus_integration). A NFC-e é sempre síncrona.
+// @Description  Se a SEFAZ estiver indisponível, a Focus emite em contingência offline (contingencia_offline=true) e transmite depois;
+// @Description  acompanhe por GET /v2/nfce/{ref} até contingencia_offline_efetivada=true.
+// @Description  Rejeições são registradas em focus_integration_errors; a nota e seus eventos em focus_documents / focus_document_events.
+// @Tags         NFC-e
+// @Accept       json
+// @Produce      json
+// @Param        company_id  query     string             true  "ID da empresa (companies.id)"
+// @Param        ref         query     string             true  "Referência única da nota (letras, números, '-', '_' e '.')"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.NFCeRequest  true  "Payload da NFC-e (campos adicionais da Focus são repassados)"
+// @Success      201         {object}  model.NFCeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfce [post]
+func (h *NfceHandler) EmitNFCe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.NFCeRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "payload inválido para NFC-e")
+		return
+	}
+	if erros := validateNFCe(req); len(erros) > 0 {
+		writeValidationErrors(w, "Payload de NFC-e incompleto", erros)
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.EmitNFCe(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	doc, ok := proxyDocumentResponse(w, resp, creds, docNFCe, ref, eventEmissao, body)
+	if !ok {
+		return
+	}
+	logDocumentError(doc, resp.StatusCode, "NFC-e")
+
+	if c := nfceContingencia(doc); c.Offline && !c.Efetivada {
+		recordContingencia(doc, "NFC-e emitida em contingência offline; aguardando transmissão à SEFAZ")
+	}
+}
+
+// GetNFCe godoc
+// @Summary      Consulta NFC-e
+// @Description  Proxy para Focus: GET /v2/nfce/{ref} usando o token da empresa. Atualiza o status em focus_documents
+// @Description  e registra quando uma nota emitida em contingência offline é efetivada na SEFAZ.
+// @Tags         NFC-e
+// @Produce      json
+// @Param        ref         path      string  true   "Referência da nota"
+// @Param        company_id  query     string  true   "ID da empresa (companies.id)"
+// @Param        completa    query     int     false  "1 = inclui o conteúdo completo da nota"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Success      200         {object}  model.NFCeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfce/{ref} [get]
+func (h *NfceHandler) GetNFCe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	completa := r.URL.Query().Get("completa") == "1" || r.URL.Query().Get("completa") == "true"
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	// estado anterior, para detectar a efetivação da contingência
+	var antes contingencia
+	if prev, err := supabase.GetFocusDocument(creds.CompanyID, docNFCe, ref); err == nil && prev != nil {
+		antes = nfceContingencia(*prev)
+	}
+
+	resp, err := fc.GetNFCe(r.Context(), ref, completa)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	doc, ok := proxyDocumentResponse(w, resp, creds, docNFCe, ref, eventConsulta, nil)
+	if !ok {
+		return
+	}
+
+	if depois := nfceContingencia(doc); antes.Offline && !antes.Efetivada && depois.Efetivada {
+		recordContingencia(doc, "NFC-e emitida em contingência offline efetivada na SEFAZ")
+	}
+}
+
+// CancelNFCe godoc
+// @Summary      Cancela NFC-e
+// @Description  Proxy para Focus: DELETE /v2/nfce/{ref} usando o token da empresa. A justificativa deve ter de 15 a 255 caracteres.
+// @Tags         NFC-e
+// @Accept       json
+// @Produce      json
+// @Param        ref         path      string                  true  "Referência da nota"
+// @Param        company_id  query     string                  true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.NFeCancelRequest  true  "Justificativa do cancelamento"
+// @Success      200         {object}  model.NFCeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfce/{ref} [delete]
+func (h *NfceHandler) CancelNFCe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.NFeCancelRequest
+	if err := json.Unmarshal(body, &req); err != nil || !textLenBetween(req.Justificativa, 15, 255) {
+		writeJSONError(w, http.StatusBadRequest, "justificativa é obrigatória e deve ter entre 15 e 255 caracteres")
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.CancelNFCe(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if doc, ok := proxyDocumentResponse(w, resp, creds, docNFCe, ref, eventCancelamento, body); ok {
+		logDocumentError(doc, resp.StatusCode, "Cancelamento NFC-e")
+	}
+}
+
+type contingencia struct {
+	Offline   bool `json:"contingencia_offline"`
+	Efetivada bool `json:"contingencia_offline_efetivada"`
+}
+
+func nfceContingencia(doc supabase.FocusDocument) contingencia {
+	var c contingencia
+	if len(doc.Response) > 0 {
+		_ = json.Unmarshal(doc.Response, &c)
+	}
+	return c
+}
+
+func recordContingencia(doc supabase.FocusDocument, mensagem string) {
+	ev := supabase.FocusDocumentEvent{
+		CompanyID:    doc.CompanyID,
+		DocumentType: doc.DocumentType,
+		Ref:          doc.Ref,
+		Event:        eventContingencia,
+		Status:       doc.Status,
+		Mensagem:     mensagem,
+	}
+	if err := supabase.InsertFocusDocumentEvent(ev); err != nil {
+		log.Printf("[supabase] erro ao gravar focus_document_events (%s ref=%s): %v", doc.DocumentType, doc.Ref, err)
+	}
+}
+
+// validateNFCe confere itens e pagamentos antes de gastar uma chamada à Focus
+// (a NFC-e é síncrona: uma rejeição da SEFAZ consome o número da nota).
+func validateNFCe(req model.NFCeRequest) []focus.FieldError {
+	var erros []focus.FieldError
+	if strings.TrimSpace(req.NaturezaOperacao) == "" {
+		erros = append(erros, focus.FieldError{Campo: "natureza_operacao", Mensagem: "obrigatório"})
+	}
+	if req.DataEmissao == "" {
+		erros = append(erros, focus.FieldError{Campo: "data_emissao", Mensagem: "obrigatório"})
+	}
+	if req.CnpjEmitente == "" {
+		erros = append(erros, focus.FieldError{Campo: "cnpj_emitente", Mensagem: "obrigatório"})
+	}
+
+	erros = append(erros, invalidNumbers(
+		numberField{"presenca_comprador", req.PresencaComprador, true},
+		numberField{"modalidade_frete", req.ModalidadeFrete, true},
+		numberField{"local_destino", req.LocalDestino, true},
+		numberField{"forma_emissao", req.FormaEmissao, true},
+		numberField{"valor_produtos", req.ValorProdutos, false},
+		numberField{"valor_desconto", req.ValorDesconto, false},
+		numberField{"valor_total", req.ValorTotal, false},
+	)...)
+
+	if len(req.Items) == 0 {
+		erros = append(erros, focus.FieldError{Campo: "items", Mensagem: "informe ao menos um item"})
+	}
+	for i, item := range req.Items {
+		campo := "items[" + strconv.Itoa(i) + "]"
+		if item.Cfop == "" {
+			erros = append(erros, focus.FieldError{Campo: campo + ".cfop", Mensagem: "obrigatório"})
+		}
+		if item.CodigoNcm == "" {
+			erros = append(erros, focus.FieldError{Campo: campo + ".codigo_ncm", Mensagem: "obrigatório"})
+		}
+		if q, ok := item.QuantidadeComercial.Float(); !ok || q <= 0 {
+			erros = append(erros, focus.FieldError{Campo: campo + ".quantidade_comercial", Mensagem: "deve ser um número maior que zero"})
+		}
+		erros = append(erros, invalidNumbers(
+			numberField{campo + ".numero_item", item.NumeroItem, true},
+			numberField{campo + ".valor_unitario_comercial", item.ValorUnitarioComercial, false},
+			numberField{campo + ".valor_bruto", item.ValorBruto, false},
+			numberField{campo + ".valor_desconto", item.ValorDesconto, false},
+			numberField{campo + ".icms_origem", item.IcmsOrigem, true},
+		)...)
+	}
+
+	if len(req.FormasPagamento) == 0 {
+		erros = append(erros, focus.FieldError{Campo: "formas_pagamento", Mensagem: "informe ao menos uma forma de pagamento"})
+	}
+	var pago float64
+	somaValida := true
+	for i, p := range req.FormasPagamento {
+		campo := "formas_pagamento[" + strconv.Itoa(i) + "]"
+		if !nfceFormasPagamento[p.FormaPagamento] {
+			erros = append(erros, focus.FieldError{Campo: campo + ".forma_pagamento", Mensagem: "código inválido"})
+		}
+		valor, ok := p.ValorPagamento.Float()
+		switch {
+		case !ok:
+			erros = append(erros, focus.FieldError{Campo: campo + ".valor_pagamento", Mensagem: "deve ser numérico"})
+			somaValida = false
+		case valor < 0:
+			erros = append(erros, focus.FieldError{Campo: campo + ".valor_pagamento", Mensagem: "não pode ser negativo"})
+		}
+		pago += valor
+		if troco, ok := p.Troco.Float(); ok {
+			pago -= troco
+		} else if !p.Troco.Vazio() {
+			erros = append(erros, focus.FieldError{Campo: campo + ".troco", Mensagem: "deve ser numérico"})
+			somaValida = false
+		}
+		erros = append(erros, invalidNumber(campo+".tipo_integracao", p.TipoIntegracao, true)...)
+	}
+	total, ok := req.ValorTotal.Float()
+	if len(req.FormasPagamento) > 0 && somaValida && ok && total > 0 && math.Abs(pago-total) > 0.005 {
+		erros = append(erros, focus.FieldError{Campo: "formas_pagamento", Mensagem: "soma dos pagamentos (descontado o troco) difere de valor_total"})
+	}
+	return erros
+}
@@ -0,0 +1,466 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/model"
+	"github.com/seuuser/focus-integration-service/internal/supabase"
+)
+
+const (
+	// Espera máxima do GET /v2/nfe/{ref}?aguardar= e intervalo entre consultas à Focus.
+	nfeMaxAguardar       = 60 * time.Second
+	nfePollingInterval   = 2 * time.Second
+	nfeStatusProcessando = "processando_autorizacao"
+)
+
+type NfeHandler struct {
+	focus *focus.Client
+}
+
+func NewNfeHandler(focusClient *focus.Client) *NfeHandler {
+	return &NfeHandler{focus: focusClient}
+}
+
+// EmitNFe godoc
+// @Summary      Emite NF-e
+// @Description  Proxy para Focus: POST /v2/nfe?ref=REF usando o token da empresa (focus_integration).
+// @Description  No modo assíncrono (padrão) a Focus responde 202 com status processando_autorizacao: acompanhe por GET /v2/nfe/{ref}.
+// @Description  A emissão e cada mudança de status são registradas em focus_documents / focus_document_events.
+// @Tags         NF-e
+// @Accept       json
+// @Produce      json
+// @Param        company_id  query     string            true  "ID da empresa (companies.id)"
+// @Param        ref         query     string            true  "Referência única da nota (letras, números, '-', '_' e '.')"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.NFeRequest  true  "Payload da NF-e (campos adicionais da Focus são repassados)"
+// @Success      201         {object}  model.NFeResponse
+// @Success      202         {object}  model.NFeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfe [post]
+func (h *NfeHandler) EmitNFe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.NFeRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "payload inválido para NF-e")
+		return
+	}
+	if erros := validateNFe(req); len(erros) > 0 {
+		writeValidationErrors(w, "Payload de NF-e incompleto", erros)
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.EmitNFe(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docNFe, ref, eventEmissao, body)
+}
+
+// ListNFe godoc
+// @Summary      Histórico de NF-e da empresa
+// @Description  Lista as NF-e emitidas pela empresa (focus_documents), da mais recente para a mais antiga. O total vai em X-Total-Count.
+// @Tags         NF-e
+// @Produce      json
+// @Param        company_id  query     string  true   "ID da empresa (companies.id)"
+// @Param        status      query     string  false  "Filtra por status (ex: autorizado, cancelado, processando_autorizacao, erro_autorizacao)"
+// @Param        limit       query     int     false  "Tamanho da página (padrão 50, máx 100)"
+// @Param        offset      query     int     false  "Paginação (offset)"
+// @Success      200         {array}   supabase.FocusDocument
+// @Failure      400         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Router       /v2/nfe [get]
+func (h *NfeHandler) ListNFe(w http.ResponseWriter, r *http.Request) {
+	listDocuments(w, r, docNFe)
+}
+
+// GetNFe godoc
+// @Summary      Consulta NF-e
+// @Description  Proxy para Focus: GET /v2/nfe/{ref} usando o token da empresa. Atualiza o status em focus_documents.
+// @Description  Com aguardar=N (segundos, máx 60), consulta a Focus a cada 2s enquanto o status for processando_autorizacao.
+// @Tags         NF-e
+// @Produce      json
+// @Param        ref         path      string  true   "Referência da nota"
+// @Param        company_id  query     string  true   "ID da empresa (companies.id)"
+// @Param        completa    query     int     false  "1 = inclui o conteúdo completo da nota e dos eventos"
+// @Param        aguardar    query     int     false  "Segundos para aguardar a autorização (máx 60)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Success      200         {object}  model.NFeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfe/{ref} [get]
+func (h *NfeHandler) GetNFe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	completa := q.Get("completa") == "1" || q.Get("completa") == "true"
+	aguardarSeg, err := queryInt(q.Get("aguardar"), 0)
+	if err != nil || aguardarSeg < 0 {
+		writeJSONError(w, http.StatusBadRequest, "aguardar inválido: informe os segundos de espera (máx 60)")
+		return
+	}
+	aguardar := min(time.Duration(aguardarSeg)*time.Second, nfeMaxAguardar)
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	deadline := time.Now().Add(aguardar)
+	for {
+		resp, err := fc.GetNFe(r.Context(), ref, completa)
+		if err != nil {
+			writeFocusClientError(w, http.StatusBadGateway, err)
+			return
+		}
+
+		if resp.StatusCode != http.StatusOK || time.Now().Add(nfePollingInterval).After(deadline) || !isProcessando(resp) {
+			defer resp.Body.Close()
+			proxyDocumentResponse(w, resp, creds, docNFe, ref, eventConsulta, nil)
+			return
+		}
+		resp.Body.Close()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(nfePollingInterval):
+		}
+	}
+}
+
+// isProcessando lê o status da resposta sem consumi-la (o corpo é restaurado para o proxy).
+func isProcessando(resp *http.Response) bool {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	var out struct {
+		Status string `json:"status"`
+	}
+	_ = json.Unmarshal(body, &out)
+	return out.Status == nfeStatusProcessando
+}
+
+// NFeEvents godoc
+// @Summary      Linha do tempo da NF-e
+// @Description  Lista as operações e mudanças de status registradas para a nota (focus_document_events), em ordem cronológica.
+// @Tags         NF-e
+// @Produce      json
+// @Param        ref         path      string  true  "Referência da nota"
+// @Param        company_id  query     string  true  "ID da empresa (companies.id)"
+// @Success      200         {array}   supabase.FocusDocumentEvent
+// @Failure      400         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Router       /v2/nfe/{ref}/eventos [get]
+func (h *NfeHandler) NFeEvents(w http.ResponseWriter, r *http.Request) {
+	documentEvents(w, r, docNFe)
+}
+
+// CancelNFe godoc
+// @Summary      Cancela NF-e
+// @Description  Proxy para Focus: DELETE /v2/nfe/{ref} usando o token da empresa. A justificativa deve ter de 15 a 255 caracteres.
+// @Tags         NF-e
+// @Accept       json
+// @Produce      json
+// @Param        ref         path      string                  true  "Referência da nota"
+// @Param        company_id  query     string                  true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.NFeCancelRequest  true  "Justificativa do cancelamento"
+// @Success      200         {object}  model.NFeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfe/{ref} [delete]
+func (h *NfeHandler) CancelNFe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.NFeCancelRequest
+	if err := json.Unmarshal(body, &req); err != nil || !textLenBetween(req.Justificativa, 15, 255) {
+		writeJSONError(w, http.StatusBadRequest, "justificativa é obrigatória e deve ter entre 15 e 255 caracteres")
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.CancelNFe(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docNFe, ref, eventCancelamento, body)
+}
+
+// CartaCorrecaoNFe godoc
+// @Summary      Emite carta de correção (CC-e) da NF-e
+// @Description  Proxy para Focus: POST /v2/nfe/{ref}/carta_correcao usando o token da empresa. A correção deve ter de 15 a 1000 caracteres.
+// @Tags         NF-e
+// @Accept       json
+// @Produce      json
+// @Param        ref         path      string                         true  "Referência da nota"
+// @Param        company_id  query     string                         true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.NFeCartaCorrecaoRequest  true  "Texto da correção"
+// @Success      201         {object}  model.NFeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfe/{ref}/carta_correcao [post]
+func (h *NfeHandler) CartaCorrecaoNFe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.NFeCartaCorrecaoRequest
+	if err := json.Unmarshal(body, &req); err != nil || !textLenBetween(req.Correcao, 15, 1000) {
+		writeJSONError(w, http.StatusBadRequest, "correcao é obrigatória e deve ter entre 15 e 1000 caracteres")
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.CartaCorrecaoNFe(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docNFe, ref, eventCartaCorrecao, body)
+}
+
+// InutilizarNFe godoc
+// @Summary      Inutiliza faixa de numeração de NF-e
+// @Description  Proxy para Focus: POST /v2/nfe/inutilizacao usando o token da empresa.
+// @Description  O resultado é registrado em focus_document_events com ref inutilizacao-SERIE-INICIAL-FINAL.
+// @Tags         NF-e
+// @Accept       json
+// @Produce      json
+// @Param        company_id  query     string                        true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.NFeInutilizacaoRequest  true  "Faixa a inutilizar"
+// @Success      200         {object}  model.NFeInutilizacaoResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfe/inutilizacao [post]
+func (h *NfeHandler) InutilizarNFe(w http.ResponseWriter, r *http.Request) {
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.NFeInutilizacaoRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "payload inválido para inutilização")
+		return
+	}
+	if erros := validateInutilizacao(req); len(erros) > 0 {
+		writeValidationErrors(w, "Pedido de inutilização inválido", erros)
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.InutilizarNFe(r.Context(), body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "erro ao ler resposta da Focus")
+		return
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBytes))
+
+	// inutilização não tem ref: vira apenas um evento, identificado pela faixa
+	doc := documentFromResponse(creds, docNFe, fmt.Sprintf("inutilizacao-%s-%s-%s", req.Serie, req.NumeroInicial, req.NumeroFinal), resp.StatusCode, respBytes)
+	ev := supabase.FocusDocumentEvent{
+		CompanyID:    doc.CompanyID,
+		DocumentType: doc.DocumentType,
+		Ref:          doc.Ref,
+		Event:        eventInutilizacao,
+		Status:       doc.Status,
+		Mensagem:     doc.Mensagem,
+		Payload:      body,
+	}
+	if err := supabase.InsertFocusDocumentEvent(ev); err != nil {
+		log.Printf("[supabase] erro ao gravar inutilização (%s): %v", doc.Ref, err)
+	}
+
+	proxyResponse(w, resp)
+}
+
+// validateNFe confere os campos mínimos da NF-e antes de gastar uma chamada à Focus.
+func validateNFe(req model.NFeRequest) []focus.FieldError {
+	var erros []focus.FieldError
+	if strings.TrimSpace(req.NaturezaOperacao) == "" {
+		erros = append(erros, focus.FieldError{Campo: "natureza_operacao", Mensagem: "obrigatório"})
+	}
+	if req.DataEmissao == "" {
+		erros = append(erros, focus.FieldError{Campo: "data_emissao", Mensagem: "obrigatório"})
+	}
+	if req.CnpjEmitente == "" {
+		erros = append(erros, focus.FieldError{Campo: "cnpj_emitente", Mensagem: "obrigatório"})
+	}
+	erros = append(erros, invalidNumbers(
+		numberField{"tipo_documento", req.TipoDocumento, true},
+		numberField{"finalidade_emissao", req.FinalidadeEmissao, true},
+		numberField{"local_destino", req.LocalDestino, true},
+		numberField{"consumidor_final", req.ConsumidorFinal, true},
+		numberField{"presenca_comprador", req.PresencaComprador, true},
+		numberField{"indicador_inscricao_estadual_destinatario", req.IndicadorInscricaoEstadualDestinatario, true},
+		numberField{"valor_produtos", req.ValorProdutos, false},
+		numberField{"valor_frete", req.ValorFrete, false},
+		numberField{"valor_seguro", req.ValorSeguro, false},
+		numberField{"valor_total", req.ValorTotal, false},
+		numberField{"modalidade_frete", req.ModalidadeFrete, true},
+	)...)
+
+	if len(req.Items) == 0 {
+		erros = append(erros, focus.FieldError{Campo: "items", Mensagem: "informe ao menos um item"})
+	}
+	for i, item := range req.Items {
+		campo := "items[" + strconv.Itoa(i) + "]"
+		if item.Cfop == "" {
+			erros = append(erros, focus.FieldError{Campo: campo + ".cfop", Mensagem: "obrigatório"})
+		}
+		if item.CodigoNcm == "" {
+			erros = append(erros, focus.FieldError{Campo: campo + ".codigo_ncm", Mensagem: "obrigatório"})
+		}
+		if q, ok := item.QuantidadeComercial.Float(); !ok || q <= 0 {
+			erros = append(erros, focus.FieldError{Campo: campo + ".quantidade_comercial", Mensagem: "deve ser um número maior que zero"})
+		}
+		erros = append(erros, invalidNumbers(
+			numberField{campo + ".numero_item", item.NumeroItem, true},
+			numberField{campo + ".valor_unitario_comercial", item.ValorUnitarioComercial, false},
+			numberField{campo + ".quantidade_tributavel", item.QuantidadeTributavel, false},
+			numberField{campo + ".valor_unitario_tributavel", item.ValorUnitarioTributavel, false},
+			numberField{campo + ".valor_bruto", item.ValorBruto, false},
+			numberField{campo + ".valor_desconto", item.ValorDesconto, false},
+			numberField{campo + ".icms_origem", item.IcmsOrigem, true},
+		)...)
+	}
+
+	for i, p := range req.FormasPagamento {
+		campo := "formas_pagamento[" + strconv.Itoa(i) + "]"
+		erros = append(erros, invalidNumbers(
+			numberField{campo + ".valor_pagamento", p.ValorPagamento, false},
+			numberField{campo + ".tipo_integracao", p.TipoIntegracao, true},
+			numberField{campo + ".troco", p.Troco, false},
+		)...)
+	}
+	return erros
+}
+
+func validateInutilizacao(req model.NFeInutilizacaoRequest) []focus.FieldError {
+	var erros []focus.FieldError
+	if !cnpjDigits14.MatchString(req.CNPJ) {
+		erros = append(erros, focus.FieldError{Campo: "cnpj", Mensagem: "informe 14 dígitos, somente números"})
+	}
+	if req.Serie == "" {
+		erros = append(erros, focus.FieldError{Campo: "serie", Mensagem: "obrigatório"})
+	}
+	ini, errIni := strconv.Atoi(req.NumeroInicial)
+	fim, errFim := strconv.Atoi(req.NumeroFinal)
+	if errIni != nil || ini < 1 {
+		erros = append(erros, focus.FieldError{Campo: "numero_inicial", Mensagem: "informe um número positivo"})
+	}
+	if errFim != nil || fim < 1 {
+		erros = append(erros, focus.FieldError{Campo: "numero_final", Mensagem: "informe um número positivo"})
+	}
+	if errIni == nil && errFim == nil && fim < ini {
+		erros = append(erros, focus.FieldError{Campo: "numero_final", Mensagem: "deve ser maior ou igual a numero_inicial"})
+	}
+	if !textLenBetween(req.Justificativa, 15, 255) {
+		erros = append(erros, focus.FieldError{Campo: "justificativa", Mensagem: "deve ter entre 15 e 255 caracteres"})
+	}
+	return erros
+}
+
+func textLenBetween(s string, lo, hi int) bool {
+	n := utf8.RuneCountInString(strings.TrimSpace(s))
+	return n >= lo && n <= hi
+}
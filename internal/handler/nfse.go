@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/model"
+)
+
+// Os requisitos de NFS-e de um município mudam raramente; evitamos uma consulta à Focus por emissão.
+const municipioCacheTTL = time.Hour
+
+type NfseHandler struct {
+	focus      *focus.Client
+	municipios *ttlCache[*model.FocusMunicipioResponse]
+}
+
+func NewNfseHandler(focusClient *focus.Client) *NfseHandler {
+	return &NfseHandler{
+		focus:      focusClient,
+		municipios: newTTLCache[*model.FocusMunicipioResponse](municipioCacheTTL),
+	}
+}
+
+// EmitNFSe godoc
+// @Summary      Emite NFS-e
+// @Description  Proxy para Focus: POST /v2/nfse?ref=REF usando o token da empresa (focus_integration).
+// @Description  Antes do envio, o payload é validado contra os campos obrigatórios do município do prestador.
+// @Description  A emissão e o status retornado são registrados em focus_documents.
+// @Tags         NFS-e
+// @Accept       json
+// @Produce      json
+// @Param        company_id  query     string             true  "ID da empresa (companies.id)"
+// @Param        ref         query     string             true  "Referência única da nota (letras, números, '-', '_' e '.')"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.NFSeRequest  true  "Payload da NFS-e (campos adicionais da Focus são repassados)"
+// @Success      202         {object}  model.NFSeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfse [post]
+func (h *NfseHandler) EmitNFSe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.NFSeRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "payload inválido para NFS-e")
+		return
+	}
+	if erros := invalidNumbers(
+		numberField{"natureza_operacao", req.NaturezaOperacao, true},
+		numberField{"servico.valor_servicos", req.Servico.ValorServicos, false},
+		numberField{"servico.aliquota", req.Servico.Aliquota, false},
+	); len(erros) > 0 {
+		writeValidationErrors(w, "Payload de NFS-e inválido", erros)
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	if erros := h.validateNFSe(r, req); len(erros) > 0 {
+		writeValidationErrors(w, "Payload de NFS-e incompleto para o município do prestador", erros)
+		return
+	}
+
+	resp, err := fc.EmitNFSe(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docNFSe, ref, eventEmissao, body)
+}
+
+// GetNFSe godoc
+// @Summary      Consulta NFS-e
+// @Description  Proxy para Focus: GET /v2/nfse/{ref} usando o token da empresa. Atualiza o status em focus_documents.
+// @Tags         NFS-e
+// @Produce      json
+// @Param        ref         path      string  true  "Referência da nota"
+// @Param        company_id  query     string  true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Success      200         {object}  model.NFSeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfse/{ref} [get]
+func (h *NfseHandler) GetNFSe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.GetNFSe(r.Context(), ref)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docNFSe, ref, eventConsulta, nil)
+}
+
+// CancelNFSe godoc
+// @Summary      Cancela NFS-e
+// @Description  Proxy para Focus: DELETE /v2/nfse/{ref} usando o token da empresa. Atualiza o status em focus_documents.
+// @Tags         NFS-e
+// @Accept       json
+// @Produce      json
+// @Param        ref         path      string                   true  "Referência da nota"
+// @Param        company_id  query     string                   true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.NFSeCancelRequest  true  "Justificativa do cancelamento"
+// @Success      200         {object}  model.NFSeResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfse/{ref} [delete]
+func (h *NfseHandler) CancelNFSe(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.NFSeCancelRequest
+	if err := json.Unmarshal(body, &req); err != nil || strings.TrimSpace(req.Justificativa) == "" {
+		writeJSONError(w, http.StatusBadRequest, "justificativa é obrigatória")
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.CancelNFSe(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docNFSe, ref, eventCancelamento, body)
+}
+
+// ResendNFSeEmail godoc
+// @Summary      Reenvia NFS-e por e-mail
+// @Description  Proxy para Focus: POST /v2/nfse/{ref}/email usando o token da empresa.
+// @Tags         NFS-e
+// @Accept       json
+// @Produce      json
+// @Param        ref         path      string                  true  "Referência da nota"
+// @Param        company_id  query     string                  true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.NFSeEmailRequest  true  "Destinatários"
+// @Success      200         {object}  RawPayload
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfse/{ref}/email [post]
+func (h *NfseHandler) ResendNFSeEmail(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.NFSeEmailRequest
+	if err := json.Unmarshal(body, &req); err != nil || len(req.Emails) == 0 {
+		writeJSONError(w, http.StatusBadRequest, "informe ao menos um e-mail em emails")
+		return
+	}
+
+	fc, _, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.ResendNFSeEmail(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyResponse(w, resp)
+}
+
+// validateNFSe confere o payload com os campos que o município do prestador exige.
+// Se a consulta do município falhar, a validação fica a cargo da própria Focus.
+func (h *NfseHandler) validateNFSe(r *http.Request, req model.NFSeRequest) []focus.FieldError {
+	codigo := req.Prestador.CodigoMunicipio
+	if codigo == "" {
+		return []focus.FieldError{{Campo: "prestador.codigo_municipio", Mensagem: "obrigatório"}}
+	}
+	if !municipioCodigoDigits.MatchString(codigo) {
+		return []focus.FieldError{{Campo: "prestador.codigo_municipio", Mensagem: "informe somente números (código IBGE)"}}
+	}
+
+	m, ok := h.municipios.Get(codigo)
+	if !ok {
+		var err error
+		m, err = h.focus.GetMunicipioTyped(r.Context(), codigo)
+		if err != nil {
+			if apiErr, isAPI := focus.AsAPIError(err); isAPI && apiErr.StatusCode == http.StatusNotFound {
+				return []focus.FieldError{{Campo: "prestador.codigo_municipio", Mensagem: "município não encontrado na Focus"}}
+			}
+			log.Printf("[focus] não foi possível consultar município %s para validar NFS-e: %v", codigo, err)
+			return nil
+		}
+		h.municipios.Set(codigo, m)
+	}
+
+	var erros []focus.FieldError
+	if !m.NfseHabilitada {
+		erros = append(erros, focus.FieldError{Campo: "prestador.codigo_municipio", Mensagem: "município não habilitado para emissão de NFS-e na Focus"})
+	}
+	if isTrue(m.ItemListaServicoObrigatorioNfse) && req.Servico.ItemListaServico == "" {
+		erros = append(erros, focus.FieldError{Campo: "servico.item_lista_servico", Mensagem: "obrigatório para este município"})
+	}
+	if isTrue(m.CodigoTributarioMunicipioObrigatorioNfse) && req.Servico.CodigoTributarioMunicipio == "" {
+		erros = append(erros, focus.FieldError{Campo: "servico.codigo_tributario_municipio", Mensagem: "obrigatório para este município"})
+	}
+	if isTrue(m.CodigoCnaeObrigatorioNfse) && req.Servico.CodigoCnae == "" {
+		erros = append(erros, focus.FieldError{Campo: "servico.codigo_cnae", Mensagem: "obrigatório para este município"})
+	}
+	if isTrue(m.CpfCnpjObrigatorioNfse) && (req.Tomador == nil || (req.Tomador.CNPJ == "" && req.Tomador.CPF == "")) {
+		erros = append(erros, focus.FieldError{Campo: "tomador.cpf_cnpj", Mensagem: "CPF ou CNPJ do tomador é obrigatório para este município"})
+	}
+	if isTrue(m.EnderecoObrigatorioNfse) && (req.Tomador == nil || req.Tomador.Endereco == nil) {
+		erros = append(erros, focus.FieldError{Campo: "tomador.endereco", Mensagem: "obrigatório para este município"})
+	}
+	return erros
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/model"
+)
+
+type NfsenHandler struct {
+	focus *focus.Client
+	// empresas (focus_company_id) já confirmadas com habilita_nfsen_producao.
+	// Só o resultado positivo é guardado: quem acabou de habilitar não fica bloqueado pelo cache.
+	habilitadas *ttlCache[bool]
+}
+
+func NewNfsenHandler(focusClient *focus.Client) *NfsenHandler {
+	return &NfsenHandler{
+		focus:       focusClient,
+		habilitadas: newTTLCache[bool](municipioCacheTTL),
+	}
+}
+
+// EmitNFSeN godoc
+// @Summary      Emite NFS-e Nacional (DPS)
+// @Description  Proxy para Focus: POST /v2/nfsen?ref=REF usando o token da empresa (focus_integration).
+// @Description  Em produção, exige que a empresa esteja com habilita_nfsen_producao ativo na Focus.
+// @Description  A emissão e o status retornado são registrados em focus_documents.
+// @Tags         NFS-e Nacional
+// @Accept       json
+// @Produce      json
+// @Param        company_id  query     string              true  "ID da empresa (companies.id)"
+// @Param        ref         query     string              true  "Referência única da nota (letras, números, '-', '_' e '.')"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.NFSeNRequest  true  "DPS (campos adicionais da Focus são repassados)"
+// @Success      202         {object}  model.NFSeNResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfsen [post]
+func (h *NfsenHandler) EmitNFSeN(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.NFSeNRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "payload inválido para NFS-e Nacional")
+		return
+	}
+	if erros := validateDPS(req); len(erros) > 0 {
+		writeValidationErrors(w, "DPS incompleta", erros)
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+	if !h.ensureHabilitada(w, r, creds) {
+		return
+	}
+
+	resp, err := fc.EmitNFSeN(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docNFSeN, ref, eventEmissao, body)
+}
+
+// GetNFSeN godoc
+// @Summary      Consulta NFS-e Nacional
+// @Description  Proxy para Focus: GET /v2/nfsen/{ref} usando o token da empresa. Atualiza o status em focus_documents.
+// @Tags         NFS-e Nacional
+// @Produce      json
+// @Param        ref         path      string  true  "Referência da nota"
+// @Param        company_id  query     string  true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Success      200         {object}  model.NFSeNResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfsen/{ref} [get]
+func (h *NfsenHandler) GetNFSeN(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.GetNFSeN(r.Context(), ref)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docNFSeN, ref, eventConsulta, nil)
+}
+
+// CancelNFSeN godoc
+// @Summary      Cancela NFS-e Nacional
+// @Description  Proxy para Focus: DELETE /v2/nfsen/{ref} usando o token da empresa. Atualiza o status em focus_documents.
+// @Tags         NFS-e Nacional
+// @Accept       json
+// @Produce      json
+// @Param        ref         path      string                   true  "Referência da nota"
+// @Param        company_id  query     string                   true  "ID da empresa (companies.id)"
+// @Param        X-Focus-Environment  header  string  false  "Opcional: ambiente em que a empresa foi integrada (producao ou homologacao); outro ambiente responde 409"
+// @Param        payload     body      model.NFSeCancelRequest  true  "Justificativa do cancelamento"
+// @Success      200         {object}  model.NFSeNResponse
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      404         {object}  RawPayload
+// @Failure      409         {object}  RawPayload
+// @Failure      422         {object}  RawPayload
+// @Failure      429         {object}  RawPayload
+// @Failure      500         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /v2/nfsen/{ref} [delete]
+func (h *NfsenHandler) CancelNFSeN(w http.ResponseWriter, r *http.Request) {
+	ref, ok := documentRef(w, r)
+	if !ok {
+		return
+	}
+	body, ok := readJSONBody(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.NFSeCancelRequest
+	if err := json.Unmarshal(body, &req); err != nil || strings.TrimSpace(req.Justificativa) == "" {
+		writeJSONError(w, http.StatusBadRequest, "justificativa é obrigatória")
+		return
+	}
+
+	fc, creds, ok := companyFocusClient(w, r, h.focus)
+	if !ok {
+		return
+	}
+
+	resp, err := fc.CancelNFSeN(r.Context(), ref, body)
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	proxyDocumentResponse(w, resp, creds, docNFSeN, ref, eventCancelamento, body)
+}
+
+// ensureHabilitada recusa a emissão em produção quando a empresa não tem habilita_nfsen_producao na Focus.
+// A consulta usa o token principal (a empresa é lida pelo id da Focus gravado em focus_integration).
+func (h *NfsenHandler) ensureHabilitada(w http.ResponseWriter, r *http.Request, creds focus.CompanyCredentials) bool {
+	if creds.Environment != focus.EnvProducao {
+		return true
+	}
+	if _, ok := h.habilitadas.Get(creds.FocusCompanyID); ok {
+		return true
+	}
+
+	empresa, err := h.focus.GetEmpresaTyped(focus.WithEnvironment(r.Context(), creds.Environment), creds.FocusCompanyID)
+	if apiErr, isAPI := focus.AsAPIError(err); isAPI && apiErr.StatusCode == http.StatusNotFound {
+		writeJSONError(w, http.StatusUnprocessableEntity, "Empresa integrada não encontrada na Focus (focus_company_id="+creds.FocusCompanyID+").")
+		return false
+	}
+	if err != nil {
+		writeFocusClientError(w, http.StatusBadGateway, err)
+		return false
+	}
+	if !isTrue(empresa.HabilitaNFSENProducao) {
+		writeJSONError(w, http.StatusUnprocessableEntity, "Empresa não habilitada para NFS-e Nacional em produção na Focus (habilita_nfsen_producao). Atualize o cadastro da empresa antes de emitir.")
+		return false
+	}
+
+	h.habilitadas.Set(creds.FocusCompanyID, true)
+	return true
+}
+
+// validateDPS confere os campos mínimos da DPS antes de gastar uma chamada à Focus.
+func validateDPS(req model.NFSeNRequest) []focus.FieldError {
+	var erros []focus.FieldError
+	required := func(campo, valor string) {
+		if strings.TrimSpace(valor) == "" {
+			erros = append(erros, focus.FieldError{Campo: campo, Mensagem: "obrigatório"})
+		}
+	}
+
+	required("data_emissao", req.DataEmissao)
+	required("data_competencia", req.DataCompetencia)
+	required("codigo_municipio_emissora", req.CodigoMunicipioEmissora)
+	required("codigo_municipio_prestacao", req.CodigoMunicipioPrestacao)
+	required("codigo_tributacao_nacional_iss", req.CodigoTributacaoNacionalIss)
+	required("descricao_servico", req.DescricaoServico)
+	if req.CnpjPrestador == "" && req.CpfPrestador == "" {
+		erros = append(erros, focus.FieldError{Campo: "cnpj_prestador", Mensagem: "informe cnpj_prestador ou cpf_prestador"})
+	}
+	if v, ok := req.ValorServico.Float(); !ok || v <= 0 {
+		erros = append(erros, focus.FieldError{Campo: "valor_servico", Mensagem: "deve ser um número maior que zero"})
+	}
+	erros = append(erros, invalidNumbers(
+		numberField{"codigo_opcao_simples_nacional", req.CodigoOpcaoSimplesNacional, true},
+		numberField{"regime_especial_tributacao", req.RegimeEspecialTributacao, true},
+		numberField{"tributacao_iss", req.TributacaoIss, true},
+		numberField{"tipo_retencao_iss", req.TipoRetencaoIss, true},
+		numberField{"percentual_aliquota_relativa_municipio", req.PercentualAliquotaRelativaMunicipio, false},
+	)...)
+	return erros
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/seuuser/focus-integration-service/internal/reconcile"
+)
+
+type ReconciliationHandler struct {
+	reconciler *reconcile.Reconciler
+}
+
+func NewReconciliationHandler(reconciler *reconcile.Reconciler) *ReconciliationHandler {
+	return &ReconciliationHandler{reconciler: reconciler}
+}
+
+// GetReconciliation godoc
+// @Summary      Último relatório de reconciliação Supabase x Focus
+// @Description  Devolve o relatório da última reconciliação (agendada ou manual) entre companies / focus_integration e as empresas da Focus.
+// @Tags         Reconciliação
+// @Produce      json
+// @Success      200  {object}  model.ReconciliationReport
+// @Failure      404  {object}  RawPayload
+// @Router       /v2/reconciliation [get]
+func (h *ReconciliationHandler) GetReconciliation(w http.ResponseWriter, r *http.Request) {
+	report := h.reconciler.Last()
+	if report == nil {
+		writeJSONError(w, http.StatusNotFound, "nenhuma reconciliação executada ainda")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// RunReconciliation godoc
+// @Summary      Executa a reconciliação Supabase x Focus
+// @Description  Percorre todas as empresas da Focus (GET /v2/empresas), casa pelo CNPJ/CPF com companies e devolve as divergências:
+// @Description  missing_link, integrated_flag, missing_in_focus, orphaned_focus_empresa, token_mismatch e certificate_dates.
+// @Description  Com fix=true corrige o que é possível do lado do Supabase (a Focus não é alterada).
+// @Description  Um relatório parcial (com error) é devolvido com 502 quando a execução é interrompida.
+// @Tags         Reconciliação
+// @Produce      json
+// @Param        fix  query     bool  false  "Corrige as divergências no Supabase (padrão: false)"
+// @Success      200  {object}  model.ReconciliationReport
+// @Failure      400  {object}  RawPayload
+// @Failure      409  {object}  RawPayload
+// @Failure      502  {object}  model.ReconciliationReport
+// @Router       /v2/reconciliation [post]
+func (h *ReconciliationHandler) RunReconciliation(w http.ResponseWriter, r *http.Request) {
+	fix := false
+	if v := r.URL.Query().Get("fix"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "fix inválido: use true ou false")
+			return
+		}
+		fix = b
+	}
+
+	report, err := h.reconciler.RunOnce(r.Context(), fix)
+	if errors.Is(err, reconcile.ErrRunning) {
+		writeJSONError(w, http.StatusConflict, "reconciliação já em andamento; consulte o resultado em GET /v2/reconciliation")
+		return
+	}
+	if err != nil {
+		writeJSON(w, http.StatusBadGateway, report)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/supabase"
+)
+
+const (
+	// Tamanho máximo aceito no corpo de um webhook (a Focus envia o mesmo JSON da consulta do documento).
+	webhookMaxBody = 1 << 20
+	// Entregas recentes lembradas em memória, para responder reenvios sem tocar no Supabase.
+	webhookRecentTTL = 10 * time.Minute
+	// Entregas gravadas e não processadas (fila cheia, restart) são retomadas a cada webhookSweepInterval.
+	webhookSweepInterval = time.Minute
+	webhookSweepBatch    = 100
+	// Entregas que ainda não puderam ser associadas a uma empresa (documento não gravado, Supabase fora)
+	// ficam pendentes e voltam na varredura até webhookRetryWindow depois do recebimento.
+	webhookRetryWindow = time.Hour
+
+	eventWebhook = "webhook"
+)
+
+// errAmbiguousRef: a ref existe em mais de uma empresa; esperar não resolve, a entrega é encerrada com erro.
+var errAmbiguousRef = errors.New("ref ambígua entre empresas")
+
+// Tipos de documento aceitos em ?evento= (o mesmo valor usado ao registrar o gatilho na Focus).
+var webhookDocumentTypes = map[string]bool{
+	docNFSe: true, docNFSeN: true, docNFe: true, docNFCe: true, docCTe: true, docMDFe: true,
+}
+
+// WebhooksHandler recebe as notificações da Focus. A entrega é gravada em focus_webhook_events antes da
+// resposta; a atualização do documento acontece num worker (em ordem de chegada), que também retoma as
+// entregas gravadas e ainda não processadas.
+type WebhooksHandler struct {
+	focus  *focus.Client
+	secret string
+	header string
+	queue  chan supabase.FocusWebhookEvent
+	recent *ttlCache[bool]
+
+	mu     sync.Mutex
+	queued map[string]bool // delivery_hash na fila (a varredura não os pega de novo)
+}
+
+// NewWebhooksHandler cria o handler e inicia o worker. secret vazio desliga o endpoint (503).
+// focusClient resolve a integração da empresa (ambiente do documento).
+func NewWebhooksHandler(focusClient *focus.Client, secret, header string, buffer int) *WebhooksHandler {
+	if buffer <= 0 {
+		buffer = 256
+	}
+	h := &WebhooksHandler{
+		focus:  focusClient,
+		secret: secret,
+		header: header,
+		queue:  make(chan supabase.FocusWebhookEvent, buffer),
+		recent: newTTLCache[bool](webhookRecentTTL),
+		queued: make(map[string]bool),
+	}
+	go h.run()
+	return h
+}
+
+// FocusWebhook godoc
+// @Summary      Recebe webhook da Focus
+// @Description  Endpoint chamado pela Focus quando o status de um documento muda (gatilhos cadastrados em /v2/hooks).
+// @Description  Autenticado pelo segredo compartilhado no header configurado (FOCUS_WEBHOOK_SECRET_HEADER).
+// @Description  Entregas repetidas são ignoradas. O evento é gravado em focus_webhook_events antes da resposta (falha: 503, a Focus reenvia);
+// @Description  o documento em focus_documents é atualizado de forma assíncrona.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        evento      query     string      true   "Tipo do documento: nfse, nfsen, nfe, nfce, cte ou mdfe"
+// @Param        company_id  query     string      false  "ID da empresa (companies.id); sem ele, a empresa é deduzida pela ref"
+// @Param        payload     body      RawPayload  true   "Documento no formato da consulta da Focus"
+// @Success      200         {object}  RawPayload
+// @Success      202         {object}  RawPayload
+// @Failure      400         {object}  RawPayload
+// @Failure      401         {object}  RawPayload
+// @Failure      503         {object}  RawPayload
+// @Router       /webhooks/focus [post]
+func (h *WebhooksHandler) FocusWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.secret == "" {
+		writeJSONError(w, http.StatusServiceUnavailable, "webhook da Focus não configurado")
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(h.header)), []byte(h.secret)) != 1 {
+		writeJSONError(w, http.StatusUnauthorized, "não autorizado")
+		return
+	}
+
+	q := r.URL.Query()
+	docType := q.Get("evento")
+	if !webhookDocumentTypes[docType] {
+		writeJSONError(w, http.StatusBadRequest, "evento inválido: use nfse, nfsen, nfe, nfce, cte ou mdfe")
+		return
+	}
+	companyID := q.Get("company_id")
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, webhookMaxBody))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "não foi possível ler o corpo da requisição")
+		return
+	}
+	var doc struct {
+		Ref string `json:"ref"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil || doc.Ref == "" {
+		writeJSONError(w, http.StatusBadRequest, "payload inválido: esperado o documento com ref")
+		return
+	}
+
+	sum := sha256.Sum256([]byte(docType + "\n" + companyID + "\n" + string(body)))
+	hash := hex.EncodeToString(sum[:])
+
+	if _, seen := h.recent.Get(hash); seen {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "duplicado"})
+		return
+	}
+
+	if companyID == "" {
+		// sem company_id, a empresa vem do documento já gravado; se não der, o worker tenta de novo
+		companyID, _ = companyForRef(docType, doc.Ref)
+	}
+	var payload struct {
+		Status string `json:"status"`
+	}
+	_ = json.Unmarshal(body, &payload)
+	ev := supabase.FocusWebhookEvent{
+		DeliveryHash: hash,
+		CompanyID:    companyID,
+		DocumentType: docType,
+		Ref:          doc.Ref,
+		Status:       payload.Status,
+		Payload:      body,
+		ReceivedAt:   time.Now().UTC(),
+	}
+
+	// Só responde 2xx depois de gravar: a Focus não reenvia entregas confirmadas.
+	duplicate, err := supabase.InsertFocusWebhookEvent(ev)
+	if err != nil {
+		log.Printf("[webhook] erro ao gravar focus_webhook_events (%s ref=%s): %v", docType, doc.Ref, err)
+		w.Header().Set("Retry-After", "5")
+		writeJSONError(w, http.StatusServiceUnavailable, "não foi possível registrar o webhook, tente novamente")
+		return
+	}
+	h.recent.Set(hash, true)
+	if duplicate {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "duplicado"})
+		return
+	}
+
+	h.mu.Lock()
+	select {
+	case h.queue <- ev:
+		h.queued[hash] = true
+	default:
+		// fila cheia: a entrega já está gravada e a varredura do worker a processa
+		log.Printf("[webhook] fila cheia; %s ref=%s será processado pela varredura", docType, doc.Ref)
+	}
+	h.mu.Unlock()
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": "recebido"})
+}
+
+func (h *WebhooksHandler) run() {
+	ticker := time.NewTicker(webhookSweepInterval)
+	defer ticker.Stop()
+
+	h.sweep()
+	for {
+		select {
+		case ev := <-h.queue:
+			h.process(ev)
+			h.mu.Lock()
+			delete(h.queued, ev.DeliveryHash)
+			h.mu.Unlock()
+		case <-ticker.C:
+			h.sweep()
+		}
+	}
+}
+
+// sweep processa as entregas gravadas que não passaram pela fila (fila cheia ou serviço reiniciado).
+// Só pega as recebidas há mais de um intervalo, para não disputar com a fila.
+func (h *WebhooksHandler) sweep() {
+	if supabase.GetClient() == nil {
+		return
+	}
+	pending, err := supabase.ListPendingFocusWebhookEvents(time.Now().Add(-webhookSweepInterval), webhookSweepBatch)
+	if err != nil {
+		log.Printf("[webhook] erro ao listar webhooks pendentes: %v", err)
+		return
+	}
+	for _, ev := range pending {
+		h.mu.Lock()
+		inQueue := h.queued[ev.DeliveryHash]
+		h.mu.Unlock()
+		if inQueue {
+			continue
+		}
+		h.process(ev)
+	}
+}
+
+// process atualiza o documento a partir de uma entrega já gravada e marca a entrega como processada.
+// Se a empresa (ou o ambiente dela) ainda não pode ser resolvida, ou o documento não pôde ser gravado,
+// a entrega fica pendente para a varredura.
+func (h *WebhooksHandler) process(ev supabase.FocusWebhookEvent) {
+	creds, err := h.eventCompany(ev)
+	if err != nil {
+		if !errors.Is(err, errAmbiguousRef) && time.Since(ev.ReceivedAt) < webhookRetryWindow {
+			log.Printf("[webhook] %s ref=%s pendente (nova tentativa na varredura): %v", ev.DocumentType, ev.Ref, err)
+			return
+		}
+		log.Printf("[webhook] %s ref=%s não processado: %v", ev.DocumentType, ev.Ref, err)
+		if err := supabase.MarkFocusWebhookEventProcessed(ev.DeliveryHash, "", err.Error()); err != nil {
+			log.Printf("[webhook] erro ao marcar webhook processado (%s ref=%s): %v", ev.DocumentType, ev.Ref, err)
+		}
+		return
+	}
+
+	// Um status mais novo do mesmo documento já foi aplicado (entrega fora de ordem, varredura): não volta
+	// o documento. A empresa é a resolvida, mesmo quando a entrega veio sem company_id.
+	ev.CompanyID = creds.CompanyID
+	if newer, err := supabase.HasNewerProcessedFocusWebhookEvent(ev); err != nil {
+		log.Printf("[webhook] %s ref=%s pendente (erro ao verificar webhooks mais recentes): %v", ev.DocumentType, ev.Ref, err)
+		return
+	} else if newer {
+		if err := supabase.MarkFocusWebhookEventProcessed(ev.DeliveryHash, ev.CompanyID, "ignorado: notificação mais recente já processada"); err != nil {
+			log.Printf("[webhook] erro ao marcar webhook processado (%s ref=%s): %v", ev.DocumentType, ev.Ref, err)
+		}
+		return
+	}
+
+	doc := documentFromResponse(creds, ev.DocumentType, ev.Ref, http.StatusOK, ev.Payload)
+	if err := recordDocument(doc, eventWebhook, nil); err != nil {
+		// a Focus não reenvia (já recebeu 2xx): a varredura refaz a partir da entrega gravada
+		log.Printf("[webhook] %s ref=%s pendente (nova tentativa na varredura): %v", ev.DocumentType, ev.Ref, err)
+		return
+	}
+
+	if err := supabase.MarkFocusWebhookEventProcessed(ev.DeliveryHash, ev.CompanyID, ""); err != nil {
+		log.Printf("[webhook] erro ao marcar webhook processado (%s ref=%s): %v", ev.DocumentType, ev.Ref, err)
+	}
+}
+
+// eventCompany resolve a empresa da entrega (company_id ou, sem ele, pela ref) e o ambiente em que ela
+// foi integrada: o documento é gravado nesse ambiente, nunca no padrão.
+func (h *WebhooksHandler) eventCompany(ev supabase.FocusWebhookEvent) (focus.CompanyCredentials, error) {
+	companyID := ev.CompanyID
+	if companyID == "" {
+		var err error
+		if companyID, err = companyForRef(ev.DocumentType, ev.Ref); err != nil {
+			return focus.CompanyCredentials{}, err
+		}
+	}
+	creds, err := h.focus.ResolveCompany(context.Background(), companyID)
+	if err != nil {
+		return focus.CompanyCredentials{}, fmt.Errorf("erro ao resolver a integração da empresa %s: %w", companyID, err)
+	}
+	return creds, nil
+}
+
+// companyForRef deduz a empresa de um webhook sem company_id pelo documento já gravado.
+func companyForRef(docType, ref string) (string, error) {
+	docs, err := supabase.FindFocusDocumentsByRef(docType, ref)
+	if err != nil {
+		return "", fmt.Errorf("erro ao buscar documento: %w", err)
+	}
+	switch len(docs) {
+	case 0:
+		return "", fmt.Errorf("documento %s ref=%s não encontrado em focus_documents", docType, ref)
+	case 1:
+		return docs[0].CompanyID, nil
+	default:
+		return "", fmt.Errorf("%w: ref %s; registre o gatilho com company_id", errAmbiguousRef, ref)
+	}
+}
@@ -0,0 +1,23 @@
+package model
+
+// FocusBackupsResponse is the response of GET /v2/backups/{cnpj}.json: one entry per month with
+// links to the zip archives kept by FocusNFe.
+type FocusBackupsResponse struct {
+	Backups []FocusBackup `json:"backups"`
+}
+
+type FocusBackup struct {
+	Mes    string `json:"mes" example:"2024-03"`
+	Danfes string `json:"danfes,omitempty" example:"https://api.focusnfe.com.br/arquivos/backups/10964044000164_2024-03_danfes.zip"`
+	XMLs   string `json:"xmls,omitempty" example:"https://api.focusnfe.com.br/arquivos/backups/10964044000164_2024-03_xmls.zip"`
+}
+
+// CompanyBackupArchiveResponse: resultado do arquivamento de um mês no Supabase Storage.
+type CompanyBackupArchiveResponse struct {
+	Mes               string `json:"mes" example:"2024-03"`
+	Bucket            string `json:"bucket" example:"focus-documents"`
+	XMLsStoragePath   string `json:"xmls_storage_path,omitempty" example:"b3c1.../backups/producao/2024-03/xmls.zip"`
+	XMLsURL           string `json:"xmls_url,omitempty"`
+	DanfesStoragePath string `json:"danfes_storage_path,omitempty" example:"b3c1.../backups/producao/2024-03/danfes.zip"`
+	DanfesURL         string `json:"danfes_url,omitempty"`
+}
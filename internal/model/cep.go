@@ -0,0 +1,17 @@
+package model
+
+// FocusCepResponse represents the response from FocusNFe CEP lookup endpoint.
+// This struct is used mainly for Swagger documentation.
+type FocusCepResponse struct {
+	CEP               string `json:"cep" example:"01310100"`
+	Tipo              string `json:"tipo" example:"logradouro"`
+	UF                string `json:"uf" example:"SP"`
+	NomeLocalidade    string `json:"nome_localidade" example:"São Paulo"`
+	CodigoIbge        string `json:"codigo_ibge" example:"3550308"`
+	TipoLogradouro    string `json:"tipo_logradouro" example:"Avenida"`
+	NomeLogradouro    string `json:"nome_logradouro" example:"Paulista"`
+	Descricao         string `json:"descricao" example:"Avenida Paulista"`
+	DescricaoBairro   string `json:"descricao_bairro" example:"Bela Vista"`
+	NomeBairroInicial string `json:"nome_bairro_inicial,omitempty"`
+	Complemento       string `json:"complemento,omitempty" example:"de 612 a 1510 - lado par"`
+}
@@ -0,0 +1,8 @@
+package model
+
+// FocusCfopResponse represents a CFOP (Código Fiscal de Operações e Prestações) record returned by FocusNFe.
+// It is mainly used for Swagger documentation.
+type FocusCfopResponse struct {
+	Codigo    string `json:"codigo" example:"5102"`
+	Descricao string `json:"descricao" example:"Venda de mercadoria adquirida ou recebida de terceiros"`
+}
@@ -37,6 +37,7 @@ type FocusEmpresaResponse struct {
 	HabilitaNFCE              *bool `json:"habilita_nfce,omitempty" example:"false"`
 	HabilitaNFE               *bool `json:"habilita_nfe,omitempty" example:"false"`
 	HabilitaNFSE              *bool `json:"habilita_nfse,omitempty" example:"false"`
+	HabilitaNFSENProducao     *bool `json:"habilita_nfsen_producao,omitempty" example:"false"`
 	HabilitaCTE               *bool `json:"habilita_cte,omitempty" example:"false"`
 	HabilitaMDFE              *bool `json:"habilita_mdfe,omitempty" example:"false"`
 	HabilitaManifestacao      *bool `json:"habilita_manifestacao,omitempty" example:"false"`
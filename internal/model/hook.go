@@ -0,0 +1,26 @@
+package model
+
+// FocusHookRequest é o cadastro de um gatilho na Focus (POST /v2/hooks).
+type FocusHookRequest struct {
+	CNPJ                string `json:"cnpj,omitempty" example:"10964044000164"`
+	CPF                 string `json:"cpf,omitempty"`
+	Event               string `json:"event" example:"nfe"`
+	URL                 string `json:"url" example:"https://api.exemplo.com.br/webhooks/focus?evento=nfe"`
+	Authorization       string `json:"authorization,omitempty"`
+	AuthorizationHeader string `json:"authorization_header,omitempty" example:"X-Focus-Webhook-Secret"`
+}
+
+// FocusHookResponse é um gatilho cadastrado na Focus.
+type FocusHookResponse struct {
+	ID                  string `json:"id" example:"Vj5rmkBq"`
+	CNPJ                string `json:"cnpj,omitempty" example:"10964044000164"`
+	CPF                 string `json:"cpf,omitempty"`
+	Event               string `json:"event" example:"nfe"`
+	URL                 string `json:"url" example:"https://api.exemplo.com.br/webhooks/focus?evento=nfe"`
+	AuthorizationHeader string `json:"authorization_header,omitempty" example:"X-Focus-Webhook-Secret"`
+}
+
+// CompanyHookRequest: POST /v2/companies/{company_id}/hooks. A URL e o segredo vêm da configuração do serviço.
+type CompanyHookRequest struct {
+	Event string `json:"event" example:"nfe"`
+}
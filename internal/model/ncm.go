@@ -0,0 +1,14 @@
+package model
+
+// FocusNcmResponse represents an NCM (Nomenclatura Comum do Mercosul) record returned by FocusNFe.
+// It is mainly used for Swagger documentation.
+type FocusNcmResponse struct {
+	Codigo            string `json:"codigo" example:"84713012"`
+	DescricaoCompleta string `json:"descricao_completa" example:"Máquinas automáticas para processamento de dados, portáteis..."`
+	Capitulo          string `json:"capitulo" example:"84"`
+	Posicao           string `json:"posicao" example:"71"`
+	Subposicao1       string `json:"subposicao1" example:"3"`
+	Subposicao2       string `json:"subposicao2" example:"0"`
+	Item1             string `json:"item1" example:"1"`
+	Item2             string `json:"item2" example:"2"`
+}
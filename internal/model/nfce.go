@@ -0,0 +1,80 @@
+package model
+
+// NFCeRequest representa o payload de emissão de NFC-e na Focus (POST /v2/nfce?ref=REF).
+// Documenta os campos principais; campos adicionais aceitos pela Focus são repassados sem alteração.
+// Campos numéricos aceitam número ou texto (ver Numero).
+type NFCeRequest struct {
+	NaturezaOperacao  string `json:"natureza_operacao" example:"Venda ao consumidor"`
+	DataEmissao       string `json:"data_emissao" example:"2025-01-15T10:00:00-03:00"`
+	PresencaComprador Numero `json:"presenca_comprador,omitempty" swaggertype:"integer" example:"1"`
+	ModalidadeFrete   Numero `json:"modalidade_frete,omitempty" swaggertype:"integer" example:"9"`
+	LocalDestino      Numero `json:"local_destino,omitempty" swaggertype:"integer" example:"1"`
+	// FormaEmissao: 1 = normal (padrão); 9 = contingência offline (a Focus transmite à SEFAZ depois).
+	FormaEmissao Numero `json:"forma_emissao,omitempty" swaggertype:"integer" example:"1"`
+
+	CnpjEmitente string `json:"cnpj_emitente" example:"10964044000164"`
+
+	// Consumidor (opcional na NFC-e; obrigatório acima do limite da UF)
+	NomeDestinatario string `json:"nome_destinatario,omitempty" example:"Maria da Silva"`
+	CpfDestinatario  string `json:"cpf_destinatario,omitempty" example:"12345678901"`
+	CnpjDestinatario string `json:"cnpj_destinatario,omitempty"`
+
+	ValorProdutos Numero `json:"valor_produtos,omitempty" swaggertype:"number" example:"30.00"`
+	ValorDesconto Numero `json:"valor_desconto,omitempty" swaggertype:"number" example:"0"`
+	ValorTotal    Numero `json:"valor_total" swaggertype:"number" example:"30.00"`
+
+	InformacoesAdicionaisContribuinte string `json:"informacoes_adicionais_contribuinte,omitempty"`
+
+	Items           []NFCeItem      `json:"items"`
+	FormasPagamento []NFCePagamento `json:"formas_pagamento"`
+}
+
+// NFCeItem é um item (produto) da NFC-e.
+type NFCeItem struct {
+	NumeroItem             Numero `json:"numero_item" swaggertype:"integer" example:"1"`
+	CodigoProduto          string `json:"codigo_produto" example:"SKU-001"`
+	Descricao              string `json:"descricao" example:"Caneta azul"`
+	CodigoBarrasComercial  string `json:"codigo_barras_comercial,omitempty" example:"SEM GTIN"`
+	Cfop                   string `json:"cfop" example:"5102"`
+	CodigoNcm              string `json:"codigo_ncm" example:"96081000"`
+	UnidadeComercial       string `json:"unidade_comercial" example:"UN"`
+	QuantidadeComercial    Numero `json:"quantidade_comercial" swaggertype:"number" example:"2"`
+	ValorUnitarioComercial Numero `json:"valor_unitario_comercial" swaggertype:"number" example:"15.00"`
+	ValorBruto             Numero `json:"valor_bruto" swaggertype:"number" example:"30.00"`
+	ValorDesconto          Numero `json:"valor_desconto,omitempty" swaggertype:"number" example:"0"`
+	IcmsOrigem             Numero `json:"icms_origem,omitempty" swaggertype:"integer" example:"0"`
+	IcmsSituacaoTributaria string `json:"icms_situacao_tributaria,omitempty" example:"102"`
+}
+
+// NFCePagamento é uma forma de pagamento (formas_pagamento) da NFC-e.
+// forma_pagamento: 01 dinheiro, 02 cheque, 03 cartão de crédito, 04 cartão de débito, 05 crédito loja,
+// 10 vale alimentação, 11 vale refeição, 12 vale presente, 13 vale combustível, 15 boleto,
+// 16 depósito, 17 PIX, 18 transferência, 19 fidelidade/cashback, 90 sem pagamento, 99 outros.
+type NFCePagamento struct {
+	FormaPagamento    string `json:"forma_pagamento" example:"01"`
+	ValorPagamento    Numero `json:"valor_pagamento" swaggertype:"number" example:"50.00"`
+	TipoIntegracao    Numero `json:"tipo_integracao,omitempty" swaggertype:"integer" example:"2"`
+	CnpjCredenciadora string `json:"cnpj_credenciadora,omitempty"`
+	BandeiraOperadora string `json:"bandeira_operadora,omitempty" example:"01"`
+	NumeroAutorizacao string `json:"numero_autorizacao,omitempty"`
+	Troco             Numero `json:"troco,omitempty" swaggertype:"number" example:"20.00"`
+}
+
+// NFCeResponse representa o retorno da Focus para emissão/consulta/cancelamento de NFC-e.
+type NFCeResponse struct {
+	CnpjEmitente                 string `json:"cnpj_emitente,omitempty" example:"10964044000164"`
+	Ref                          string `json:"ref,omitempty" example:"nfce-2025-0001"`
+	Status                       string `json:"status" example:"autorizado"`
+	StatusSefaz                  string `json:"status_sefaz,omitempty" example:"100"`
+	MensagemSefaz                string `json:"mensagem_sefaz,omitempty" example:"Autorizado o uso da NF-e"`
+	ChaveNfe                     string `json:"chave_nfe,omitempty"`
+	Numero                       string `json:"numero,omitempty" example:"1"`
+	Serie                        string `json:"serie,omitempty" example:"1"`
+	CaminhoXMLNotaFiscal         string `json:"caminho_xml_nota_fiscal,omitempty"`
+	CaminhoDanfe                 string `json:"caminho_danfe,omitempty"`
+	QrcodeURL                    string `json:"qrcode_url,omitempty"`
+	URLConsultaNF                string `json:"url_consulta_nf,omitempty"`
+	ContingenciaOffline          *bool  `json:"contingencia_offline,omitempty" example:"false"`
+	ContingenciaOfflineEfetivada *bool  `json:"contingencia_offline_efetivada,omitempty" example:"false"`
+	CaminhoXMLCancelamento       string `json:"caminho_xml_cancelamento,omitempty"`
+}
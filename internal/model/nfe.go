@@ -0,0 +1,125 @@
+package model
+
+// NFeRequest representa o payload de emissão de NF-e na Focus (POST /v2/nfe?ref=REF).
+// Documenta os campos principais; campos adicionais aceitos pela Focus são repassados sem alteração.
+// Campos numéricos aceitam número ou texto (ver Numero).
+type NFeRequest struct {
+	NaturezaOperacao  string `json:"natureza_operacao" example:"Venda de mercadoria"`
+	DataEmissao       string `json:"data_emissao" example:"2025-01-15T10:00:00-03:00"`
+	DataEntradaSaida  string `json:"data_entrada_saida,omitempty" example:"2025-01-15T10:00:00-03:00"`
+	TipoDocumento     Numero `json:"tipo_documento" swaggertype:"integer" example:"1"`
+	FinalidadeEmissao Numero `json:"finalidade_emissao" swaggertype:"integer" example:"1"`
+	LocalDestino      Numero `json:"local_destino,omitempty" swaggertype:"integer" example:"1"`
+	ConsumidorFinal   Numero `json:"consumidor_final,omitempty" swaggertype:"integer" example:"0"`
+	PresencaComprador Numero `json:"presenca_comprador,omitempty" swaggertype:"integer" example:"1"`
+
+	// Emitente
+	CnpjEmitente              string `json:"cnpj_emitente" example:"10964044000164"`
+	InscricaoEstadualEmitente string `json:"inscricao_estadual_emitente,omitempty" example:"1234567890"`
+
+	// Destinatário
+	NomeDestinatario                       string `json:"nome_destinatario" example:"Acme Ltda"`
+	CnpjDestinatario                       string `json:"cnpj_destinatario,omitempty" example:"07504505000132"`
+	CpfDestinatario                        string `json:"cpf_destinatario,omitempty"`
+	InscricaoEstadualDestinatario          string `json:"inscricao_estadual_destinatario,omitempty"`
+	IndicadorInscricaoEstadualDestinatario Numero `json:"indicador_inscricao_estadual_destinatario,omitempty" swaggertype:"integer" example:"9"`
+	LogradouroDestinatario                 string `json:"logradouro_destinatario,omitempty" example:"Rua João da Silva"`
+	NumeroDestinatario                     string `json:"numero_destinatario,omitempty" example:"153"`
+	BairroDestinatario                     string `json:"bairro_destinatario,omitempty" example:"Vila Isabel"`
+	MunicipioDestinatario                  string `json:"municipio_destinatario,omitempty" example:"Curitiba"`
+	UFDestinatario                         string `json:"uf_destinatario,omitempty" example:"PR"`
+	CepDestinatario                        string `json:"cep_destinatario,omitempty" example:"80210000"`
+	PaisDestinatario                       string `json:"pais_destinatario,omitempty" example:"Brasil"`
+	TelefoneDestinatario                   string `json:"telefone_destinatario,omitempty"`
+	EmailDestinatario                      string `json:"email_destinatario,omitempty" example:"financeiro@acme.com.br"`
+
+	// Totais e frete
+	ValorProdutos   Numero `json:"valor_produtos,omitempty" swaggertype:"number" example:"150.00"`
+	ValorFrete      Numero `json:"valor_frete,omitempty" swaggertype:"number" example:"0"`
+	ValorSeguro     Numero `json:"valor_seguro,omitempty" swaggertype:"number" example:"0"`
+	ValorTotal      Numero `json:"valor_total,omitempty" swaggertype:"number" example:"150.00"`
+	ModalidadeFrete Numero `json:"modalidade_frete,omitempty" swaggertype:"integer" example:"9"`
+
+	Items           []NFeItem      `json:"items"`
+	FormasPagamento []NFePagamento `json:"formas_pagamento,omitempty"`
+}
+
+// NFeItem é um item (produto) da NF-e/NFC-e.
+type NFeItem struct {
+	NumeroItem               Numero `json:"numero_item" swaggertype:"integer" example:"1"`
+	CodigoProduto            string `json:"codigo_produto" example:"SKU-001"`
+	Descricao                string `json:"descricao" example:"Caneta azul"`
+	Cfop                     string `json:"cfop" example:"5102"`
+	CodigoNcm                string `json:"codigo_ncm" example:"96081000"`
+	UnidadeComercial         string `json:"unidade_comercial" example:"UN"`
+	QuantidadeComercial      Numero `json:"quantidade_comercial" swaggertype:"number" example:"10"`
+	ValorUnitarioComercial   Numero `json:"valor_unitario_comercial" swaggertype:"number" example:"15.00"`
+	UnidadeTributavel        string `json:"unidade_tributavel,omitempty" example:"UN"`
+	QuantidadeTributavel     Numero `json:"quantidade_tributavel,omitempty" swaggertype:"number" example:"10"`
+	ValorUnitarioTributavel  Numero `json:"valor_unitario_tributavel,omitempty" swaggertype:"number" example:"15.00"`
+	ValorBruto               Numero `json:"valor_bruto" swaggertype:"number" example:"150.00"`
+	ValorDesconto            Numero `json:"valor_desconto,omitempty" swaggertype:"number" example:"0"`
+	IcmsOrigem               Numero `json:"icms_origem,omitempty" swaggertype:"integer" example:"0"`
+	IcmsSituacaoTributaria   string `json:"icms_situacao_tributaria,omitempty" example:"102"`
+	PisSituacaoTributaria    string `json:"pis_situacao_tributaria,omitempty" example:"07"`
+	CofinsSituacaoTributaria string `json:"cofins_situacao_tributaria,omitempty" example:"07"`
+}
+
+// NFePagamento é uma forma de pagamento (formas_pagamento) da NF-e/NFC-e.
+type NFePagamento struct {
+	FormaPagamento    string `json:"forma_pagamento" example:"01"`
+	ValorPagamento    Numero `json:"valor_pagamento" swaggertype:"number" example:"150.00"`
+	TipoIntegracao    Numero `json:"tipo_integracao,omitempty" swaggertype:"integer" example:"2"`
+	CnpjCredenciadora string `json:"cnpj_credenciadora,omitempty"`
+	BandeiraOperadora string `json:"bandeira_operadora,omitempty" example:"01"`
+	NumeroAutorizacao string `json:"numero_autorizacao,omitempty"`
+	Troco             Numero `json:"troco,omitempty" swaggertype:"number" example:"0"`
+}
+
+// NFeResponse representa o retorno da Focus para emissão/consulta/cancelamento de NF-e.
+type NFeResponse struct {
+	CnpjEmitente            string `json:"cnpj_emitente,omitempty" example:"10964044000164"`
+	Ref                     string `json:"ref,omitempty" example:"nfe-2025-0001"`
+	Status                  string `json:"status" example:"autorizado"`
+	StatusSefaz             string `json:"status_sefaz,omitempty" example:"100"`
+	MensagemSefaz           string `json:"mensagem_sefaz,omitempty" example:"Autorizado o uso da NF-e"`
+	ChaveNfe                string `json:"chave_nfe,omitempty" example:"NFe41250110964044000164550010000000011000000016"`
+	Numero                  string `json:"numero,omitempty" example:"1"`
+	Serie                   string `json:"serie,omitempty" example:"1"`
+	CaminhoXMLNotaFiscal    string `json:"caminho_xml_nota_fiscal,omitempty"`
+	CaminhoDanfe            string `json:"caminho_danfe,omitempty"`
+	CaminhoXMLCancelamento  string `json:"caminho_xml_cancelamento,omitempty"`
+	CaminhoXMLCartaCorrecao string `json:"caminho_xml_carta_correcao,omitempty"`
+	CaminhoPDFCartaCorrecao string `json:"caminho_pdf_carta_correcao,omitempty"`
+	NumeroCartaCorrecao     *int   `json:"numero_carta_correcao,omitempty" example:"1"`
+}
+
+// NFeCancelRequest: DELETE /v2/nfe/REF
+type NFeCancelRequest struct {
+	Justificativa string `json:"justificativa" example:"Nota emitida com valor incorreto"`
+}
+
+// NFeCartaCorrecaoRequest: POST /v2/nfe/REF/carta_correcao
+type NFeCartaCorrecaoRequest struct {
+	Correcao string `json:"correcao" example:"Corrigido o endereço de entrega para Rua João da Silva, 153"`
+}
+
+// NFeInutilizacaoRequest: POST /v2/nfe/inutilizacao
+type NFeInutilizacaoRequest struct {
+	CNPJ          string `json:"cnpj" example:"10964044000164"`
+	Serie         string `json:"serie" example:"1"`
+	NumeroInicial string `json:"numero_inicial" example:"10"`
+	NumeroFinal   string `json:"numero_final" example:"12"`
+	Justificativa string `json:"justificativa" example:"Numeração pulada por falha no sistema emissor"`
+}
+
+// NFeInutilizacaoResponse representa o retorno da Focus para inutilização de numeração.
+type NFeInutilizacaoResponse struct {
+	Status        string `json:"status" example:"autorizado"`
+	StatusSefaz   string `json:"status_sefaz,omitempty" example:"102"`
+	MensagemSefaz string `json:"mensagem_sefaz,omitempty" example:"Inutilização de número homologado"`
+	Serie         string `json:"serie,omitempty" example:"1"`
+	NumeroInicial string `json:"numero_inicial,omitempty" example:"10"`
+	NumeroFinal   string `json:"numero_final,omitempty" example:"12"`
+	CaminhoXML    string `json:"caminho_xml,omitempty"`
+}
@@ -0,0 +1,81 @@
+package model
+
+// NFSeRequest representa o payload de emissão de NFS-e na Focus (POST /v2/nfse?ref=REF).
+// Documenta os campos principais; campos adicionais aceitos pela Focus são repassados sem alteração.
+// Campos numéricos aceitam número ou texto (ver Numero).
+type NFSeRequest struct {
+	DataEmissao            string        `json:"data_emissao" example:"2025-01-15T10:00:00-03:00"`
+	NaturezaOperacao       Numero        `json:"natureza_operacao,omitempty" swaggertype:"integer" example:"1"`
+	OptanteSimplesNacional *bool         `json:"optante_simples_nacional,omitempty" example:"true"`
+	Prestador              NFSePrestador `json:"prestador"`
+	Tomador                *NFSeTomador  `json:"tomador,omitempty"`
+	Servico                NFSeServico   `json:"servico"`
+}
+
+type NFSePrestador struct {
+	CNPJ               string `json:"cnpj" example:"10964044000164"`
+	InscricaoMunicipal string `json:"inscricao_municipal" example:"0046532"`
+	CodigoMunicipio    string `json:"codigo_municipio" example:"4106902"`
+}
+
+type NFSeTomador struct {
+	CNPJ        string        `json:"cnpj,omitempty" example:"07504505000132"`
+	CPF         string        `json:"cpf,omitempty" example:"12345678901"`
+	RazaoSocial string        `json:"razao_social,omitempty" example:"Acme Ltda"`
+	Email       string        `json:"email,omitempty" example:"financeiro@acme.com.br"`
+	Endereco    *NFSeEndereco `json:"endereco,omitempty"`
+}
+
+type NFSeEndereco struct {
+	Logradouro      string `json:"logradouro" example:"Rua João da Silva"`
+	Numero          string `json:"numero" example:"153"`
+	Complemento     string `json:"complemento,omitempty" example:"Sala 2"`
+	Bairro          string `json:"bairro" example:"Vila Isabel"`
+	CodigoMunicipio string `json:"codigo_municipio" example:"4106902"`
+	UF              string `json:"uf" example:"PR"`
+	CEP             string `json:"cep" example:"80210000"`
+}
+
+type NFSeServico struct {
+	ValorServicos             Numero `json:"valor_servicos" swaggertype:"number" example:"1500.00"`
+	Aliquota                  Numero `json:"aliquota,omitempty" swaggertype:"number" example:"2.5"`
+	IssRetido                 *bool  `json:"iss_retido,omitempty" example:"false"`
+	Discriminacao             string `json:"discriminacao" example:"Serviços de contabilidade - competência 01/2025"`
+	ItemListaServico          string `json:"item_lista_servico,omitempty" example:"17.19"`
+	CodigoTributarioMunicipio string `json:"codigo_tributario_municipio,omitempty" example:"171901"`
+	CodigoCnae                string `json:"codigo_cnae,omitempty" example:"6920601"`
+	CodigoMunicipio           string `json:"codigo_municipio,omitempty" example:"4106902"`
+}
+
+// NFSeResponse representa o retorno da Focus para emissão/consulta de NFS-e.
+type NFSeResponse struct {
+	CnpjPrestador        string               `json:"cnpj_prestador,omitempty" example:"10964044000164"`
+	Ref                  string               `json:"ref,omitempty" example:"nfse-2025-0001"`
+	NumeroRps            string               `json:"numero_rps,omitempty" example:"12"`
+	SerieRps             string               `json:"serie_rps,omitempty" example:"1"`
+	Status               string               `json:"status" example:"autorizado"`
+	Numero               string               `json:"numero,omitempty" example:"1024"`
+	CodigoVerificacao    string               `json:"codigo_verificacao,omitempty" example:"ABC123XYZ"`
+	DataEmissao          string               `json:"data_emissao,omitempty" example:"2025-01-15T10:00:00-03:00"`
+	URL                  string               `json:"url,omitempty"`
+	URLDanfse            string               `json:"url_danfse,omitempty"`
+	CaminhoXMLNotaFiscal string               `json:"caminho_xml_nota_fiscal,omitempty"`
+	Erros                []FocusDocumentoErro `json:"erros,omitempty"`
+}
+
+// FocusDocumentoErro é um erro de autorização devolvido pela prefeitura/SEFAZ via Focus.
+type FocusDocumentoErro struct {
+	Codigo   string `json:"codigo" example:"E10"`
+	Mensagem string `json:"mensagem" example:"RPS já informado"`
+	Correcao string `json:"correcao,omitempty"`
+}
+
+// NFSeCancelRequest: DELETE /v2/nfse/REF
+type NFSeCancelRequest struct {
+	Justificativa string `json:"justificativa" example:"Serviço não prestado; nota emitida em duplicidade"`
+}
+
+// NFSeEmailRequest: POST /v2/nfse/REF/email
+type NFSeEmailRequest struct {
+	Emails []string `json:"emails" example:"financeiro@acme.com.br"`
+}
@@ -0,0 +1,55 @@
+package model
+
+// NFSeNRequest representa a DPS (Declaração de Prestação de Serviço) da NFS-e Nacional
+// enviada à Focus em POST /v2/nfsen?ref=REF. Campos adicionais aceitos pela Focus são repassados sem alteração.
+// Campos numéricos aceitam número ou texto (ver Numero).
+type NFSeNRequest struct {
+	DataEmissao             string `json:"data_emissao" example:"2025-01-15T10:00:00-03:00"`
+	DataCompetencia         string `json:"data_competencia" example:"2025-01-15"`
+	CodigoMunicipioEmissora string `json:"codigo_municipio_emissora" example:"4106902"`
+
+	// Prestador
+	CnpjPrestador               string `json:"cnpj_prestador,omitempty" example:"10964044000164"`
+	CpfPrestador                string `json:"cpf_prestador,omitempty"`
+	InscricaoMunicipalPrestador string `json:"inscricao_municipal_prestador,omitempty" example:"0046532"`
+	CodigoOpcaoSimplesNacional  Numero `json:"codigo_opcao_simples_nacional,omitempty" swaggertype:"integer" example:"3"`
+	RegimeEspecialTributacao    Numero `json:"regime_especial_tributacao,omitempty" swaggertype:"integer" example:"0"`
+
+	// Tomador
+	CnpjTomador            string `json:"cnpj_tomador,omitempty" example:"07504505000132"`
+	CpfTomador             string `json:"cpf_tomador,omitempty"`
+	RazaoSocialTomador     string `json:"razao_social_tomador,omitempty" example:"Acme Ltda"`
+	EmailTomador           string `json:"email_tomador,omitempty" example:"financeiro@acme.com.br"`
+	CodigoMunicipioTomador string `json:"codigo_municipio_tomador,omitempty" example:"4106902"`
+	CepTomador             string `json:"cep_tomador,omitempty" example:"80210000"`
+	LogradouroTomador      string `json:"logradouro_tomador,omitempty" example:"Rua João da Silva"`
+	NumeroTomador          string `json:"numero_tomador,omitempty" example:"153"`
+	BairroTomador          string `json:"bairro_tomador,omitempty" example:"Vila Isabel"`
+
+	// Serviço
+	CodigoMunicipioPrestacao            string `json:"codigo_municipio_prestacao" example:"4106902"`
+	CodigoTributacaoNacionalIss         string `json:"codigo_tributacao_nacional_iss" example:"170201"`
+	CodigoTributacaoMunicipalIss        string `json:"codigo_tributacao_municipal_iss,omitempty" example:"001"`
+	DescricaoServico                    string `json:"descricao_servico" example:"Serviços de contabilidade - competência 01/2025"`
+	ValorServico                        Numero `json:"valor_servico" swaggertype:"number" example:"1500.00"`
+	TributacaoIss                       Numero `json:"tributacao_iss,omitempty" swaggertype:"integer" example:"1"`
+	TipoRetencaoIss                     Numero `json:"tipo_retencao_iss,omitempty" swaggertype:"integer" example:"1"`
+	PercentualAliquotaRelativaMunicipio Numero `json:"percentual_aliquota_relativa_municipio,omitempty" swaggertype:"number" example:"2.5"`
+}
+
+// NFSeNResponse representa o retorno da Focus para emissão/consulta de NFS-e Nacional.
+type NFSeNResponse struct {
+	CnpjPrestador        string               `json:"cnpj_prestador,omitempty" example:"10964044000164"`
+	Ref                  string               `json:"ref,omitempty" example:"nfsen-2025-0001"`
+	Status               string               `json:"status" example:"autorizado"`
+	NumeroDps            string               `json:"numero_dps,omitempty" example:"12"`
+	SerieDps             string               `json:"serie_dps,omitempty" example:"1"`
+	Numero               string               `json:"numero,omitempty" example:"1024"`
+	CodigoVerificacao    string               `json:"codigo_verificacao,omitempty"`
+	Chave                string               `json:"chave_nfse,omitempty"`
+	DataEmissao          string               `json:"data_emissao,omitempty" example:"2025-01-15T10:00:00-03:00"`
+	URL                  string               `json:"url,omitempty"`
+	URLDanfse            string               `json:"url_danfse,omitempty"`
+	CaminhoXMLNotaFiscal string               `json:"caminho_xml_nota_fiscal,omitempty"`
+	Erros                []FocusDocumentoErro `json:"erros,omitempty"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Numero é um campo numérico de payload de documento fiscal. A Focus aceita tanto número (1500.5) quanto
+// texto ("1500.50", "1"), então os dois formatos são aceitos aqui; o valor é guardado como recebido
+// (vazio = campo ausente ou null). O payload original é o que segue para a Focus: Numero só serve à validação.
+type Numero string
+
+func (n *Numero) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	switch {
+	case bytes.Equal(b, []byte("null")):
+		*n = ""
+	case len(b) > 0 && b[0] == '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*n = Numero(strings.TrimSpace(s))
+	case len(b) > 0 && (b[0] == '-' || (b[0] >= '0' && b[0] <= '9')):
+		*n = Numero(b)
+	default:
+		return fmt.Errorf("esperado número ou texto, recebido %s", b)
+	}
+	return nil
+}
+
+func (n Numero) MarshalJSON() ([]byte, error) {
+	if n == "" {
+		return []byte("null"), nil
+	}
+	if _, ok := n.Float(); ok {
+		return []byte(n), nil
+	}
+	return json.Marshal(string(n))
+}
+
+// Vazio indica campo ausente (ou null).
+func (n Numero) Vazio() bool {
+	return n == ""
+}
+
+// Float devolve o valor; ok=false se vazio ou não numérico.
+func (n Numero) Float() (float64, bool) {
+	if n == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(string(n), 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false
+	}
+	return v, true
+}
+
+// Int devolve o valor inteiro; ok=false se vazio, não numérico ou com casas decimais.
+func (n Numero) Int() (int, bool) {
+	v, ok := n.Float()
+	if !ok || v != math.Trunc(v) || math.Abs(v) > math.MaxInt32 {
+		return 0, false
+	}
+	return int(v), true
+}
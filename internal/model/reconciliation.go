@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// ReconciliationReport: resultado de uma reconciliação entre as empresas do Supabase (companies /
+// focus_integration) e as empresas cadastradas na Focus.
+type ReconciliationReport struct {
+	Environment   string                `json:"environment" example:"producao"`
+	StartedAt     time.Time             `json:"started_at"`
+	FinishedAt    time.Time             `json:"finished_at"`
+	AutoFix       bool                  `json:"auto_fix"`
+	FocusEmpresas int                   `json:"focus_empresas" example:"120"`
+	Companies     int                   `json:"companies" example:"118"`
+	Fixed         int                   `json:"fixed" example:"0"`
+	Issues        []ReconciliationIssue `json:"issues"`
+	// Error: motivo da interrupção (relatório parcial) ou de uma verificação que não pôde ser feita.
+	Error string `json:"error,omitempty"`
+}
+
+// ReconciliationIssue é uma divergência encontrada. Kind:
+//   - missing_link: a empresa existe na Focus com o CNPJ da empresa, mas focus_integration não aponta para ela
+//   - integrated_flag: focus_integration está correta, mas companies.focus_integrated = false
+//   - missing_in_focus: focus_integration aponta para uma empresa que não existe mais na Focus
+//   - orphaned_focus_empresa: empresa da Focus sem empresa correspondente no Supabase
+//   - token_mismatch: token gravado em focus_integration diferente do token da Focus
+//   - certificate_dates: validade do certificado diferente entre Supabase e Focus
+type ReconciliationIssue struct {
+	Kind           string `json:"kind" example:"missing_link"`
+	CompanyID      string `json:"company_id,omitempty"`
+	FocusCompanyID string `json:"focus_company_id,omitempty" example:"170571"`
+	CNPJ           string `json:"cnpj,omitempty" example:"61453926000127"`
+	Detail         string `json:"detail"`
+	Fixed          bool   `json:"fixed"`
+	FixError       string `json:"fix_error,omitempty"`
+}
@@ -0,0 +1,140 @@
+package model
+
+// CTeRequest representa o payload de emissão de CT-e na Focus (POST /v2/cte?ref=REF).
+// Documenta os campos principais; campos adicionais aceitos pela Focus (por modal) são repassados sem alteração.
+// Campos numéricos aceitam número ou texto (ver Numero).
+type CTeRequest struct {
+	Modal            string `json:"modal" example:"01"`
+	TipoDocumento    Numero `json:"tipo_documento" swaggertype:"integer" example:"0"`
+	TipoServico      Numero `json:"tipo_servico" swaggertype:"integer" example:"0"`
+	NaturezaOperacao string `json:"natureza_operacao" example:"Prestação de serviço de transporte"`
+	Cfop             string `json:"cfop" example:"5353"`
+	DataEmissao      string `json:"data_emissao" example:"2025-01-15T10:00:00-03:00"`
+	IndicadorTomador Numero `json:"indicador_tomador,omitempty" swaggertype:"integer" example:"0"`
+
+	CnpjEmitente string `json:"cnpj_emitente" example:"10964044000164"`
+
+	CodigoMunicipioEnvio  string `json:"codigo_municipio_envio" example:"4106902"`
+	CodigoMunicipioInicio string `json:"codigo_municipio_inicio" example:"4106902"`
+	UFInicio              string `json:"uf_inicio,omitempty" example:"PR"`
+	CodigoMunicipioFim    string `json:"codigo_municipio_fim" example:"3550308"`
+	UFFim                 string `json:"uf_fim,omitempty" example:"SP"`
+
+	CnpjRemetente    string `json:"cnpj_remetente,omitempty" example:"07504505000132"`
+	NomeRemetente    string `json:"nome_remetente,omitempty" example:"Acme Ltda"`
+	CnpjDestinatario string `json:"cnpj_destinatario,omitempty"`
+	CpfDestinatario  string `json:"cpf_destinatario,omitempty"`
+	NomeDestinatario string `json:"nome_destinatario,omitempty" example:"Maria da Silva"`
+
+	ValorTotal             Numero `json:"valor_total" swaggertype:"number" example:"350.00"`
+	ValorReceber           Numero `json:"valor_receber,omitempty" swaggertype:"number" example:"350.00"`
+	IcmsSituacaoTributaria string `json:"icms_situacao_tributaria,omitempty" example:"00"`
+	ValorTotalCarga        Numero `json:"valor_total_carga,omitempty" swaggertype:"number" example:"12000.00"`
+	ProdutoPredominante    string `json:"produto_predominante,omitempty" example:"Materiais de escritório"`
+
+	// Chaves das NF-e transportadas
+	NfesChaves []CTeDocumentoNFe `json:"nfes,omitempty"`
+}
+
+type CTeDocumentoNFe struct {
+	ChaveNfe string `json:"chave_nfe" example:"41250110964044000164550010000000011000000016"`
+}
+
+// CTeResponse representa o retorno da Focus para emissão/consulta/cancelamento de CT-e.
+type CTeResponse struct {
+	CnpjEmitente           string `json:"cnpj_emitente,omitempty" example:"10964044000164"`
+	Ref                    string `json:"ref,omitempty" example:"cte-2025-0001"`
+	Status                 string `json:"status" example:"autorizado"`
+	StatusSefaz            string `json:"status_sefaz,omitempty" example:"100"`
+	MensagemSefaz          string `json:"mensagem_sefaz,omitempty" example:"Autorizado o uso do CT-e"`
+	Chave                  string `json:"chave,omitempty"`
+	Numero                 string `json:"numero,omitempty" example:"1"`
+	Serie                  string `json:"serie,omitempty" example:"1"`
+	CaminhoXMLNotaFiscal   string `json:"caminho_xml_nota_fiscal,omitempty"`
+	CaminhoDacte           string `json:"caminho_dacte,omitempty"`
+	CaminhoXMLCancelamento string `json:"caminho_xml_cancelamento,omitempty"`
+}
+
+// MDFeRequest representa o payload de emissão de MDF-e na Focus (POST /v2/mdfe?ref=REF), modal rodoviário.
+// Documenta os campos principais; campos adicionais aceitos pela Focus são repassados sem alteração.
+// Campos numéricos aceitam número ou texto (ver Numero).
+type MDFeRequest struct {
+	TipoEmitente Numero `json:"tipo_emitente" swaggertype:"integer" example:"2"`
+	CnpjEmitente string `json:"cnpj_emitente" example:"10964044000164"`
+	Modal        string `json:"modal,omitempty" example:"1"`
+	DataEmissao  string `json:"data_emissao" example:"2025-01-15T10:00:00-03:00"`
+	UFInicio     string `json:"uf_inicio" example:"PR"`
+	UFFim        string `json:"uf_fim" example:"SP"`
+
+	MunicipiosCarregamento    []MDFeMunicipio         `json:"municipios_carregamento"`
+	MunicipiosDescarregamento []MDFeMunicipioDescarga `json:"municipios_descarregamento"`
+	Percursos                 []MDFePercurso          `json:"percursos,omitempty"`
+
+	ValorTotalCarga              Numero `json:"valor_total_carga" swaggertype:"number" example:"12000.00"`
+	CodigoUnidadeMedidaPesoBruto string `json:"codigo_unidade_medida_peso_bruto" example:"01"`
+	PesoBruto                    Numero `json:"peso_bruto" swaggertype:"number" example:"850.5"`
+
+	ModalRodoviario *MDFeModalRodoviario `json:"modal_rodoviario,omitempty"`
+}
+
+type MDFeMunicipio struct {
+	Codigo string `json:"codigo" example:"4106902"`
+	Nome   string `json:"nome" example:"Curitiba"`
+}
+
+type MDFeMunicipioDescarga struct {
+	Codigo string             `json:"codigo" example:"3550308"`
+	Nome   string             `json:"nome" example:"São Paulo"`
+	Nfes   []CTeDocumentoNFe  `json:"nfes,omitempty"`
+	Ctes   []MDFeDocumentoCTe `json:"ctes,omitempty"`
+}
+
+type MDFeDocumentoCTe struct {
+	ChaveCte string `json:"chave_cte" example:"41250110964044000164570010000000011000000016"`
+}
+
+type MDFePercurso struct {
+	UF string `json:"uf" example:"SC"`
+}
+
+type MDFeModalRodoviario struct {
+	VeiculoTracao MDFeVeiculo `json:"veiculo_tracao"`
+}
+
+type MDFeVeiculo struct {
+	Placa           string         `json:"placa" example:"ABC1D23"`
+	Tara            Numero         `json:"tara" swaggertype:"integer" example:"8000"`
+	TipoRodado      string         `json:"tipo_rodado" example:"03"`
+	TipoCarroceria  string         `json:"tipo_carroceria" example:"02"`
+	UFLicenciamento string         `json:"uf_licenciamento" example:"PR"`
+	Condutores      []MDFeCondutor `json:"condutores"`
+}
+
+// MDFeCondutor também é o payload de POST /v2/mdfe/REF/inclusao_condutor.
+type MDFeCondutor struct {
+	Nome string `json:"nome" example:"João da Silva"`
+	CPF  string `json:"cpf" example:"12345678901"`
+}
+
+// MDFeEncerramentoRequest: POST /v2/mdfe/REF/encerrar
+type MDFeEncerramentoRequest struct {
+	Data          string `json:"data" example:"2025-01-16"`
+	SiglaUF       string `json:"sigla_uf" example:"SP"`
+	NomeMunicipio string `json:"nome_municipio" example:"São Paulo"`
+}
+
+// MDFeResponse representa o retorno da Focus para as operações de MDF-e.
+type MDFeResponse struct {
+	CnpjEmitente           string `json:"cnpj_emitente,omitempty" example:"10964044000164"`
+	Ref                    string `json:"ref,omitempty" example:"mdfe-2025-0001"`
+	Status                 string `json:"status" example:"autorizado"`
+	StatusSefaz            string `json:"status_sefaz,omitempty" example:"100"`
+	MensagemSefaz          string `json:"mensagem_sefaz,omitempty" example:"Autorizado o uso do MDF-e"`
+	Chave                  string `json:"chave,omitempty"`
+	Numero                 string `json:"numero,omitempty" example:"1"`
+	Serie                  string `json:"serie,omitempty" example:"1"`
+	CaminhoXMLNotaFiscal   string `json:"caminho_xml_nota_fiscal,omitempty"`
+	CaminhoDamdfe          string `json:"caminho_damdfe,omitempty"`
+	CaminhoXMLCancelamento string `json:"caminho_xml_cancelamento,omitempty"`
+	CaminhoXMLEncerramento string `json:"caminho_xml_encerramento,omitempty"`
+}
@@ -0,0 +1,294 @@
+// Package outbox garante as gravações no Supabase que dependem de uma operação já concluída na Focus.
+//
+// Depois que a Focus cadastra uma empresa, o serviço precisa salvar focus_integration, marcar
+// companies.focus_integrated, atualizar as datas do certificado e limpar os erros antigos. Se o Supabase
+// estiver fora nesse momento, a empresa ficaria integrada na Focus e pendente aqui para sempre.
+// Os passos de uma operação são gravados em focus_integration_outbox, encadeados e reservados, antes de
+// serem executados; o que falha (e o que vem depois dele) é refeito pelo worker (Run), na mesma ordem,
+// com backoff até dar certo. Os passos são idempotentes.
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/supabase"
+)
+
+// Step identifica um passo (coluna step de focus_integration_outbox).
+type Step string
+
+const (
+	StepInsertFocusIntegration  Step = "insert_focus_integration"
+	StepMarkFocusIntegrated     Step = "mark_focus_integrated"
+	StepUpdateCertificateDates  Step = "update_certificate_dates"
+	StepDeleteCertificateErrors Step = "delete_certificate_errors"
+)
+
+const (
+	// Passos processados por varredura.
+	batchSize = 50
+	// Intervalo até a primeira retentativa; dobra a cada falha até maxBackoff.
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 30 * time.Minute
+	// Reserva de um passo em execução; vencida, outra instância (ou a próxima varredura) o retoma.
+	leaseDuration = 2 * time.Minute
+)
+
+// Outbox grava e executa os passos. O mesmo Outbox é usado pelos handlers (Dispatch) e pelo worker (Run).
+type Outbox struct {
+	interval time.Duration
+}
+
+// New cria o outbox. interval é o intervalo entre varreduras do worker.
+func New(interval time.Duration) *Outbox {
+	return &Outbox{interval: interval}
+}
+
+// Job é um passo a executar para uma empresa.
+type Job struct {
+	Step    Step
+	payload any
+}
+
+type focusIntegrationPayload struct {
+	FocusCompanyID string `json:"focus_company_id"`
+	Token          string `json:"token_focus_company"`
+	Environment    string `json:"environment,omitempty"`
+}
+
+type certificateDatesPayload struct {
+	EffectiveDate  *time.Time `json:"effective_date"`
+	ExpirationDate *time.Time `json:"expiration_date"`
+}
+
+type certificateErrorsPayload struct {
+	CertificateID string `json:"certificate_id"`
+}
+
+// InsertFocusIntegration grava a empresa da Focus em focus_integration, substituindo a integração anterior da empresa.
+func InsertFocusIntegration(focusCompanyID, token, environment string) Job {
+	return Job{Step: StepInsertFocusIntegration, payload: focusIntegrationPayload{focusCompanyID, token, environment}}
+}
+
+// MarkFocusIntegrated marca companies.focus_integrated = true.
+func MarkFocusIntegrated() Job {
+	return Job{Step: StepMarkFocusIntegrated, payload: struct{}{}}
+}
+
+// UpdateCertificateDates grava as datas de validade do certificado (nil limpa a data).
+func UpdateCertificateDates(effectiveDate, expirationDate *time.Time) Job {
+	return Job{Step: StepUpdateCertificateDates, payload: certificateDatesPayload{effectiveDate, expirationDate}}
+}
+
+// DeleteCertificateErrors remove de focus_integration_errors os erros antigos do certificado.
+func DeleteCertificateErrors(certificateID string) Job {
+	return Job{Step: StepDeleteCertificateErrors, payload: certificateErrorsPayload{certificateID}}
+}
+
+// Dispatch grava os passos da empresa em focus_integration_outbox, já reservados por esta chamada e
+// encadeados (cada passo depende do anterior), e os executa em seguida, na ordem. Um passo que falha
+// interrompe a cadeia: ele e os seguintes ficam pendentes e o worker os refaz na mesma ordem.
+// pending traz esses passos, com o erro que os deixou pendentes.
+// Se os passos não puderem ser gravados, são executados direto; se algum falhar, nada fica registrado
+// para o worker e err != nil: quem chamou deve falhar a requisição para que ela seja repetida.
+func (o *Outbox) Dispatch(companyID string, jobs ...Job) (pending map[Step]error, err error) {
+	now := time.Now().UTC()
+	lockToken := newID()
+	lockedUntil := now.Add(leaseDuration)
+	rows := make([]supabase.OutboxJob, 0, len(jobs))
+	previous := ""
+	for _, job := range jobs {
+		payload, err := json.Marshal(job.payload)
+		if err != nil {
+			payload = []byte("{}")
+		}
+		row := supabase.OutboxJob{
+			ID:            newID(),
+			CompanyID:     companyID,
+			Step:          string(job.Step),
+			Payload:       payload,
+			Status:        supabase.OutboxRunning,
+			DependsOn:     previous,
+			LockToken:     lockToken,
+			LockedUntil:   &lockedUntil,
+			NextAttemptAt: now,
+		}
+		previous = row.ID
+		rows = append(rows, row)
+	}
+
+	if _, err := supabase.InsertOutboxJobs(rows); err != nil {
+		log.Printf("[outbox] erro ao registrar passos (company_id=%s); executando sem registro: %v", companyID, err)
+		for _, row := range rows {
+			if err := run(row); err != nil {
+				return nil, fmt.Errorf("passo %s falhou e não pôde ser registrado no outbox: %w", row.Step, err)
+			}
+		}
+		return nil, nil
+	}
+
+	pending = map[Step]error{}
+	var blocked error
+	for _, row := range rows {
+		if blocked != nil {
+			pending[Step(row.Step)] = blocked
+			continue
+		}
+		if err := o.execute(row); err != nil {
+			pending[Step(row.Step)] = err
+			blocked = fmt.Errorf("aguardando o passo %s: %w", row.Step, err)
+		}
+	}
+	if blocked != nil {
+		// os passos seguintes ainda estão reservados por esta chamada: libera para o worker
+		if err := supabase.ReleaseOutboxJobs(lockToken); err != nil {
+			log.Printf("[outbox] erro ao liberar passos (company_id=%s); ficam com o worker quando a reserva vencer: %v", companyID, err)
+		}
+	}
+	return pending, nil
+}
+
+// Run refaz os passos pendentes a cada intervalo, até ctx ser cancelado.
+func (o *Outbox) Run(ctx context.Context) {
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := o.RunOnce(ctx); err != nil {
+			log.Printf("[outbox] erro ao listar passos pendentes: %v", err)
+		} else if n > 0 {
+			log.Printf("[outbox] %d passo(s) pendente(s) concluído(s)", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce executa um lote de passos vencidos e devolve quantos foram concluídos. Cada passo é reservado
+// antes de rodar (outra réplica não o executa junto) e só roda depois do passo de que depende.
+func (o *Outbox) RunOnce(ctx context.Context) (int, error) {
+	if n, err := supabase.ReleaseExpiredOutboxJobs(); err != nil {
+		log.Printf("[outbox] erro ao liberar reservas vencidas: %v", err)
+	} else if n > 0 {
+		log.Printf("[outbox] %d passo(s) com reserva vencida de volta para pendente", n)
+	}
+
+	jobs, err := supabase.ListDueOutboxJobs(batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		if job.DependsOn != "" {
+			status, err := supabase.GetOutboxJobStatus(job.DependsOn)
+			if err != nil {
+				log.Printf("[outbox] erro ao consultar o passo anterior de %s (id=%s): %v", job.Step, job.ID, err)
+				continue
+			}
+			if status != "" && status != supabase.OutboxDone {
+				continue
+			}
+		}
+
+		job.LockToken = newID()
+		claimed, err := supabase.ClaimOutboxJob(job.ID, job.LockToken, time.Now().Add(leaseDuration))
+		if err != nil {
+			log.Printf("[outbox] erro ao reservar passo %s (id=%s): %v", job.Step, job.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if o.execute(job) == nil {
+			done++
+		}
+	}
+	return done, nil
+}
+
+// execute roda o passo reservado e registra o resultado: concluído ou de volta a pendente, com backoff.
+func (o *Outbox) execute(job supabase.OutboxJob) error {
+	err := run(job)
+	if err == nil {
+		if markErr := supabase.MarkOutboxJobDone(job.ID, job.LockToken); markErr != nil {
+			// o passo é refeito quando a reserva vencer, sem efeito (idempotente)
+			log.Printf("[outbox] erro ao concluir passo %s (id=%s): %v", job.Step, job.ID, markErr)
+		}
+		return nil
+	}
+
+	job.Attempts++
+	job.LastError = err.Error()
+	job.NextAttemptAt = time.Now().UTC().Add(backoff(job.Attempts))
+	log.Printf("[outbox] passo %s falhou (company_id=%s, tentativa %d, próxima em %s): %v",
+		job.Step, job.CompanyID, job.Attempts, job.NextAttemptAt.Format(time.RFC3339), err)
+
+	if markErr := supabase.RescheduleOutboxJob(job.ID, job.LockToken, job.Attempts, job.LastError, job.NextAttemptAt); markErr != nil {
+		log.Printf("[outbox] erro ao reagendar passo %s (id=%s): %v", job.Step, job.ID, markErr)
+	}
+	return err
+}
+
+// newID gera um UUID v4: os ids dos passos saem daqui para encadear a operação num único insert.
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func backoff(attempts int) time.Duration {
+	d := baseBackoff
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// run executa o passo no Supabase.
+func run(job supabase.OutboxJob) error {
+	switch Step(job.Step) {
+	case StepInsertFocusIntegration:
+		var p focusIntegrationPayload
+		if err := json.Unmarshal(job.Payload, &p); err != nil {
+			return fmt.Errorf("payload inválido: %w", err)
+		}
+		// upsert por company_id: repetir o passo (ou concorrer com outra réplica) não cria outra linha
+		return supabase.UpsertFocusIntegration(job.CompanyID, p.FocusCompanyID, p.Token, p.Environment)
+
+	case StepMarkFocusIntegrated:
+		return supabase.UpdateCompanyFocusIntegrated(job.CompanyID, true)
+
+	case StepUpdateCertificateDates:
+		var p certificateDatesPayload
+		if err := json.Unmarshal(job.Payload, &p); err != nil {
+			return fmt.Errorf("payload inválido: %w", err)
+		}
+		return supabase.UpdateCertificateDatesForCompany(job.CompanyID, p.EffectiveDate, p.ExpirationDate)
+
+	case StepDeleteCertificateErrors:
+		var p certificateErrorsPayload
+		if err := json.Unmarshal(job.Payload, &p); err != nil {
+			return fmt.Errorf("payload inválido: %w", err)
+		}
+		return supabase.DeleteFocusIntegrationErrorsByCertificate(job.CompanyID, p.CertificateID)
+	}
+	return fmt.Errorf("passo desconhecido: %s", job.Step)
+}
@@ -0,0 +1,127 @@
+package outbox_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/outbox"
+	"github.com/seuuser/focus-integration-service/internal/supabase"
+	"github.com/seuuser/focus-integration-service/internal/supabase/supabasetest"
+)
+
+func startDB(t *testing.T) *supabasetest.Server {
+	t.Helper()
+	db := supabasetest.Start(t)
+	db.Unique("focus_integration", "company_id")
+	db.Insert("companies", supabasetest.Row{"id": "c1", "focus_integrated": false})
+	return db
+}
+
+// due antecipa o próximo horário de tentativa dos passos pendentes (em vez de esperar o backoff).
+func due(db *supabasetest.Server) {
+	db.Update("focus_integration_outbox", func(r supabasetest.Row) bool { return r["status"] == supabase.OutboxPending },
+		supabasetest.Row{"next_attempt_at": time.Now().Add(-time.Minute).UTC().Format(time.RFC3339Nano)})
+}
+
+func statuses(db *supabasetest.Server) []any {
+	var out []any
+	for _, row := range db.Rows("focus_integration_outbox") {
+		out = append(out, row["status"])
+	}
+	return out
+}
+
+func TestDispatchStopsTheChainAtTheFailedStep(t *testing.T) {
+	db := startDB(t)
+	db.FailNext("POST", "focus_integration", 1)
+	ob := outbox.New(0)
+
+	pending, err := ob.Dispatch("c1", outbox.InsertFocusIntegration("100", "token-c1", "producao"), outbox.MarkFocusIntegrated())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending[outbox.StepInsertFocusIntegration] == nil || pending[outbox.StepMarkFocusIntegrated] == nil {
+		t.Fatalf("os dois passos deveriam ficar pendentes: %v", pending)
+	}
+	if db.Rows("companies")[0]["focus_integrated"] != false {
+		t.Fatal("a empresa não deveria ser marcada integrada antes de focus_integration ser gravada")
+	}
+	if got := statuses(db); len(got) != 2 || got[0] != supabase.OutboxPending || got[1] != supabase.OutboxPending {
+		t.Fatalf("os passos deveriam voltar para pending: %v", got)
+	}
+
+	// o segundo passo já está vencido, mas depende do primeiro
+	if n, err := ob.RunOnce(context.Background()); err != nil || n != 0 {
+		t.Fatalf("nenhum passo deveria rodar antes do primeiro vencer: n=%d err=%v", n, err)
+	}
+
+	due(db)
+	if n, err := ob.RunOnce(context.Background()); err != nil || n != 2 {
+		t.Fatalf("esperava os dois passos concluídos, n=%d err=%v", n, err)
+	}
+	if rows := db.Rows("focus_integration"); len(rows) != 1 || rows[0]["focus_company_id"] != "100" {
+		t.Fatalf("focus_integration deveria ser gravada: %v", rows)
+	}
+	if db.Rows("companies")[0]["focus_integrated"] != true {
+		t.Fatal("a empresa deveria ser marcada integrada")
+	}
+}
+
+func TestRunOnceSkipsJobsClaimedByAnotherInstance(t *testing.T) {
+	db := startDB(t)
+	db.Insert("focus_integration_outbox", supabasetest.Row{
+		"company_id":      "c1",
+		"step":            string(outbox.StepMarkFocusIntegrated),
+		"payload":         map[string]any{},
+		"status":          supabase.OutboxRunning,
+		"lock_token":      "outra-instancia",
+		"locked_until":    time.Now().Add(time.Minute).UTC().Format(time.RFC3339Nano),
+		"attempts":        0,
+		"next_attempt_at": time.Now().Add(-time.Minute).UTC().Format(time.RFC3339Nano),
+	})
+	ob := outbox.New(0)
+
+	if n, _ := ob.RunOnce(context.Background()); n != 0 || db.Rows("companies")[0]["focus_integrated"] != false {
+		t.Fatal("um passo reservado por outra instância não deveria rodar")
+	}
+
+	// a outra instância caiu: a reserva vence e o passo é retomado
+	db.Update("focus_integration_outbox", func(supabasetest.Row) bool { return true },
+		supabasetest.Row{"locked_until": time.Now().Add(-time.Second).UTC().Format(time.RFC3339Nano)})
+	if n, err := ob.RunOnce(context.Background()); err != nil || n != 1 {
+		t.Fatalf("o passo com reserva vencida deveria ser retomado, n=%d err=%v", n, err)
+	}
+	if got := statuses(db); got[0] != supabase.OutboxDone {
+		t.Fatalf("o passo deveria estar concluído: %v", got)
+	}
+}
+
+func TestDispatchWithoutOutbox(t *testing.T) {
+	t.Run("passos concluídos", func(t *testing.T) {
+		db := startDB(t)
+		db.FailNext("POST", "focus_integration_outbox", 1)
+
+		pending, err := outbox.New(0).Dispatch("c1", outbox.InsertFocusIntegration("100", "token-c1", "producao"), outbox.MarkFocusIntegrated())
+		if err != nil || len(pending) != 0 {
+			t.Fatalf("os passos deveriam rodar direto: pending=%v err=%v", pending, err)
+		}
+		if len(db.Rows("focus_integration")) != 1 || db.Rows("companies")[0]["focus_integrated"] != true {
+			t.Fatal("os passos deveriam ser aplicados")
+		}
+	})
+
+	t.Run("passo falhou", func(t *testing.T) {
+		db := startDB(t)
+		db.FailNext("POST", "focus_integration_outbox", 1)
+		db.FailNext("POST", "focus_integration", 1)
+
+		_, err := outbox.New(0).Dispatch("c1", outbox.InsertFocusIntegration("100", "token-c1", "producao"), outbox.MarkFocusIntegrated())
+		if err == nil {
+			t.Fatal("sem outbox, a falha deveria ser devolvida para a requisição falhar")
+		}
+		if db.Rows("companies")[0]["focus_integrated"] != false {
+			t.Fatal("os passos seguintes não deveriam rodar")
+		}
+	})
+}
@@ -0,0 +1,345 @@
+// Package reconcile compara as empresas do Supabase (companies / focus_integration) com as empresas
+// cadastradas na Focus e aponta as divergências.
+//
+// Nada impede que os dois lados se afastem: empresas são excluídas ou criadas direto no painel da Focus,
+// tokens são regenerados, certificados são trocados fora do serviço. O Reconciler percorre todas as
+// empresas da Focus (GET /v2/empresas), casa cada uma pelo CNPJ/CPF com companies e produz um relatório.
+// Com auto-fix ligado, corrige o que é seguro corrigir do lado do Supabase; a Focus nunca é alterada.
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/model"
+	"github.com/seuuser/focus-integration-service/internal/outbox"
+	"github.com/seuuser/focus-integration-service/internal/supabase"
+)
+
+// Tipos de divergência (model.ReconciliationIssue.Kind).
+const (
+	KindMissingLink          = "missing_link"
+	KindIntegratedFlag       = "integrated_flag"
+	KindMissingInFocus       = "missing_in_focus"
+	KindOrphanedFocusEmpresa = "orphaned_focus_empresa"
+	KindTokenMismatch        = "token_mismatch"
+	KindCertificateDates     = "certificate_dates"
+)
+
+// Diferença tolerada entre as validades do certificado: o Supabase guarda timestamp sem fuso.
+const certificateTolerance = 24 * time.Hour
+
+// ErrRunning: já existe uma reconciliação em andamento.
+var ErrRunning = errors.New("reconciliação já em andamento")
+
+type Reconciler struct {
+	focus    *focus.Client
+	outbox   *outbox.Outbox
+	env      focus.Environment
+	interval time.Duration
+	autoFix  bool
+
+	running sync.Mutex
+
+	mu   sync.RWMutex
+	last *model.ReconciliationReport
+}
+
+// NewReconciler cria o reconciliador do ambiente env. autoFix vale para as execuções agendadas (Run).
+func NewReconciler(focusClient *focus.Client, ob *outbox.Outbox, env focus.Environment, interval time.Duration, autoFix bool) *Reconciler {
+	return &Reconciler{focus: focusClient, outbox: ob, env: env, interval: interval, autoFix: autoFix}
+}
+
+// Run reconcilia a cada intervalo até ctx ser cancelado. A primeira execução acontece após o primeiro
+// intervalo, para não percorrer a Focus inteira a cada deploy.
+func (r *Reconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := r.RunOnce(ctx, r.autoFix); err != nil && !errors.Is(err, ErrRunning) {
+			log.Printf("[reconcile] %v", err)
+		}
+	}
+}
+
+// Last devolve o relatório da última reconciliação (nil se nenhuma rodou ainda).
+func (r *Reconciler) Last() *model.ReconciliationReport {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.last
+}
+
+// RunOnce executa uma reconciliação completa. O relatório é devolvido (e guardado em Last) mesmo quando
+// a execução é interrompida por um erro: nesse caso ele é parcial e report.Error traz o motivo.
+func (r *Reconciler) RunOnce(ctx context.Context, autoFix bool) (*model.ReconciliationReport, error) {
+	if !r.running.TryLock() {
+		return nil, ErrRunning
+	}
+	defer r.running.Unlock()
+
+	report := &model.ReconciliationReport{
+		Environment: string(r.env),
+		StartedAt:   time.Now().UTC(),
+		AutoFix:     autoFix,
+		Issues:      []model.ReconciliationIssue{},
+	}
+	err := r.reconcile(ctx, report)
+	report.FinishedAt = time.Now().UTC()
+	if err != nil {
+		if report.Error != "" {
+			report.Error = err.Error() + "; " + report.Error
+		} else {
+			report.Error = err.Error()
+		}
+	}
+
+	r.mu.Lock()
+	r.last = report
+	r.mu.Unlock()
+
+	log.Printf("[reconcile] %s: %d empresa(s) na Focus, %d no Supabase, %d divergência(s), %d corrigida(s)",
+		report.Environment, report.FocusEmpresas, report.Companies, len(report.Issues), report.Fixed)
+	if err != nil {
+		return report, fmt.Errorf("reconciliação interrompida: %w", err)
+	}
+	return report, nil
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, report *model.ReconciliationReport) error {
+	companies, err := supabase.ListCompaniesFocusStatus()
+	if err != nil {
+		return fmt.Errorf("companies: %w", err)
+	}
+	links, err := supabase.ListFocusIntegrations()
+	if err != nil {
+		return fmt.Errorf("focus_integration: %w", err)
+	}
+	report.Companies = len(companies)
+
+	companiesByID := make(map[string]*supabase.CompanyFocusStatus, len(companies))
+	companiesByDoc := make(map[string]*supabase.CompanyFocusStatus, len(companies))
+	for i := range companies {
+		companiesByID[companies[i].ID] = &companies[i]
+		if companies[i].CNPJ != "" {
+			companiesByDoc[companies[i].CNPJ] = &companies[i]
+		}
+	}
+	linksByCompany := make(map[string]*supabase.FocusIntegration, len(links))
+	companyByFocusID := map[string]string{}
+	for i := range links {
+		linksByCompany[links[i].CompanyID] = &links[i]
+		if links[i].Environment == string(r.env) {
+			companyByFocusID[links[i].FocusCompanyID] = links[i].CompanyID
+		}
+	}
+
+	// Certificados: sem a RPC instalada, a verificação das datas é pulada (e registrada no relatório).
+	var certs map[string]supabase.CertificateDates
+	if list, err := supabase.ListCertificateDates(); err != nil {
+		report.Error = "datas de certificado não verificadas: " + err.Error()
+	} else {
+		certs = make(map[string]supabase.CertificateDates, len(list))
+		for _, cd := range list {
+			certs[cd.CompanyID] = cd
+		}
+	}
+
+	// Empresas da Focus: casa cada uma com companies pelo CNPJ/CPF.
+	inFocus := map[string]bool{}
+	matched := map[string]bool{}
+	for e, err := range r.focus.AllEmpresas(focus.WithEnvironment(ctx, r.env), "") {
+		if err != nil {
+			return fmt.Errorf("empresas da Focus: %w", err)
+		}
+		report.FocusEmpresas++
+
+		focusID := strconv.Itoa(e.ID)
+		inFocus[focusID] = true
+
+		doc := e.CNPJ
+		if doc == "" && e.CPF != nil {
+			doc = *e.CPF
+		}
+		company := companiesByDoc[doc]
+		if company == nil {
+			// CNPJ ausente ou diferente em companies, mas a integração aponta para esta empresa
+			company = companiesByID[companyByFocusID[focusID]]
+		}
+		if company == nil {
+			report.Issues = append(report.Issues, model.ReconciliationIssue{
+				Kind:           KindOrphanedFocusEmpresa,
+				FocusCompanyID: focusID,
+				CNPJ:           doc,
+				Detail:         fmt.Sprintf("empresa %q da Focus sem empresa correspondente no Supabase", e.Nome),
+			})
+			continue
+		}
+		matched[company.ID] = true
+		r.checkEmpresa(report, e, focusID, company, linksByCompany[company.ID], certs)
+	}
+
+	// Integrações que apontam para empresas que não existem mais na Focus.
+	for _, company := range companies {
+		if matched[company.ID] {
+			continue
+		}
+		link := linksByCompany[company.ID]
+		if link != nil && (link.Environment != string(r.env) || inFocus[link.FocusCompanyID]) {
+			// outro ambiente, ou empresa da Focus com outro CNPJ (já aparece como missing_link da dona do CNPJ)
+			continue
+		}
+		if link == nil && !company.FocusIntegrated {
+			continue
+		}
+
+		issue := model.ReconciliationIssue{Kind: KindMissingInFocus, CompanyID: company.ID, CNPJ: company.CNPJ}
+		if link != nil {
+			issue.FocusCompanyID = link.FocusCompanyID
+			issue.Detail = fmt.Sprintf("focus_integration aponta para a empresa %s, que não existe na Focus", link.FocusCompanyID)
+		} else {
+			issue.Detail = "companies.focus_integrated = true sem focus_integration e sem empresa na Focus"
+		}
+		if report.AutoFix && company.FocusIntegrated {
+			r.fix(report, &issue, func() error { return supabase.UpdateCompanyFocusIntegrated(company.ID, false) })
+		}
+		report.Issues = append(report.Issues, issue)
+	}
+	return nil
+}
+
+// checkEmpresa confere integração, flag, token e certificado de uma empresa encontrada nos dois lados.
+func (r *Reconciler) checkEmpresa(report *model.ReconciliationReport, e model.FocusEmpresaResponse, focusID string, company *supabase.CompanyFocusStatus, link *supabase.FocusIntegration, certs map[string]supabase.CertificateDates) {
+	token := e.TokenProducao
+	if r.env == focus.EnvHomologacao {
+		token = e.TokenHomologacao
+	}
+	base := model.ReconciliationIssue{CompanyID: company.ID, FocusCompanyID: focusID, CNPJ: company.CNPJ}
+
+	if link == nil || link.FocusCompanyID != focusID || link.Environment != string(r.env) {
+		issue := base
+		issue.Kind = KindMissingLink
+		issue.Detail = "empresa existe na Focus, mas não há focus_integration"
+		if link != nil {
+			issue.Detail = fmt.Sprintf("focus_integration aponta para a empresa %s (%s), mas a empresa do CNPJ na Focus é %s",
+				link.FocusCompanyID, link.Environment, focusID)
+		}
+		if report.AutoFix {
+			switch {
+			case link != nil && link.Environment != string(r.env):
+				// corrigir moveria a empresa de ambiente (ex: homologação -> produção): só o relatório
+				issue.FixError = fmt.Sprintf("empresa integrada em %s; a correção automática não troca o ambiente", link.Environment)
+			case token == "":
+				issue.FixError = "token da empresa não retornado pela Focus"
+			case link != nil:
+				r.fix(report, &issue, func() error {
+					if err := supabase.UpdateFocusIntegrationCompany(link.ID, focusID, token); err != nil {
+						return err
+					}
+					return dispatch(r.outbox, company.ID, outbox.MarkFocusIntegrated())
+				})
+			default:
+				r.fix(report, &issue, func() error {
+					return dispatch(r.outbox, company.ID, outbox.InsertFocusIntegration(focusID, token, string(r.env)), outbox.MarkFocusIntegrated())
+				})
+			}
+		}
+		report.Issues = append(report.Issues, issue)
+		return
+	}
+
+	if !company.FocusIntegrated {
+		issue := base
+		issue.Kind = KindIntegratedFlag
+		issue.Detail = "focus_integration existe, mas companies.focus_integrated = false"
+		if report.AutoFix {
+			r.fix(report, &issue, func() error { return dispatch(r.outbox, company.ID, outbox.MarkFocusIntegrated()) })
+		}
+		report.Issues = append(report.Issues, issue)
+	}
+
+	if token != "" && link.TokenFocusCompany != token {
+		issue := base
+		issue.Kind = KindTokenMismatch
+		issue.Detail = "token de focus_integration diferente do token da empresa na Focus"
+		if report.AutoFix {
+			r.fix(report, &issue, func() error { return supabase.UpdateFocusIntegrationToken(link.ID, token) })
+		}
+		report.Issues = append(report.Issues, issue)
+	}
+
+	if certs == nil || e.CertificadoValidoAte == "" {
+		return
+	}
+	focusExp, err := time.Parse(time.RFC3339, e.CertificadoValidoAte)
+	if err != nil {
+		return
+	}
+	local := certs[company.ID].ExpirationDate
+	if local != nil && absDuration(local.Sub(focusExp)) <= certificateTolerance {
+		return
+	}
+
+	issue := base
+	issue.Kind = KindCertificateDates
+	issue.Detail = fmt.Sprintf("validade do certificado na Focus %s, no Supabase sem data", focusExp.Format("2006-01-02"))
+	if local != nil {
+		issue.Detail = fmt.Sprintf("validade do certificado na Focus %s, no Supabase %s", focusExp.Format("2006-01-02"), local.Format("2006-01-02"))
+	}
+	if report.AutoFix {
+		var focusEff *time.Time
+		if t, err := time.Parse(time.RFC3339, e.CertificadoValidoDe); err == nil {
+			focusEff = &t
+		}
+		r.fix(report, &issue, func() error {
+			return dispatch(r.outbox, company.ID, outbox.UpdateCertificateDates(focusEff, &focusExp))
+		})
+	}
+	report.Issues = append(report.Issues, issue)
+}
+
+func (r *Reconciler) fix(report *model.ReconciliationReport, issue *model.ReconciliationIssue, apply func() error) {
+	if err := apply(); err != nil {
+		issue.FixError = err.Error()
+		log.Printf("[reconcile] correção de %s falhou (company_id=%s): %v", issue.Kind, issue.CompanyID, err)
+		return
+	}
+	issue.Fixed = true
+	report.Fixed++
+}
+
+// dispatch executa os passos pelo outbox: o que falhar agora ainda é refeito pelo worker do outbox.
+func dispatch(ob *outbox.Outbox, companyID string, jobs ...outbox.Job) error {
+	failed, err := ob.Dispatch(companyID, jobs...)
+	if err != nil {
+		return err
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(failed))
+	for step, err := range failed {
+		msgs = append(msgs, fmt.Sprintf("%s: %v (pendente no outbox)", step, err))
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,128 @@
+package server_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+// emitForwardsRaw emite o documento e confere que a Focus recebeu exatamente o payload enviado.
+func emitForwardsRaw(t *testing.T, e *env, docType, payload string, wantStatus int) {
+	t.Helper()
+	resp, b := e.do(t, http.MethodPost, "/v2/"+docType+"?company_id=c1&ref="+docType+"-1", []byte(payload), nil)
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("HTTP %d: %s", resp.StatusCode, b)
+	}
+	docs := e.focus.Documents(docType)
+	if len(docs) != 1 || string(docs[0].Payload) != payload {
+		t.Fatalf("o payload deveria chegar à Focus sem alteração: %+v", docs)
+	}
+}
+
+// emitRejected confere que o payload é recusado com erro no campo informado, sem chamar a Focus.
+func emitRejected(t *testing.T, e *env, docType, payload, campo string) {
+	t.Helper()
+	resp, b := e.do(t, http.MethodPost, "/v2/"+docType+"?company_id=c1&ref="+docType+"-2", []byte(payload), nil)
+	if resp.StatusCode != http.StatusUnprocessableEntity || !bytes.Contains(b, []byte(`"campo":"`+campo+`"`)) {
+		t.Fatalf("esperava 422 no campo %s, veio %d: %s", campo, resp.StatusCode, b)
+	}
+	for _, doc := range e.focus.Documents(docType) {
+		if doc.Ref == docType+"-2" {
+			t.Fatal("payload inválido não deveria ser enviado à Focus")
+		}
+	}
+}
+
+func TestEmitNFSeNAcceptsNumbersAsText(t *testing.T) {
+	e := newEnv(t, nil)
+	e.integrate("c1", "producao", map[string]any{"habilita_nfsen_producao": true})
+
+	const dps = `{"data_emissao":"2025-01-15T10:00:00-03:00","data_competencia":"2025-01-15",` +
+		`"codigo_municipio_emissora":"4106902","cnpj_prestador":"11222333000181","codigo_municipio_prestacao":"4106902",` +
+		`"codigo_tributacao_nacional_iss":"170201","descricao_servico":"Contabilidade",` +
+		`"valor_servico":"1500.00","tributacao_iss":"1","tipo_retencao_iss":1,"percentual_aliquota_relativa_municipio":"2.5"}`
+	emitForwardsRaw(t, e, "nfsen", dps, http.StatusAccepted)
+
+	invalid := bytes.Replace([]byte(dps), []byte(`"tributacao_iss":"1"`), []byte(`"tributacao_iss":"um"`), 1)
+	emitRejected(t, e, "nfsen", string(invalid), "tributacao_iss")
+	invalid = bytes.Replace([]byte(dps), []byte(`"valor_servico":"1500.00"`), []byte(`"valor_servico":"0"`), 1)
+	emitRejected(t, e, "nfsen", string(invalid), "valor_servico")
+}
+
+func TestEmitNFeAcceptsNumbersAsText(t *testing.T) {
+	e := newEnv(t, nil)
+	e.integrate("c1", "producao", nil)
+
+	const nfe = `{"natureza_operacao":"Venda de mercadoria","data_emissao":"2025-01-15T10:00:00-03:00",` +
+		`"tipo_documento":"1","finalidade_emissao":"1","cnpj_emitente":"11222333000181","nome_destinatario":"Acme Ltda",` +
+		`"valor_total":"150.00","items":[{"numero_item":"1","cfop":"5102","codigo_ncm":"96081000",` +
+		`"quantidade_comercial":"10.0000","valor_unitario_comercial":"15.00","valor_bruto":"150.00"}],` +
+		`"formas_pagamento":[{"forma_pagamento":"01","valor_pagamento":"150.00"}]}`
+	emitForwardsRaw(t, e, "nfe", nfe, http.StatusAccepted)
+
+	invalid := bytes.Replace([]byte(nfe), []byte(`"numero_item":"1"`), []byte(`"numero_item":"1.5"`), 1)
+	emitRejected(t, e, "nfe", string(invalid), "items[0].numero_item")
+	invalid = bytes.Replace([]byte(nfe), []byte(`"quantidade_comercial":"10.0000"`), []byte(`"quantidade_comercial":"dez"`), 1)
+	emitRejected(t, e, "nfe", string(invalid), "items[0].quantidade_comercial")
+}
+
+func TestEmitNFCeAcceptsNumbersAsText(t *testing.T) {
+	e := newEnv(t, nil)
+	e.integrate("c1", "producao", nil)
+
+	const nfce = `{"natureza_operacao":"Venda ao consumidor","data_emissao":"2025-01-15T10:00:00-03:00",` +
+		`"cnpj_emitente":"11222333000181","valor_total":"30.00","forma_emissao":"1",` +
+		`"items":[{"numero_item":"1","cfop":"5102","codigo_ncm":"96081000","quantidade_comercial":"2",` +
+		`"valor_unitario_comercial":"15.00","valor_bruto":"30.00"}],` +
+		`"formas_pagamento":[{"forma_pagamento":"01","valor_pagamento":"50.00","troco":"20.00"}]}`
+	emitForwardsRaw(t, e, "nfce", nfce, http.StatusCreated)
+
+	invalid := bytes.Replace([]byte(nfce), []byte(`"troco":"20.00"`), []byte(`"troco":"10.00"`), 1)
+	emitRejected(t, e, "nfce", string(invalid), "formas_pagamento")
+	invalid = bytes.Replace([]byte(nfce), []byte(`"valor_pagamento":"50.00"`), []byte(`"valor_pagamento":"cinquenta"`), 1)
+	emitRejected(t, e, "nfce", string(invalid), "formas_pagamento[0].valor_pagamento")
+}
+
+func TestEmitCTeAcceptsNumbersAsText(t *testing.T) {
+	e := newEnv(t, nil)
+	e.integrate("c1", "producao", nil)
+
+	const cte = `{"modal":"01","tipo_documento":"0","tipo_servico":"0","natureza_operacao":"Prestação de serviço de transporte",` +
+		`"cfop":"5353","data_emissao":"2025-01-15T10:00:00-03:00","indicador_tomador":"0","cnpj_emitente":"11222333000181",` +
+		`"codigo_municipio_envio":"4106902","codigo_municipio_inicio":"4106902","codigo_municipio_fim":"3550308",` +
+		`"valor_total":"350.00","valor_receber":"350.00","valor_total_carga":"12000.00"}`
+	emitForwardsRaw(t, e, "cte", cte, http.StatusAccepted)
+
+	invalid := bytes.Replace([]byte(cte), []byte(`"tipo_servico":"0"`), []byte(`"tipo_servico":"normal"`), 1)
+	emitRejected(t, e, "cte", string(invalid), "tipo_servico")
+}
+
+func TestEmitMDFeAcceptsNumbersAsText(t *testing.T) {
+	e := newEnv(t, nil)
+	e.integrate("c1", "producao", nil)
+
+	const mdfe = `{"tipo_emitente":"2","cnpj_emitente":"11222333000181","data_emissao":"2025-01-15T10:00:00-03:00",` +
+		`"uf_inicio":"PR","uf_fim":"SP","municipios_carregamento":[{"codigo":"4106902","nome":"Curitiba"}],` +
+		`"municipios_descarregamento":[{"codigo":"3550308","nome":"São Paulo","nfes":[{"chave_nfe":"41250110964044000164550010000000011000000016"}]}],` +
+		`"valor_total_carga":"12000.00","codigo_unidade_medida_peso_bruto":"01","peso_bruto":"850.5",` +
+		`"modal_rodoviario":{"veiculo_tracao":{"placa":"ABC1D23","tara":"8000","tipo_rodado":"03","tipo_carroceria":"02",` +
+		`"uf_licenciamento":"PR","condutores":[{"nome":"João da Silva","cpf":"12345678901"}]}}}`
+	emitForwardsRaw(t, e, "mdfe", mdfe, http.StatusAccepted)
+
+	invalid := bytes.Replace([]byte(mdfe), []byte(`"tara":"8000"`), []byte(`"tara":"8000.5"`), 1)
+	emitRejected(t, e, "mdfe", string(invalid), "modal_rodoviario.veiculo_tracao.tara")
+}
+
+func TestEmitNFSeAcceptsNumbersAsText(t *testing.T) {
+	e := newEnv(t, nil)
+	e.integrate("c1", "producao", nil)
+
+	const nfse = `{"data_emissao":"2025-01-15T10:00:00-03:00","natureza_operacao":"1",` +
+		`"prestador":{"cnpj":"11222333000181","inscricao_municipal":"0046532","codigo_municipio":"4106902"},` +
+		`"tomador":{"cnpj":"07504505000132","razao_social":"Acme Ltda"},` +
+		`"servico":{"valor_servicos":"1500.00","aliquota":"2.5","discriminacao":"Contabilidade","item_lista_servico":"17.19"}}`
+	emitForwardsRaw(t, e, "nfse", nfse, http.StatusAccepted)
+
+	invalid := bytes.Replace([]byte(nfse), []byte(`"aliquota":"2.5"`), []byte(`"aliquota":"2,5%"`), 1)
+	emitRejected(t, e, "nfse", string(invalid), "servico.aliquota")
+}
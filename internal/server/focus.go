@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/config"
+	"github.com/seuuser/focus-integration-service/internal/focus"
+	"github.com/seuuser/focus-integration-service/internal/supabase"
+)
+
+// newFocusClient monta o focus.Client compartilhado por todos os handlers a partir da config.
+func newFocusClient(cfg config.Config) *focus.Client {
+	opts := []focus.Option{
+		focus.WithRetryPolicy(focus.RetryPolicy{
+			MaxAttempts: cfg.FocusRetryMaxAttempts,
+			BaseDelay:   cfg.FocusRetryBaseDelay,
+			MaxDelay:    cfg.FocusRetryMaxDelay,
+		}),
+		focus.WithTokenResolver(focus.NewCachingTokenResolver(lookupCompanyCredentials, cfg.FocusCompanyTokenCacheTTL)),
+	}
+
+	if cfg.FocusRateLimitPerMinute > 0 {
+		opts = append(opts, focus.WithRateLimiter(
+			focus.NewRateLimiter(cfg.FocusRateLimitPerMinute, time.Minute, cfg.FocusRateLimitWriteReserve),
+		))
+	}
+
+	if cfg.FocusBreakerFailureThreshold > 0 {
+		opts = append(opts, focus.WithCircuitBreaker(focus.BreakerConfig{
+			FailureThreshold: cfg.FocusBreakerFailureThreshold,
+			OpenTimeout:      cfg.FocusBreakerOpenTimeout,
+		}))
+	}
+
+	if cfg.FocusAuditEnabled {
+		opts = append(opts, focus.WithAudit(focus.NewAsyncAuditSink(writeFocusAudit, 512)))
+	}
+
+	if cfg.FocusHomologacaoURL != "" {
+		opts = append(opts, focus.WithHomologacao(cfg.FocusHomologacaoURL, cfg.FocusHomologacaoToken))
+	}
+
+	return focus.NewClient(cfg.FocusURL, cfg.FocusToken, opts...)
+}
+
+// lookupCompanyCredentials lê o token da empresa salvo em focus_integration (usado pelo TokenResolver).
+func lookupCompanyCredentials(_ context.Context, companyID string) (focus.CompanyCredentials, error) {
+	fi, err := supabase.GetFocusIntegration(companyID)
+	if err != nil {
+		return focus.CompanyCredentials{}, fmt.Errorf("erro ao buscar integração Focus da empresa: %w", err)
+	}
+	if fi == nil || fi.TokenFocusCompany == "" {
+		return focus.CompanyCredentials{}, focus.ErrCompanyNotIntegrated
+	}
+
+	env, ok := focus.ParseEnvironment(fi.Environment)
+	if !ok {
+		env = focus.EnvProducao
+	}
+
+	return focus.CompanyCredentials{
+		CompanyID:      companyID,
+		FocusCompanyID: fi.FocusCompanyID,
+		Token:          fi.TokenFocusCompany,
+		Environment:    env,
+	}, nil
+}
+
+// writeFocusAudit grava uma entrada de auditoria da Focus em focus_request_logs.
+func writeFocusAudit(e focus.AuditEntry) error {
+	return supabase.InsertFocusRequestLog(supabase.FocusRequestLog{
+		CompanyID:    e.CompanyID,
+		Environment:  string(e.Environment),
+		Method:       e.Method,
+		Path:         e.Path,
+		Query:        e.Query,
+		StatusCode:   e.StatusCode,
+		LatencyMs:    e.Latency.Milliseconds(),
+		RequestBody:  e.RequestBody,
+		ResponseBody: e.ResponseBody,
+		Error:        e.Error,
+		CreatedAt:    e.CreatedAt,
+	})
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/seuuser/focus-integration-service/internal/focus"
+)
+
+// FocusEnvironmentHeader seleciona o ambiente da Focus (producao | homologacao) numa requisição /v2/*.
+// Alternativa: querystring ?ambiente=homologacao.
+const FocusEnvironmentHeader = "X-Focus-Environment"
+
+const focusEnvironmentQuery = "ambiente"
+
+// focusEnvironment define o ambiente Focus no context da requisição. Prioridade:
+//  1. header X-Focus-Environment
+//  2. querystring ?ambiente=
+//  3. ambiente da integração (focus_integration) do company_id da querystring, pelo cache de credenciais do client
+//  4. produção (empresa sem integração)
+//
+// O ambiente pedido no header/querystring fica marcado no context: as rotas que chamam a Focus como empresa
+// respondem 409 quando ele difere do ambiente da integração (ver focus.WithRequestedEnvironment).
+// Se a integração não puder ser lida, responde 503: cair em produção transformaria emissões de teste de uma
+// empresa de homologação em documentos fiscais reais.
+// O parâmetro `ambiente` é removido da querystring para não ser repassado à Focus.
+// O company_id da querystring (quando houver) também vai para o context, para a auditoria.
+func focusEnvironment(fc *focus.Client) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+
+			raw := strings.TrimSpace(r.Header.Get(FocusEnvironmentHeader))
+			if raw == "" {
+				raw = strings.TrimSpace(q.Get(focusEnvironmentQuery))
+			}
+			if q.Has(focusEnvironmentQuery) {
+				q.Del(focusEnvironmentQuery)
+				r.URL.RawQuery = q.Encode()
+			}
+
+			var env focus.Environment
+			requested := raw != ""
+			if requested {
+				parsed, ok := focus.ParseEnvironment(raw)
+				if !ok {
+					writeJSONError(w, http.StatusBadRequest, "ambiente inválido: use producao ou homologacao")
+					return
+				}
+				env = parsed
+			} else if companyID := q.Get("company_id"); companyID != "" {
+				creds, err := fc.ResolveCompany(r.Context(), companyID)
+				switch {
+				case err == nil:
+					env = creds.Environment
+				case errors.Is(err, focus.ErrCompanyNotIntegrated):
+				default:
+					log.Printf("[supabase] erro ao buscar ambiente da integração (company_id=%s): %v", companyID, err)
+					w.Header().Set("Retry-After", "5")
+					writeJSONError(w, http.StatusServiceUnavailable, "não foi possível identificar o ambiente Focus da empresa, tente novamente")
+					return
+				}
+			}
+
+			ctx := r.Context()
+			switch {
+			case requested:
+				ctx = focus.WithRequestedEnvironment(ctx, env)
+			case env != "":
+				ctx = focus.WithEnvironment(ctx, env)
+			}
+			// company_id da querystring identifica a empresa na auditoria das chamadas à Focus
+			if companyID := q.Get("company_id"); companyID != "" {
+				ctx = focus.WithCompanyID(ctx, companyID)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"error": message,
+	})
+}
@@ -0,0 +1,63 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/seuuser/focus-integration-service/internal/model"
+	"github.com/seuuser/focus-integration-service/internal/supabase/supabasetest"
+)
+
+// reconcile roda a reconciliação com correção e devolve a divergência da empresa c1.
+func (e *env) reconcile(t *testing.T) model.ReconciliationIssue {
+	t.Helper()
+	resp, b := e.do(t, http.MethodPost, "/v2/reconciliation?fix=true", nil, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("HTTP %d: %s", resp.StatusCode, b)
+	}
+	var report model.ReconciliationReport
+	if err := json.Unmarshal(b, &report); err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range report.Issues {
+		if issue.CompanyID == "c1" {
+			return issue
+		}
+	}
+	t.Fatalf("divergência da empresa c1 não relatada: %s", b)
+	return model.ReconciliationIssue{}
+}
+
+func TestReconcileMissingLinkKeepsEnvironment(t *testing.T) {
+	e := newEnv(t, nil)
+	e.db.Insert("companies", supabasetest.Row{"id": "c1", "cnpj": testCNPJ, "focus_integrated": true})
+	e.integrate("c1", "homologacao", nil)
+
+	issue := e.reconcile(t)
+	if issue.Kind != "missing_link" || issue.Fixed || issue.FixError == "" {
+		t.Fatalf("integração no outro ambiente deveria ser só relatada: %+v", issue)
+	}
+	if rows := e.db.Rows("focus_integration"); len(rows) != 1 || rows[0]["environment"] != "homologacao" {
+		t.Fatalf("a integração não deveria mudar de ambiente: %v", rows)
+	}
+}
+
+func TestReconcileMissingLinkUpdatesExistingIntegration(t *testing.T) {
+	e := newEnv(t, nil)
+	e.db.Insert("companies", supabasetest.Row{"id": "c1", "cnpj": testCNPJ, "focus_integrated": true})
+	id := e.focus.AddEmpresa(map[string]any{"nome": "Empresa Teste LTDA", "cnpj": testCNPJ})
+	e.db.Insert("focus_integration", supabasetest.Row{
+		"company_id": "c1", "focus_company_id": "999", "token_focus_company": "token-antigo", "environment": "producao",
+	})
+
+	issue := e.reconcile(t)
+	if issue.Kind != "missing_link" || !issue.Fixed {
+		t.Fatalf("a integração deveria ser corrigida: %+v", issue)
+	}
+	rows := e.db.Rows("focus_integration")
+	if len(rows) != 1 || rows[0]["focus_company_id"] != strconv.Itoa(id) || rows[0]["token_focus_company"] == "token-antigo" {
+		t.Fatalf("a linha existente deveria apontar para a empresa da Focus: %v", rows)
+	}
+}
@@ -1,11 +1,17 @@
 package server
 
 import (
+	"context"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
+	"github.com/seuuser/focus-integration-service/internal/archive"
+	"github.com/seuuser/focus-integration-service/internal/certmonitor"
 	"github.com/seuuser/focus-integration-service/internal/config"
 	"github.com/seuuser/focus-integration-service/internal/focus"
 	"github.com/seuuser/focus-integration-service/internal/handler"
+	"github.com/seuuser/focus-integration-service/internal/outbox"
+	"github.com/seuuser/focus-integration-service/internal/reconcile"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 )
 
@@ -13,22 +19,68 @@ func RegisterRoutes(r *chi.Mux, cfg config.Config) {
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   cfg.CorsAllowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		ExposedHeaders:   []string{"X-Total-Count", "Rate-Limit-Limit", "Rate-Limit-Remaining", "Rate-Limit-Reset"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", FocusEnvironmentHeader, "Idempotency-Key"},
+		ExposedHeaders:   []string{"X-Total-Count", "Rate-Limit-Limit", "Rate-Limit-Remaining", "Rate-Limit-Reset", "Retry-After", "X-Integration-Warning", "X-Integration-Status", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           300, // 5 minutes
 	}))
 
-	r.Get("/health", handler.Health)
-
-	focusClient := focus.NewClient(cfg.FocusURL, cfg.FocusToken)
-	empresas := handler.NewEmpresasHandler(focusClient)
+	focusClient := newFocusClient(cfg)
+	withEnvironment := focusEnvironment(focusClient)
+	health := handler.NewHealthHandler(focusClient)
+	webhookCfg := handler.WebhookConfig{
+		URL:    cfg.FocusWebhookURL,
+		Secret: cfg.FocusWebhookSecret,
+		Header: cfg.FocusWebhookSecretHeader,
+		Events: cfg.FocusWebhookEvents,
+	}
+	integrationOutbox := outbox.New(cfg.FocusOutboxInterval)
+	empresas := handler.NewEmpresasHandler(focusClient, webhookCfg, integrationOutbox)
 	cnpjs := handler.NewCnpjsHandler(focusClient)
+	ceps := handler.NewCepsHandler(focusClient)
 	municipios := handler.NewMunicipiosHandler(focusClient)
+	ncms := handler.NewNcmsHandler(focusClient)
+	cfops := handler.NewCfopsHandler(focusClient)
+	nfse := handler.NewNfseHandler(focusClient)
+	nfsen := handler.NewNfsenHandler(focusClient)
+	nfe := handler.NewNfeHandler(focusClient)
+	nfce := handler.NewNfceHandler(focusClient)
+	cte := handler.NewCteHandler(focusClient)
+	mdfe := handler.NewMdfeHandler(focusClient)
+	hooks := handler.NewHooksHandler(focusClient, webhookCfg)
+	backups := handler.NewBackupsHandler(focusClient, cfg.FocusArchiveBucket)
+	webhooks := handler.NewWebhooksHandler(focusClient, cfg.FocusWebhookSecret, cfg.FocusWebhookSecretHeader, 256)
+
+	reconcileEnv, ok := focus.ParseEnvironment(cfg.FocusReconcileEnvironment)
+	if !ok {
+		reconcileEnv = focus.EnvProducao
+	}
+	reconciler := reconcile.NewReconciler(focusClient, integrationOutbox, reconcileEnv, cfg.FocusReconcileInterval, cfg.FocusReconcileAutoFix)
+	reconciliation := handler.NewReconciliationHandler(reconciler)
+
+	if cfg.FocusArchiveInterval > 0 {
+		go archive.NewArchiver(focusClient, cfg.FocusArchiveBucket, cfg.FocusArchiveInterval).Run(context.Background())
+	}
+	if cfg.FocusOutboxInterval > 0 {
+		go integrationOutbox.Run(context.Background())
+	}
+	if cfg.FocusReconcileInterval > 0 {
+		go reconciler.Run(context.Background())
+	}
+	if cfg.FocusCertMonitorInterval > 0 {
+		go newCertificateMonitor(cfg).Run(context.Background())
+	}
+
+	r.Get("/health", health.Health)
+
+	r.Post("/webhooks/focus", webhooks.FocusWebhook)
 
 	r.Route("/v2/empresas", func(r chi.Router) {
+		r.Use(withEnvironment)
+
 		r.Post("/", empresas.CreateEmpresa)
 		r.Get("/", empresas.ListEmpresas)
+		r.Get("/export", empresas.ExportEmpresas)
 
 		r.Route("/{id}", func(r chi.Router) {
 			r.Get("/", empresas.GetEmpresa)
@@ -37,11 +89,41 @@ func RegisterRoutes(r *chi.Mux, cfg config.Config) {
 		})
 	})
 
+	r.Route("/v2/reconciliation", func(r chi.Router) {
+		r.Get("/", reconciliation.GetReconciliation)
+		r.Post("/", reconciliation.RunReconciliation)
+	})
+
+	r.Route("/v2/companies/{company_id}/hooks", func(r chi.Router) {
+		r.Use(withEnvironment)
+
+		r.Post("/", hooks.CreateHook)
+		r.Get("/", hooks.ListHooks)
+		r.Delete("/{hook_id}", hooks.DeleteHook)
+	})
+
+	r.Route("/v2/companies/{company_id}/backups", func(r chi.Router) {
+		r.Use(withEnvironment)
+
+		r.Get("/", backups.ListBackups)
+		r.Post("/{mes}", backups.ArchiveBackup)
+	})
+
 	r.Route("/v2/cnpjs", func(r chi.Router) {
+		r.Use(withEnvironment)
+
 		r.Get("/{cnpj}", cnpjs.GetCnpj)
 	})
 
+	r.Route("/v2/ceps", func(r chi.Router) {
+		r.Use(withEnvironment)
+
+		r.Get("/{cep}", ceps.GetCep)
+	})
+
 	r.Route("/v2/municipios", func(r chi.Router) {
+		r.Use(withEnvironment)
+
 		r.Get("/", municipios.ListMunicipios)
 
 		r.Route("/{codigo_municipio}", func(r chi.Router) {
@@ -53,7 +135,112 @@ func RegisterRoutes(r *chi.Mux, cfg config.Config) {
 		})
 	})
 
+	r.Route("/v2/ncms", func(r chi.Router) {
+		r.Use(withEnvironment)
+
+		r.Get("/", ncms.ListNcms)
+		r.Get("/{codigo}", ncms.GetNcm)
+	})
+
+	r.Route("/v2/cfops", func(r chi.Router) {
+		r.Use(withEnvironment)
+
+		r.Get("/", cfops.ListCfops)
+		r.Get("/{codigo}", cfops.GetCfop)
+	})
+
+	r.Route("/v2/nfse", func(r chi.Router) {
+		r.Use(withEnvironment)
+
+		r.Post("/", nfse.EmitNFSe)
+
+		r.Route("/{ref}", func(r chi.Router) {
+			r.Get("/", nfse.GetNFSe)
+			r.Delete("/", nfse.CancelNFSe)
+			r.Post("/email", nfse.ResendNFSeEmail)
+		})
+	})
+
+	r.Route("/v2/nfsen", func(r chi.Router) {
+		r.Use(withEnvironment)
+
+		r.Post("/", nfsen.EmitNFSeN)
+
+		r.Route("/{ref}", func(r chi.Router) {
+			r.Get("/", nfsen.GetNFSeN)
+			r.Delete("/", nfsen.CancelNFSeN)
+		})
+	})
+
+	r.Route("/v2/nfe", func(r chi.Router) {
+		r.Use(withEnvironment)
+
+		r.Post("/", nfe.EmitNFe)
+		r.Get("/", nfe.ListNFe)
+		r.Post("/inutilizacao", nfe.InutilizarNFe)
+
+		r.Route("/{ref}", func(r chi.Router) {
+			r.Get("/", nfe.GetNFe)
+			r.Delete("/", nfe.CancelNFe)
+			r.Get("/eventos", nfe.NFeEvents)
+			r.Post("/carta_correcao", nfe.CartaCorrecaoNFe)
+		})
+	})
+
+	r.Route("/v2/nfce", func(r chi.Router) {
+		r.Use(withEnvironment)
+
+		r.Post("/", nfce.EmitNFCe)
+
+		r.Route("/{ref}", func(r chi.Router) {
+			r.Get("/", nfce.GetNFCe)
+			r.Delete("/", nfce.CancelNFCe)
+		})
+	})
+
+	r.Route("/v2/cte", func(r chi.Router) {
+		r.Use(withEnvironment)
+
+		r.Post("/", cte.EmitCTe)
+
+		r.Route("/{ref}", func(r chi.Router) {
+			r.Get("/", cte.GetCTe)
+			r.Delete("/", cte.CancelCTe)
+		})
+	})
+
+	r.Route("/v2/mdfe", func(r chi.Router) {
+		r.Use(withEnvironment)
+
+		r.Post("/", mdfe.EmitMDFe)
+
+		r.Route("/{ref}", func(r chi.Router) {
+			r.Get("/", mdfe.GetMDFe)
+			r.Delete("/", mdfe.CancelMDFe)
+			r.Post("/encerrar", mdfe.EncerrarMDFe)
+			r.Post("/inclusao_condutor", mdfe.IncluirCondutorMDFe)
+		})
+	})
+
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
 }
 
+// newCertificateMonitor monta o monitor de certificados com os notificadores configurados.
+func newCertificateMonitor(cfg config.Config) *certmonitor.Monitor {
+	var notifiers []certmonitor.Notifier
+	if cfg.FocusCertAlertSMTPAddr != "" && len(cfg.FocusCertAlertEmailTo) > 0 {
+		notifiers = append(notifiers, certmonitor.NewSMTPNotifier(certmonitor.SMTPConfig{
+			Addr:     cfg.FocusCertAlertSMTPAddr,
+			Username: cfg.FocusCertAlertSMTPUser,
+			Password: cfg.FocusCertAlertSMTPPassword,
+			From:     cfg.FocusCertAlertEmailFrom,
+			To:       cfg.FocusCertAlertEmailTo,
+		}))
+	}
+	if cfg.FocusCertAlertWebhookURL != "" {
+		notifiers = append(notifiers, certmonitor.NewWebhookNotifier(cfg.FocusCertAlertWebhookURL, cfg.FocusCertAlertWebhookSecret))
+	}
+	return certmonitor.NewMonitor(cfg.FocusCertAlertWindows, cfg.FocusCertMonitorInterval, notifiers...)
+}
+
 
@@ -0,0 +1,438 @@
+package server_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/seuuser/focus-integration-service/internal/config"
+	"github.com/seuuser/focus-integration-service/internal/focus/focustest"
+	"github.com/seuuser/focus-integration-service/internal/server"
+	"github.com/seuuser/focus-integration-service/internal/supabase/supabasetest"
+)
+
+const testCNPJ = "11222333000181"
+
+// env reúne o serviço (router completo) e as dependências falsas: Focus e Supabase.
+type env struct {
+	api   *httptest.Server
+	focus *focustest.Server
+	db    *supabasetest.Server
+	cfg   config.Config
+}
+
+// newEnv sobe o router com a Focus e o Supabase falsos. Os jobs em segundo plano ficam desligados
+// (intervalos zerados); configure ajusta a config antes do RegisterRoutes.
+func newEnv(t *testing.T, configure func(*config.Config)) *env {
+	t.Helper()
+	fake := focustest.NewServer()
+	t.Cleanup(fake.Close)
+	db := supabasetest.Start(t)
+	db.Unique("focus_idempotency_keys", "company_id", "idempotency_key")
+	db.Unique("focus_webhook_events", "delivery_hash")
+	db.Unique("focus_integration", "company_id")
+	db.HandleRPC("rpc_service_update_certificate_dates_for_company", func(map[string]any) (any, error) { return nil, nil })
+
+	cfg := config.Config{
+		FocusURL:              fake.URL,
+		FocusToken:            "token-revenda",
+		FocusHomologacaoURL:   fake.URL,
+		FocusHomologacaoToken: "token-revenda",
+		FocusRetryMaxAttempts: 1,
+	}
+	if configure != nil {
+		configure(&cfg)
+	}
+
+	e := &env{focus: fake, db: db, cfg: cfg}
+	e.restart(t)
+	return e
+}
+
+// restart sobe um novo router com a mesma config e as mesmas dependências, como um restart do serviço.
+func (e *env) restart(t *testing.T) {
+	t.Helper()
+	r := chi.NewRouter()
+	server.RegisterRoutes(r, e.cfg)
+	api := httptest.NewServer(r)
+	t.Cleanup(api.Close)
+	e.api = api
+}
+
+// waitFor espera cond ficar verdadeira (trabalho assíncrono do serviço) ou falha o teste.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout esperando %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (e *env) do(t *testing.T, method, path string, body []byte, header map[string]string) (*http.Response, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, e.api.URL+path, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	return resp, b
+}
+
+// integrate cadastra a empresa na Focus falsa (com os campos extras de empresa) e grava a integração
+// em focus_integration, como o cadastro faria.
+func (e *env) integrate(companyID, environment string, empresa map[string]any) {
+	fields := map[string]any{"nome": "Empresa Teste LTDA", "cnpj": testCNPJ}
+	for k, v := range empresa {
+		fields[k] = v
+	}
+	id := e.focus.AddEmpresa(fields)
+	e.db.Insert("focus_integration", supabasetest.Row{
+		"company_id":          companyID,
+		"focus_company_id":    strconv.Itoa(id),
+		"token_focus_company": "token-" + companyID,
+		"environment":         environment,
+	})
+}
+
+func countRequests(requests []string, prefix string) int {
+	n := 0
+	for _, r := range requests {
+		if strings.HasPrefix(r, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestExportEmpresasReadsEveryPage(t *testing.T) {
+	e := newEnv(t, nil)
+	const total = 2*focustest.PageSize + 7
+	for i := 0; i < total; i++ {
+		e.focus.AddEmpresa(map[string]any{"nome": fmt.Sprintf("Empresa %d", i), "cnpj": fmt.Sprintf("%014d", i+1)})
+	}
+
+	resp, b := e.do(t, http.MethodGet, "/v2/empresas/export", nil, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("HTTP %d: %s", resp.StatusCode, b)
+	}
+	var empresas []map[string]any
+	if err := json.Unmarshal(b, &empresas); err != nil {
+		t.Fatalf("export não é um array JSON completo: %v", err)
+	}
+	if len(empresas) != total || resp.Header.Get("X-Total-Count") != fmt.Sprint(total) {
+		t.Fatalf("esperava %d empresas, veio %d (X-Total-Count=%q)", total, len(empresas), resp.Header.Get("X-Total-Count"))
+	}
+	if n := countRequests(e.focus.Requests(), "GET /v2/empresas"); n != 3 {
+		t.Fatalf("esperava 3 páginas lidas na Focus, foram %d", n)
+	}
+}
+
+func TestBreakerOpensAndHealthReportsDegraded(t *testing.T) {
+	e := newEnv(t, func(cfg *config.Config) {
+		cfg.FocusBreakerFailureThreshold = 2
+		cfg.FocusBreakerOpenTimeout = time.Minute
+	})
+	e.focus.FailNext(http.MethodGet, "/v2/cnpjs", http.StatusServiceUnavailable, 0)
+
+	for i := 0; i < 2; i++ {
+		e.do(t, http.MethodGet, "/v2/cnpjs/"+testCNPJ, nil, nil)
+	}
+	resp, b := e.do(t, http.MethodGet, "/v2/cnpjs/"+testCNPJ, nil, nil)
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Header.Get("Retry-After") == "" {
+		t.Fatalf("breaker aberto deveria responder 503 com Retry-After, veio %d: %s", resp.StatusCode, b)
+	}
+	if n := countRequests(e.focus.Requests(), "GET /v2/cnpjs"); n != 2 {
+		t.Fatalf("com o breaker aberto a Focus não deveria ser chamada: %d chamadas", n)
+	}
+
+	// as outras famílias seguem liberadas
+	if resp, b := e.do(t, http.MethodGet, "/v2/ceps/01001000", nil, nil); resp.StatusCode == http.StatusServiceUnavailable {
+		t.Fatalf("breaker de cnpjs não deveria bloquear ceps: %s", b)
+	}
+
+	_, b = e.do(t, http.MethodGet, "/health", nil, nil)
+	var health struct {
+		Status string            `json:"status"`
+		Focus  map[string]string `json:"focus"`
+	}
+	if err := json.Unmarshal(b, &health); err != nil {
+		t.Fatal(err)
+	}
+	if health.Status != "degraded" || health.Focus["cnpjs"] != "open" {
+		t.Fatalf("health deveria indicar o breaker de cnpjs aberto: %s", b)
+	}
+}
+
+func TestRateLimiterRefusesWithRetryAfter(t *testing.T) {
+	e := newEnv(t, func(cfg *config.Config) {
+		cfg.FocusRateLimitPerMinute = 1
+	})
+
+	if resp, b := e.do(t, http.MethodGet, "/v2/ceps/01001000", nil, nil); resp.StatusCode == http.StatusServiceUnavailable {
+		t.Fatalf("primeira consulta deveria passar: %s", b)
+	}
+	resp, b := e.do(t, http.MethodGet, "/v2/ceps/01001000", nil, nil)
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Header.Get("Retry-After") == "" {
+		t.Fatalf("orçamento esgotado deveria responder 503 com Retry-After, veio %d: %s", resp.StatusCode, b)
+	}
+	if n := countRequests(e.focus.Requests(), "GET /v2/ceps"); n != 1 {
+		t.Fatalf("a consulta recusada não deveria chegar à Focus: %d chamadas", n)
+	}
+}
+
+const nfcePayload = `{"natureza_operacao":"Venda ao consumidor","data_emissao":"2025-01-15T10:00:00-03:00",` +
+	`"cnpj_emitente":"11222333000181","valor_total":30,"campo_extra":{"mantido":true},` +
+	`"items":[{"numero_item":1,"cfop":"5102","codigo_ncm":"96081000","quantidade_comercial":2,"valor_unitario_comercial":15,"valor_bruto":30}],` +
+	`"formas_pagamento":[{"forma_pagamento":"01","valor_pagamento":30}]}`
+
+func TestEmitNFCeForwardsPayloadAndRecordsDocument(t *testing.T) {
+	e := newEnv(t, nil)
+	e.integrate("c1", "producao", nil)
+
+	resp, b := e.do(t, http.MethodPost, "/v2/nfce?company_id=c1&ref=nfce-1", []byte(nfcePayload), nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("HTTP %d: %s", resp.StatusCode, b)
+	}
+
+	docs := e.focus.Documents("nfce")
+	if len(docs) != 1 || string(docs[0].Payload) != nfcePayload {
+		t.Fatalf("o payload deveria chegar à Focus sem alteração: %+v", docs)
+	}
+	rows := e.db.Rows("focus_documents")
+	if len(rows) != 1 || rows[0]["status"] != "autorizado" || rows[0]["ref"] != "nfce-1" {
+		t.Fatalf("focus_documents deveria ter a NFC-e autorizada: %v", rows)
+	}
+}
+
+func TestEmitRejectedResendKeepsAuthorizedDocument(t *testing.T) {
+	e := newEnv(t, nil)
+	e.integrate("c1", "producao", nil)
+
+	if resp, b := e.do(t, http.MethodPost, "/v2/nfce?company_id=c1&ref=nfce-1", []byte(nfcePayload), nil); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("HTTP %d: %s", resp.StatusCode, b)
+	}
+	// reenvio da mesma ref: a Focus recusa (ref já utilizada)
+	resp, b := e.do(t, http.MethodPost, "/v2/nfce?company_id=c1&ref=nfce-1", []byte(nfcePayload), nil)
+	if resp.StatusCode < 400 || resp.StatusCode >= 500 {
+		t.Fatalf("esperava a recusa da Focus, veio %d: %s", resp.StatusCode, b)
+	}
+
+	doc := e.document("nfce-1")
+	if doc["status"] != "autorizado" {
+		t.Fatalf("a recusa do reenvio não deveria alterar o documento autorizado: %v", doc)
+	}
+	var rejected int
+	for _, ev := range e.db.Rows("focus_document_events") {
+		if ev["ref"] == "nfce-1" && ev["event"] == "emissao" && ev["status"] == "erro" {
+			rejected++
+		}
+	}
+	if rejected != 1 {
+		t.Fatalf("a recusa deveria ficar registrada como evento: %v", e.db.Rows("focus_document_events"))
+	}
+}
+
+func TestEmitNFCeRejectsEnvironmentMismatch(t *testing.T) {
+	e := newEnv(t, nil)
+	e.integrate("c1", "homologacao", nil)
+
+	resp, b := e.do(t, http.MethodPost, "/v2/nfce?company_id=c1&ref=nfce-1", []byte(nfcePayload),
+		map[string]string{server.FocusEnvironmentHeader: "producao"})
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("ambiente diferente do da empresa deveria responder 409, veio %d: %s", resp.StatusCode, b)
+	}
+	if docs := e.focus.Documents("nfce"); len(docs) != 0 {
+		t.Fatalf("nada deveria ser emitido: %+v", docs)
+	}
+
+	// sem o header, vale o ambiente da empresa
+	resp, b = e.do(t, http.MethodPost, "/v2/nfce?company_id=c1&ref=nfce-1", []byte(nfcePayload), nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("HTTP %d: %s", resp.StatusCode, b)
+	}
+}
+
+func empresaPayload(cert, senha string) []byte {
+	b, _ := json.Marshal(map[string]string{
+		"nome":                       "Empresa Teste LTDA",
+		"cnpj":                       testCNPJ,
+		"arquivo_certificado_base64": cert,
+		"senha_certificado":          senha,
+	})
+	return b
+}
+
+func TestCreateEmpresaIdempotencyKeyReplays(t *testing.T) {
+	e := newEnv(t, nil)
+	now := time.Now()
+	body := empresaPayload(focustest.Certificate(testCNPJ, "1234", now.AddDate(0, -1, 0), now.AddDate(1, 0, 0)), "1234")
+	header := map[string]string{"Idempotency-Key": "cadastro-1"}
+
+	first, b := e.do(t, http.MethodPost, "/v2/empresas?company_id=c1", body, header)
+	if first.StatusCode != http.StatusCreated {
+		t.Fatalf("HTTP %d: %s", first.StatusCode, b)
+	}
+	replay, b2 := e.do(t, http.MethodPost, "/v2/empresas?company_id=c1", body, header)
+	if replay.StatusCode != http.StatusCreated || replay.Header.Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("repetição deveria devolver a resposta gravada, veio %d: %s", replay.StatusCode, b2)
+	}
+	if !bytes.Contains(b2, []byte(`"token_producao"`)) {
+		t.Fatalf("o replay deveria devolver o token da empresa: %s", b2)
+	}
+	if n := countRequests(e.focus.Requests(), "POST /v2/empresas"); n != 1 {
+		t.Fatalf("esperava um único cadastro na Focus, foram %d", n)
+	}
+	if rows := e.db.Rows("focus_integration"); len(rows) != 1 {
+		t.Fatalf("esperava uma linha em focus_integration, veio %d", len(rows))
+	}
+
+	other := empresaPayload(focustest.Certificate(testCNPJ, "4321", now.AddDate(0, -1, 0), now.AddDate(1, 0, 0)), "4321")
+	if resp, b := e.do(t, http.MethodPost, "/v2/empresas?company_id=c1", other, header); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("mesma chave com outro payload deveria responder 422, veio %d: %s", resp.StatusCode, b)
+	}
+}
+
+func TestCreateEmpresaIdempotencyKeyIsScopedToEnvironment(t *testing.T) {
+	e := newEnv(t, nil)
+	now := time.Now()
+	body := empresaPayload(focustest.Certificate(testCNPJ, "1234", now.AddDate(0, -1, 0), now.AddDate(1, 0, 0)), "1234")
+
+	resp, b := e.do(t, http.MethodPost, "/v2/empresas?company_id=c1", body,
+		map[string]string{"Idempotency-Key": "cadastro-1", "X-Focus-Environment": "producao"})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("HTTP %d: %s", resp.StatusCode, b)
+	}
+	resp, b = e.do(t, http.MethodPost, "/v2/empresas?company_id=c1", body,
+		map[string]string{"Idempotency-Key": "cadastro-1", "X-Focus-Environment": "homologacao"})
+	if resp.StatusCode != http.StatusUnprocessableEntity || resp.Header.Get("Idempotent-Replayed") != "" {
+		t.Fatalf("mesma chave em outro ambiente deveria responder 422, veio %d: %s", resp.StatusCode, b)
+	}
+}
+
+func TestCreateEmpresaKeepsOneIntegrationPerCompany(t *testing.T) {
+	now := time.Now()
+	body := empresaPayload(focustest.Certificate(testCNPJ, "1234", now.AddDate(0, -1, 0), now.AddDate(1, 0, 0)), "1234")
+
+	t.Run("removida na Focus", func(t *testing.T) {
+		e := newEnv(t, nil)
+		e.db.Insert("focus_integration", supabasetest.Row{
+			"company_id": "c1", "focus_company_id": "999", "token_focus_company": "token-antigo", "environment": "producao",
+		})
+		resp, b := e.do(t, http.MethodPost, "/v2/empresas?company_id=c1", body, nil)
+		if resp.StatusCode != http.StatusCreated || resp.Header.Get("X-Integration-Status") != "created" {
+			t.Fatalf("esperava o recadastro, veio %d: %s", resp.StatusCode, b)
+		}
+		rows := e.db.Rows("focus_integration")
+		if len(rows) != 1 || rows[0]["focus_company_id"] == "999" {
+			t.Fatalf("o recadastro deveria substituir a integração anterior: %v", rows)
+		}
+	})
+
+	t.Run("adotada no outro ambiente", func(t *testing.T) {
+		e := newEnv(t, nil)
+		e.integrate("c1", "homologacao", nil)
+		resp, b := e.do(t, http.MethodPost, "/v2/empresas?company_id=c1", body, map[string]string{"X-Focus-Environment": "producao"})
+		if resp.StatusCode >= 300 || resp.Header.Get("X-Integration-Status") != "adopted" {
+			t.Fatalf("esperava a adoção, veio %d: %s", resp.StatusCode, b)
+		}
+		rows := e.db.Rows("focus_integration")
+		if len(rows) != 1 || rows[0]["environment"] != "producao" {
+			t.Fatalf("a adoção deveria substituir a integração anterior: %v", rows)
+		}
+	})
+}
+
+func TestCreateEmpresaFailsWhenIntegrationCannotBeSaved(t *testing.T) {
+	e := newEnv(t, nil)
+	now := time.Now()
+	body := empresaPayload(focustest.Certificate(testCNPJ, "1234", now.AddDate(0, -1, 0), now.AddDate(1, 0, 0)), "1234")
+	e.db.FailNext(http.MethodPost, "focus_integration_outbox", 1)
+	e.db.FailNext(http.MethodPost, "focus_integration", 1)
+
+	resp, b := e.do(t, http.MethodPost, "/v2/empresas?company_id=c1", body, nil)
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Header.Get("Retry-After") == "" {
+		t.Fatalf("sem outbox e sem focus_integration, esperava 503 com Retry-After, veio %d: %s", resp.StatusCode, b)
+	}
+
+	resp, b = e.do(t, http.MethodPost, "/v2/empresas?company_id=c1", body, nil)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("X-Integration-Status") != "adopted" {
+		t.Fatalf("a repetição deveria adotar a empresa já criada, veio %d: %s", resp.StatusCode, b)
+	}
+	if rows := e.db.Rows("focus_integration"); len(rows) != 1 {
+		t.Fatalf("esperava uma linha em focus_integration: %v", rows)
+	}
+}
+
+func TestCreateEmpresaRejectsInvalidCertificateLocally(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name  string
+		cert  string
+		senha string
+		campo string
+	}{
+		{"senha errada", focustest.Certificate(testCNPJ, "1234", now.AddDate(0, -1, 0), now.AddDate(1, 0, 0)), "errada", "senha_certificado"},
+		{"vencido", focustest.Certificate(testCNPJ, "1234", now.AddDate(-2, 0, 0), now.AddDate(0, 0, -1)), "1234", "arquivo_certificado_base64"},
+		{"outro cnpj", focustest.Certificate("99888777000166", "1234", now.AddDate(0, -1, 0), now.AddDate(1, 0, 0)), "1234", "arquivo_certificado_base64"},
+		{"não é pkcs12", "bm90LWEtcGZ4", "1234", "arquivo_certificado_base64"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := newEnv(t, nil)
+			resp, b := e.do(t, http.MethodPost, "/v2/empresas?company_id=c1", empresaPayload(tc.cert, tc.senha), nil)
+			if resp.StatusCode != http.StatusUnprocessableEntity || !bytes.Contains(b, []byte(tc.campo)) {
+				t.Fatalf("esperava 422 no campo %s, veio %d: %s", tc.campo, resp.StatusCode, b)
+			}
+			if n := countRequests(e.focus.Requests(), "POST /v2/empresas"); n != 0 {
+				t.Fatal("certificado inválido não deveria ser enviado à Focus")
+			}
+			rows := e.db.Rows("focus_integration_errors")
+			if len(rows) != 1 || rows[0]["code"] != "certificado_invalido" {
+				t.Fatalf("a recusa deveria ser registrada em focus_integration_errors: %v", rows)
+			}
+		})
+	}
+}
+
+func TestUpdateEmpresaRecordsInvalidCertificate(t *testing.T) {
+	e := newEnv(t, nil)
+	e.integrate("c1", "producao", nil)
+	now := time.Now()
+	body, _ := json.Marshal(map[string]string{
+		"arquivo_certificado_base64": focustest.Certificate(testCNPJ, "1234", now.AddDate(0, -1, 0), now.AddDate(1, 0, 0)),
+		"senha_certificado":          "errada",
+	})
+
+	id := e.db.Rows("focus_integration")[0]["focus_company_id"]
+	resp, b := e.do(t, http.MethodPut, fmt.Sprintf("/v2/empresas/%v?company_id=c1&certificate_id=cert-1", id), body, nil)
+	if resp.StatusCode != http.StatusUnprocessableEntity || !bytes.Contains(b, []byte("senha_certificado")) {
+		t.Fatalf("esperava 422 no campo senha_certificado, veio %d: %s", resp.StatusCode, b)
+	}
+	if n := countRequests(e.focus.Requests(), "PUT /v2/empresas"); n != 0 {
+		t.Fatal("certificado inválido não deveria ser enviado à Focus")
+	}
+	rows := e.db.Rows("focus_integration_errors")
+	if len(rows) != 1 || rows[0]["code"] != "certificado_invalido" || rows[0]["company_id"] != "c1" || rows[0]["certificates_id"] != "cert-1" {
+		t.Fatalf("a recusa deveria ser registrada em focus_integration_errors: %v", rows)
+	}
+}
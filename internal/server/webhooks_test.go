@@ -0,0 +1,162 @@
+package server_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/config"
+	"github.com/seuuser/focus-integration-service/internal/supabase/supabasetest"
+)
+
+var webhookHeader = map[string]string{"X-Focus-Webhook-Secret": "segredo"}
+
+func newWebhookEnv(t *testing.T) *env {
+	return newEnv(t, func(cfg *config.Config) {
+		cfg.FocusWebhookSecret = "segredo"
+		cfg.FocusWebhookSecretHeader = "X-Focus-Webhook-Secret"
+	})
+}
+
+// document devolve a linha de focus_documents da ref (nil se não existe).
+func (e *env) document(ref string) map[string]any {
+	for _, row := range e.db.Rows("focus_documents") {
+		if row["ref"] == ref {
+			return row
+		}
+	}
+	return nil
+}
+
+// webhookEvent devolve a entrega gravada da ref (nil se não existe).
+func (e *env) webhookEvent(ref string) map[string]any {
+	for _, row := range e.db.Rows("focus_webhook_events") {
+		if row["ref"] == ref {
+			return row
+		}
+	}
+	return nil
+}
+
+func TestWebhookRecordsDocumentInCompanyEnvironment(t *testing.T) {
+	e := newWebhookEnv(t)
+	e.integrate("c1", "homologacao", nil)
+
+	body := []byte(`{"ref":"nfe-1","status":"autorizado"}`)
+	resp, b := e.do(t, http.MethodPost, "/webhooks/focus?evento=nfe&company_id=c1", body, webhookHeader)
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("HTTP %d: %s", resp.StatusCode, b)
+	}
+	waitFor(t, "o documento do webhook", func() bool { return e.document("nfe-1") != nil })
+	if doc := e.document("nfe-1"); doc["environment"] != "homologacao" || doc["status"] != "autorizado" {
+		t.Fatalf("o documento deveria ficar no ambiente da integração: %v", doc)
+	}
+
+	resp, b = e.do(t, http.MethodPost, "/webhooks/focus?evento=nfe&company_id=c1", body, webhookHeader)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(b), "duplicado") {
+		t.Fatalf("reenvio deveria ser duplicado: HTTP %d: %s", resp.StatusCode, b)
+	}
+	if rows := e.db.Rows("focus_webhook_events"); len(rows) != 1 {
+		t.Fatalf("a entrega deveria ser gravada uma vez: %v", rows)
+	}
+}
+
+func TestWebhookWithoutCompanyStaysPendingUntilSweep(t *testing.T) {
+	e := newWebhookEnv(t)
+	e.integrate("c1", "homologacao", nil)
+
+	// a ref ainda não foi gravada (emissão em andamento): a empresa não pode ser deduzida
+	resp, b := e.do(t, http.MethodPost, "/webhooks/focus?evento=nfe", []byte(`{"ref":"nfe-a","status":"autorizado"}`), webhookHeader)
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("HTTP %d: %s", resp.StatusCode, b)
+	}
+	// a fila processa em ordem: quando a segunda entrega aparece, a primeira já passou pelo worker
+	e.do(t, http.MethodPost, "/webhooks/focus?evento=nfe&company_id=c1", []byte(`{"ref":"nfe-b","status":"autorizado"}`), webhookHeader)
+	waitFor(t, "a segunda entrega", func() bool { return e.document("nfe-b") != nil })
+
+	if ev := e.webhookEvent("nfe-a"); ev == nil || ev["processed_at"] != nil {
+		t.Fatalf("a entrega sem empresa deveria continuar pendente: %v", ev)
+	}
+
+	// a emissão grava o documento; a varredura depois do restart retoma a entrega
+	e.db.Insert("focus_documents", supabasetest.Row{
+		"company_id": "c1", "document_type": "nfe", "ref": "nfe-a",
+		"environment": "homologacao", "status": "processando_autorizacao",
+	})
+	e.db.Update("focus_webhook_events", func(r supabasetest.Row) bool { return r["ref"] == "nfe-a" },
+		supabasetest.Row{"received_at": time.Now().Add(-2 * time.Minute).UTC().Format(time.RFC3339Nano)})
+	e.restart(t)
+
+	waitFor(t, "a varredura", func() bool { return e.webhookEvent("nfe-a")["processed_at"] != nil })
+	if ev := e.webhookEvent("nfe-a"); ev["error"] != nil && ev["error"] != "" {
+		t.Fatalf("a entrega deveria ser processada sem erro: %v", ev)
+	}
+	if doc := e.document("nfe-a"); doc["status"] != "autorizado" || doc["environment"] != "homologacao" {
+		t.Fatalf("o documento deveria ser atualizado pela varredura: %v", doc)
+	}
+}
+
+func TestWebhookStaysPendingWhenDocumentCannotBeSaved(t *testing.T) {
+	e := newWebhookEnv(t)
+	e.integrate("c1", "producao", nil)
+	e.db.FailNext(http.MethodPost, "focus_documents", 1)
+
+	resp, b := e.do(t, http.MethodPost, "/webhooks/focus?evento=nfe&company_id=c1", []byte(`{"ref":"nfe-a","status":"autorizado"}`), webhookHeader)
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("HTTP %d: %s", resp.StatusCode, b)
+	}
+	e.do(t, http.MethodPost, "/webhooks/focus?evento=nfe&company_id=c1", []byte(`{"ref":"nfe-b","status":"autorizado"}`), webhookHeader)
+	waitFor(t, "a segunda entrega", func() bool { return e.document("nfe-b") != nil })
+
+	if ev := e.webhookEvent("nfe-a"); ev["processed_at"] != nil || e.document("nfe-a") != nil {
+		t.Fatalf("sem o documento gravado, a entrega deveria continuar pendente: %v", ev)
+	}
+
+	e.db.Update("focus_webhook_events", func(r supabasetest.Row) bool { return r["ref"] == "nfe-a" },
+		supabasetest.Row{"received_at": time.Now().Add(-2 * time.Minute).UTC().Format(time.RFC3339Nano)})
+	e.restart(t)
+	waitFor(t, "a varredura", func() bool { return e.webhookEvent("nfe-a")["processed_at"] != nil })
+	if doc := e.document("nfe-a"); doc == nil || doc["status"] != "autorizado" {
+		t.Fatalf("a varredura deveria gravar o documento: %v", doc)
+	}
+}
+
+func TestWebhookSweepDoesNotRollBackDocumentResolvedByRef(t *testing.T) {
+	e := newWebhookEnv(t)
+	e.integrate("c1", "producao", nil)
+	e.db.Insert("focus_documents", supabasetest.Row{
+		"company_id": "c1", "document_type": "nfe", "ref": "nfe-a", "environment": "producao", "status": "autorizado",
+	})
+
+	// entrega mais recente, sem company_id: a empresa vem da ref
+	resp, b := e.do(t, http.MethodPost, "/webhooks/focus?evento=nfe", []byte(`{"ref":"nfe-a","status":"cancelado"}`), webhookHeader)
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("HTTP %d: %s", resp.StatusCode, b)
+	}
+	waitFor(t, "o cancelamento", func() bool { return e.document("nfe-a")["status"] == "cancelado" })
+
+	// entrega mais antiga, ainda pendente, também sem company_id
+	e.db.Insert("focus_webhook_events", supabasetest.Row{
+		"delivery_hash": "entrega-antiga", "document_type": "nfe", "ref": "nfe-a", "status": "autorizado",
+		"payload":     map[string]any{"ref": "nfe-a", "status": "autorizado"},
+		"received_at": time.Now().Add(-5 * time.Minute).UTC().Format(time.RFC3339Nano),
+	})
+	e.restart(t)
+
+	old := func() map[string]any {
+		for _, row := range e.db.Rows("focus_webhook_events") {
+			if row["delivery_hash"] == "entrega-antiga" {
+				return row
+			}
+		}
+		return nil
+	}
+	waitFor(t, "a varredura", func() bool { return old()["processed_at"] != nil })
+	if doc := e.document("nfe-a"); doc["status"] != "cancelado" {
+		t.Fatalf("a entrega antiga não deveria voltar o documento: %v", doc)
+	}
+	if ev := old(); ev["error"] == nil {
+		t.Fatalf("a entrega antiga deveria ser marcada como ignorada: %v", ev)
+	}
+}
@@ -0,0 +1,65 @@
+package supabase
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// FocusRequestLog é uma linha de focus_request_logs (auditoria das chamadas à Focus).
+// Os corpos já chegam aqui com os campos sensíveis mascarados.
+type FocusRequestLog struct {
+	CompanyID    string
+	Environment  string
+	Method       string
+	Path         string
+	Query        string
+	StatusCode   int
+	LatencyMs    int64
+	RequestBody  json.RawMessage
+	ResponseBody json.RawMessage
+	Error        string
+	CreatedAt    time.Time
+}
+
+// InsertFocusRequestLog grava um registro de auditoria. company_id é opcional
+// (consultas de CNPJ/municípios não pertencem a uma empresa).
+func InsertFocusRequestLog(entry FocusRequestLog) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+
+	payload := map[string]any{
+		"environment": entry.Environment,
+		"method":      entry.Method,
+		"path":        entry.Path,
+		"latency_ms":  entry.LatencyMs,
+		"created_at":  entry.CreatedAt,
+	}
+	if entry.CompanyID != "" {
+		payload["company_id"] = entry.CompanyID
+	}
+	if entry.Query != "" {
+		payload["query"] = entry.Query
+	}
+	if entry.StatusCode != 0 {
+		payload["status_code"] = entry.StatusCode
+	}
+	if len(entry.RequestBody) > 0 {
+		payload["request_body"] = entry.RequestBody
+	}
+	if len(entry.ResponseBody) > 0 {
+		payload["response_body"] = entry.ResponseBody
+	}
+	if entry.Error != "" {
+		payload["error"] = entry.Error
+	}
+
+	_, _, err := c.
+		From("focus_request_logs").
+		Insert(payload, false, "", "", "").
+		Execute()
+
+	return err
+}
@@ -0,0 +1,144 @@
+package supabase
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/supabase-community/postgrest-go"
+)
+
+// CertificateAlert é uma linha de focus_certificate_alerts: o aviso de uma janela (30/15/7/1 dias)
+// para o certificado de uma empresa, identificado pela data de validade.
+type CertificateAlert struct {
+	ID             string     `json:"id,omitempty"`
+	CompanyID      string     `json:"company_id"`
+	ExpirationDate time.Time  `json:"expiration_date"`
+	WindowDays     int        `json:"window_days"`
+	DaysLeft       int        `json:"days_left"`
+	NotifiedAt     *time.Time `json:"notified_at,omitempty"`
+	NotifyAttempts int        `json:"notify_attempts"`
+	NotifyError    string     `json:"notify_error,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+}
+
+// ClaimCertificateAlert grava o aviso. Se o aviso da janela já existe, devolve a linha gravada
+// (existing != nil) sem alterar nada.
+func ClaimCertificateAlert(a CertificateAlert) (created *CertificateAlert, existing *CertificateAlert, err error) {
+	c := GetClient()
+	if c == nil {
+		return nil, nil, fmt.Errorf("supabase client não inicializado")
+	}
+
+	data, _, err := c.
+		From("focus_certificate_alerts").
+		Insert(a, false, "", "representation", "").
+		Execute()
+	if err == nil {
+		var rows []CertificateAlert
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return nil, nil, fmt.Errorf("erro ao decodificar focus_certificate_alerts: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil, nil, fmt.Errorf("focus_certificate_alerts: insert sem retorno")
+		}
+		return &rows[0], nil, nil
+	}
+	if !strings.HasPrefix(err.Error(), "(23505)") { // unique_violation
+		return nil, nil, err
+	}
+
+	data, _, err = c.
+		From("focus_certificate_alerts").
+		Select("*", "", false).
+		Eq("company_id", a.CompanyID).
+		Eq("expiration_date", a.ExpirationDate.UTC().Format(time.RFC3339)).
+		Eq("window_days", fmt.Sprint(a.WindowDays)).
+		Limit(1, "").
+		Execute()
+	if err != nil {
+		return nil, nil, err
+	}
+	var rows []CertificateAlert
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, nil, fmt.Errorf("erro ao decodificar focus_certificate_alerts: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("aviso de certificado em conflito não encontrado (company_id=%s)", a.CompanyID)
+	}
+	return nil, &rows[0], nil
+}
+
+// MarkCertificateAlertNotified registra o resultado das notificações (errMsg vazio = todas enviadas).
+func MarkCertificateAlertNotified(a CertificateAlert, errMsg string) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+
+	update := map[string]any{
+		"notify_attempts": a.NotifyAttempts + 1,
+		"notify_error":    nil,
+	}
+	if errMsg != "" {
+		update["notify_error"] = errMsg
+	} else {
+		update["notified_at"] = time.Now().UTC()
+	}
+
+	_, _, err := c.
+		From("focus_certificate_alerts").
+		Update(update, "", "").
+		Eq("id", a.ID).
+		Execute()
+
+	return err
+}
+
+// ListOpenCertificateAlerts lista os avisos ainda não resolvidos (certificado não renovado).
+func ListOpenCertificateAlerts() ([]CertificateAlert, error) {
+	c := GetClient()
+	if c == nil {
+		return nil, fmt.Errorf("supabase client não inicializado")
+	}
+
+	var out []CertificateAlert
+	for from := 0; ; from += listPageSize {
+		data, _, err := c.
+			From("focus_certificate_alerts").
+			Select("*", "", false).
+			Is("resolved_at", "null").
+			Order("id", &postgrest.OrderOpts{Ascending: true}).
+			Range(from, from+listPageSize-1, "").
+			Execute()
+		if err != nil {
+			return nil, err
+		}
+
+		var rows []CertificateAlert
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar focus_certificate_alerts: %w", err)
+		}
+		out = append(out, rows...)
+		if len(rows) < listPageSize {
+			return out, nil
+		}
+	}
+}
+
+// ResolveCertificateAlert encerra o aviso (a empresa trocou de certificado).
+func ResolveCertificateAlert(id string) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+
+	_, _, err := c.
+		From("focus_certificate_alerts").
+		Update(map[string]any{"resolved_at": time.Now().UTC()}, "", "").
+		Eq("id", id).
+		Execute()
+
+	return err
+}
@@ -0,0 +1,273 @@
+package supabase
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/supabase-community/postgrest-go"
+)
+
+// FocusDocument é uma linha de focus_documents: um documento fiscal emitido via Focus
+// (nfse, nfsen, nfe, nfce, cte, mdfe), identificado por (company_id, document_type, ref).
+type FocusDocument struct {
+	CompanyID         string          `json:"company_id"`
+	DocumentType      string          `json:"document_type"`
+	Ref               string          `json:"ref"`
+	Environment       string          `json:"environment,omitempty"`
+	Status            string          `json:"status,omitempty"`
+	Numero            string          `json:"numero,omitempty"`
+	Serie             string          `json:"serie,omitempty"`
+	Chave             string          `json:"chave,omitempty"`
+	CodigoVerificacao string          `json:"codigo_verificacao,omitempty"`
+	Mensagem          string          `json:"mensagem,omitempty"`
+	CaminhoXML        string          `json:"caminho_xml,omitempty"`
+	CaminhoPDF        string          `json:"caminho_pdf,omitempty"`
+	XMLStoragePath    string          `json:"xml_storage_path,omitempty"`
+	PDFStoragePath    string          `json:"pdf_storage_path,omitempty"`
+	ArchivedAt        *time.Time      `json:"archived_at,omitempty"`
+	ArchiveAttempts   int             `json:"archive_attempts,omitempty"`
+	ArchiveError      string          `json:"archive_error,omitempty"`
+	Payload           json.RawMessage `json:"payload,omitempty" swaggertype:"object"`
+	Response          json.RawMessage `json:"response,omitempty" swaggertype:"object"`
+	CreatedAt         *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt         *time.Time      `json:"updated_at,omitempty"`
+}
+
+// UpsertFocusDocument cria ou atualiza o documento (chave company_id + document_type + ref).
+// Campos vazios não sobrescrevem valores já gravados (ex: payload da emissão ao consultar status).
+func UpsertFocusDocument(doc FocusDocument) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+	if doc.CompanyID == "" || doc.DocumentType == "" || doc.Ref == "" {
+		return fmt.Errorf("company_id, document_type e ref são obrigatórios")
+	}
+
+	now := time.Now().UTC()
+	doc.UpdatedAt = &now
+	doc.CreatedAt = nil
+
+	_, _, err := c.
+		From("focus_documents").
+		Upsert(doc, "company_id,document_type,ref", "", "").
+		Execute()
+
+	return err
+}
+
+// GetFocusDocument busca um documento. Retorna (nil, nil) se não existir.
+func GetFocusDocument(companyID, documentType, ref string) (*FocusDocument, error) {
+	c := GetClient()
+	if c == nil {
+		return nil, fmt.Errorf("supabase client não inicializado")
+	}
+
+	data, _, err := c.
+		From("focus_documents").
+		Select("*", "", false).
+		Eq("company_id", companyID).
+		Eq("document_type", documentType).
+		Eq("ref", ref).
+		Limit(1, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []FocusDocument
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar focus_documents: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+// FocusDocumentsFilter filtra a listagem de documentos de uma empresa (histórico).
+type FocusDocumentsFilter struct {
+	CompanyID    string
+	DocumentType string
+	Status       string
+	Limit        int
+	Offset       int
+}
+
+// ListFocusDocuments lista os documentos de uma empresa, do mais recente ao mais antigo.
+// Retorna também o total de linhas do filtro (para paginação).
+func ListFocusDocuments(f FocusDocumentsFilter) ([]FocusDocument, int64, error) {
+	c := GetClient()
+	if c == nil {
+		return nil, 0, fmt.Errorf("supabase client não inicializado")
+	}
+	if f.CompanyID == "" {
+		return nil, 0, fmt.Errorf("company_id é obrigatório")
+	}
+	if f.Limit <= 0 {
+		f.Limit = 50
+	}
+
+	q := c.
+		From("focus_documents").
+		Select("*", "exact", false).
+		Eq("company_id", f.CompanyID)
+	if f.DocumentType != "" {
+		q = q.Eq("document_type", f.DocumentType)
+	}
+	if f.Status != "" {
+		q = q.Eq("status", f.Status)
+	}
+
+	data, count, err := q.
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		Range(f.Offset, f.Offset+f.Limit-1, "").
+		Execute()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []FocusDocument
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, 0, fmt.Errorf("erro ao decodificar focus_documents: %w", err)
+	}
+	return rows, count, nil
+}
+
+// ListFocusDocumentsToArchive devolve documentos autorizados/cancelados com XML na Focus que ainda não foram
+// copiados para o Storage, dos mais antigos aos mais novos, ignorando os que já falharam maxAttempts vezes.
+func ListFocusDocumentsToArchive(maxAttempts, limit int) ([]FocusDocument, error) {
+	c := GetClient()
+	if c == nil {
+		return nil, fmt.Errorf("supabase client não inicializado")
+	}
+
+	data, _, err := c.
+		From("focus_documents").
+		Select("company_id,document_type,ref,environment,status,caminho_xml,caminho_pdf,archive_attempts,created_at", "", false).
+		In("status", []string{"autorizado", "cancelado"}).
+		Not("caminho_xml", "is", "null").
+		Is("archived_at", "null").
+		Lt("archive_attempts", strconv.Itoa(maxAttempts)).
+		Order("created_at", &postgrest.OrderOpts{Ascending: true}).
+		Limit(limit, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []FocusDocument
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar focus_documents: %w", err)
+	}
+	return rows, nil
+}
+
+// MarkFocusDocumentArchived grava os caminhos no Storage e a data do arquivamento.
+func MarkFocusDocumentArchived(doc FocusDocument, xmlPath, pdfPath string) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+
+	now := time.Now().UTC()
+	update := map[string]any{
+		"xml_storage_path": xmlPath,
+		"archived_at":      now,
+		"archive_error":    nil,
+		"updated_at":       now,
+	}
+	if pdfPath != "" {
+		update["pdf_storage_path"] = pdfPath
+	}
+
+	_, _, err := c.
+		From("focus_documents").
+		Update(update, "", "").
+		Eq("company_id", doc.CompanyID).
+		Eq("document_type", doc.DocumentType).
+		Eq("ref", doc.Ref).
+		Execute()
+
+	return err
+}
+
+// MarkFocusDocumentArchiveFailed conta uma tentativa de arquivamento que falhou (e guarda o motivo).
+func MarkFocusDocumentArchiveFailed(doc FocusDocument, errMsg string) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+
+	_, _, err := c.
+		From("focus_documents").
+		Update(map[string]any{
+			"archive_attempts": doc.ArchiveAttempts + 1,
+			"archive_error":    errMsg,
+		}, "", "").
+		Eq("company_id", doc.CompanyID).
+		Eq("document_type", doc.DocumentType).
+		Eq("ref", doc.Ref).
+		Execute()
+
+	return err
+}
+
+// FocusDocumentEvent é uma linha de focus_document_events: uma operação ou mudança de status de um documento.
+type FocusDocumentEvent struct {
+	CompanyID      string          `json:"company_id"`
+	DocumentType   string          `json:"document_type"`
+	Ref            string          `json:"ref"`
+	Event          string          `json:"event"`
+	StatusAnterior string          `json:"status_anterior,omitempty"`
+	Status         string          `json:"status,omitempty"`
+	Mensagem       string          `json:"mensagem,omitempty"`
+	Payload        json.RawMessage `json:"payload,omitempty" swaggertype:"object"`
+	CreatedAt      *time.Time      `json:"created_at,omitempty"`
+}
+
+func InsertFocusDocumentEvent(ev FocusDocumentEvent) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+	if ev.CompanyID == "" || ev.DocumentType == "" || ev.Ref == "" || ev.Event == "" {
+		return fmt.Errorf("company_id, document_type, ref e event são obrigatórios")
+	}
+	ev.CreatedAt = nil
+
+	_, _, err := c.
+		From("focus_document_events").
+		Insert(ev, false, "", "", "").
+		Execute()
+
+	return err
+}
+
+// ListFocusDocumentEvents devolve a linha do tempo de um documento, em ordem cronológica.
+func ListFocusDocumentEvents(companyID, documentType, ref string) ([]FocusDocumentEvent, error) {
+	c := GetClient()
+	if c == nil {
+		return nil, fmt.Errorf("supabase client não inicializado")
+	}
+
+	data, _, err := c.
+		From("focus_document_events").
+		Select("*", "", false).
+		Eq("company_id", companyID).
+		Eq("document_type", documentType).
+		Eq("ref", ref).
+		Order("created_at", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []FocusDocumentEvent
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar focus_document_events: %w", err)
+	}
+	return rows, nil
+}
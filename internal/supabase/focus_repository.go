@@ -3,27 +3,171 @@ package supabase
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
+
+	"github.com/supabase-community/postgrest-go"
 )
 
-func InsertFocusIntegration(companyID string, focusCompanyID string, tokenFocusCompany string) error {
+// FocusIntegration representa uma linha de focus_integration (empresa local <-> empresa na Focus).
+type FocusIntegration struct {
+	ID                string `json:"id"`
+	CompanyID         string `json:"company_id"`
+	FocusCompanyID    string `json:"focus_company_id"`
+	TokenFocusCompany string `json:"token_focus_company"`
+	// Environment: "producao" ou "homologacao" (ambiente em que a empresa foi integrada).
+	Environment string `json:"environment"`
+}
+
+// UpsertFocusIntegration grava a integração da empresa: focus_integration tem uma linha por company_id
+// (índice único), então um novo cadastro ou uma adoção substitui a linha existente em vez de criar outra.
+// environment pode ser "producao" ou "homologacao" (vazio = producao, default da coluna).
+func UpsertFocusIntegration(companyID string, focusCompanyID string, tokenFocusCompany string, environment string) error {
 	c := GetClient()
 	if c == nil {
 		return fmt.Errorf("supabase client não inicializado")
 	}
 
+	payload := map[string]any{
+		"company_id":          companyID,
+		"focus_company_id":    focusCompanyID,
+		"token_focus_company": tokenFocusCompany,
+	}
+	if environment == "" {
+		environment = "producao"
+	}
+	payload["environment"] = environment
+
 	_, _, err := c.
 		From("focus_integration").
-		Insert(map[string]any{
-			"company_id":          companyID,
-			"focus_company_id":    focusCompanyID,
-			"token_focus_company": tokenFocusCompany,
-		}, false, "", "", "").
+		Upsert(payload, "company_id", "", "").
 		Execute()
 
 	return err
 }
 
+// GetFocusIntegration busca a integração Focus mais recente de uma empresa.
+// Retorna (nil, nil) quando a empresa ainda não foi integrada.
+func GetFocusIntegration(companyID string) (*FocusIntegration, error) {
+	c := GetClient()
+	if c == nil {
+		return nil, fmt.Errorf("supabase client não inicializado")
+	}
+	if companyID == "" {
+		return nil, fmt.Errorf("company_id é obrigatório")
+	}
+
+	data, _, err := c.
+		From("focus_integration").
+		Select("*", "", false).
+		Eq("company_id", companyID).
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		Limit(1, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := decodeFocusIntegrations(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+func decodeFocusIntegrations(data []byte) ([]FocusIntegration, error) {
+	// focus_company_id pode vir como número ou texto, dependendo do tipo da coluna.
+	var rows []struct {
+		ID                any    `json:"id"`
+		CompanyID         string `json:"company_id"`
+		FocusCompanyID    any    `json:"focus_company_id"`
+		TokenFocusCompany string `json:"token_focus_company"`
+		Environment       string `json:"environment"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar focus_integration: %w", err)
+	}
+
+	out := make([]FocusIntegration, 0, len(rows))
+	for _, row := range rows {
+		fi := FocusIntegration{
+			ID:                anyToString(row.ID),
+			CompanyID:         row.CompanyID,
+			FocusCompanyID:    anyToString(row.FocusCompanyID),
+			TokenFocusCompany: row.TokenFocusCompany,
+			Environment:       row.Environment,
+		}
+		if fi.Environment == "" {
+			fi.Environment = "producao"
+		}
+		out = append(out, fi)
+	}
+	return out, nil
+}
+
+func anyToString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatInt(int64(t), 10)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// GetCompanyCNPJ lê o CNPJ (somente números) e a razão social de companies. Devolve "" nos campos
+// ausentes (ou nos dois, se a empresa não existir).
+func GetCompanyCNPJ(companyID string) (cnpj, legalName string, err error) {
+	c := GetClient()
+	if c == nil {
+		return "", "", fmt.Errorf("supabase client não inicializado")
+	}
+
+	data, _, err := c.
+		From("companies").
+		Select("cnpj,legal_name", "", false).
+		Eq("id", companyID).
+		Limit(1, "").
+		Execute()
+	if err != nil {
+		return "", "", err
+	}
+
+	var rows []struct {
+		CNPJ      *string `json:"cnpj"`
+		LegalName *string `json:"legal_name"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return "", "", fmt.Errorf("erro ao decodificar companies: %w", err)
+	}
+	if len(rows) == 0 {
+		return "", "", nil
+	}
+	if rows[0].CNPJ != nil {
+		cnpj = onlyDigits(*rows[0].CNPJ)
+	}
+	if rows[0].LegalName != nil {
+		legalName = *rows[0].LegalName
+	}
+	return cnpj, legalName, nil
+}
+
+func onlyDigits(s string) string {
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			out = append(out, s[i])
+		}
+	}
+	return string(out)
+}
+
 func UpdateCompanyFocusIntegrated(companyID string, integrated bool) error {
 	c := GetClient()
 	if c == nil {
@@ -0,0 +1,126 @@
+package supabase
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IdempotencyKey é uma linha de focus_idempotency_keys: o resultado de uma requisição identificada
+// pelo header Idempotency-Key (por empresa). Status "pending" enquanto a requisição original processa.
+type IdempotencyKey struct {
+	CompanyID       string            `json:"company_id"`
+	Key             string            `json:"idempotency_key"`
+	Operation       string            `json:"operation"`
+	RequestHash     string            `json:"request_hash"`
+	Status          string            `json:"status"`
+	ResponseStatus  int               `json:"response_status,omitempty"`
+	ResponseBody    json.RawMessage   `json:"response_body,omitempty"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	CreatedAt       *time.Time        `json:"created_at,omitempty"`
+	CompletedAt     *time.Time        `json:"completed_at,omitempty"`
+}
+
+const (
+	IdempotencyPending   = "pending"
+	IdempotencyCompleted = "completed"
+)
+
+// ClaimIdempotencyKey reserva a chave (status pending). Se a chave já existe, devolve a linha gravada
+// (existing != nil) e não altera nada.
+func ClaimIdempotencyKey(k IdempotencyKey) (existing *IdempotencyKey, err error) {
+	c := GetClient()
+	if c == nil {
+		return nil, fmt.Errorf("supabase client não inicializado")
+	}
+	if k.CompanyID == "" || k.Key == "" {
+		return nil, fmt.Errorf("company_id e idempotency_key são obrigatórios")
+	}
+	k.Status = IdempotencyPending
+	k.CreatedAt = nil
+
+	_, _, err = c.
+		From("focus_idempotency_keys").
+		Insert(k, false, "", "", "").
+		Execute()
+	if err == nil {
+		return nil, nil
+	}
+	if !strings.HasPrefix(err.Error(), "(23505)") { // unique_violation
+		return nil, err
+	}
+	existing, err = getIdempotencyKey(k.CompanyID, k.Key)
+	if err == nil && existing == nil {
+		// removida entre o insert e a leitura (liberada pela requisição original)
+		return nil, fmt.Errorf("idempotency_key %s em uso; tente novamente", k.Key)
+	}
+	return existing, err
+}
+
+func getIdempotencyKey(companyID, key string) (*IdempotencyKey, error) {
+	c := GetClient()
+	if c == nil {
+		return nil, fmt.Errorf("supabase client não inicializado")
+	}
+
+	data, _, err := c.
+		From("focus_idempotency_keys").
+		Select("*", "", false).
+		Eq("company_id", companyID).
+		Eq("idempotency_key", key).
+		Limit(1, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []IdempotencyKey
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar focus_idempotency_keys: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+// CompleteIdempotencyKey grava o resultado final da requisição original.
+func CompleteIdempotencyKey(companyID, key string, status int, body json.RawMessage, headers map[string]string) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+
+	_, _, err := c.
+		From("focus_idempotency_keys").
+		Update(map[string]any{
+			"status":           IdempotencyCompleted,
+			"response_status":  status,
+			"response_body":    body,
+			"response_headers": headers,
+			"completed_at":     time.Now().UTC(),
+		}, "", "").
+		Eq("company_id", companyID).
+		Eq("idempotency_key", key).
+		Execute()
+
+	return err
+}
+
+// ReleaseIdempotencyKey remove a chave (a requisição falhou de forma transitória e pode ser repetida).
+func ReleaseIdempotencyKey(companyID, key string) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+
+	_, _, err := c.
+		From("focus_idempotency_keys").
+		Delete("", "").
+		Eq("company_id", companyID).
+		Eq("idempotency_key", key).
+		Execute()
+
+	return err
+}
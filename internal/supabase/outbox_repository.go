@@ -0,0 +1,240 @@
+package supabase
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/supabase-community/postgrest-go"
+)
+
+// OutboxJob é uma linha de focus_integration_outbox: um passo de gravação no Supabase que precisa
+// acontecer depois de uma operação bem-sucedida na Focus (ex: salvar focus_integration).
+// DependsOn aponta o passo anterior da mesma operação: o passo só roda depois dele.
+// Um passo em execução (running) pertence a quem tem LockToken até LockedUntil.
+type OutboxJob struct {
+	ID            string          `json:"id,omitempty"`
+	CompanyID     string          `json:"company_id"`
+	Step          string          `json:"step"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	DependsOn     string          `json:"depends_on,omitempty"`
+	LockToken     string          `json:"lock_token,omitempty"`
+	LockedUntil   *time.Time      `json:"locked_until,omitempty"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"last_error,omitempty"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	CreatedAt     *time.Time      `json:"created_at,omitempty"`
+	CompletedAt   *time.Time      `json:"completed_at,omitempty"`
+}
+
+const (
+	OutboxPending = "pending"
+	OutboxRunning = "running"
+	OutboxDone    = "done"
+)
+
+// InsertOutboxJobs grava os passos e devolve as linhas criadas (com id), na mesma ordem.
+func InsertOutboxJobs(jobs []OutboxJob) ([]OutboxJob, error) {
+	c := GetClient()
+	if c == nil {
+		return nil, fmt.Errorf("supabase client não inicializado")
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	data, _, err := c.
+		From("focus_integration_outbox").
+		Insert(jobs, false, "", "representation", "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []OutboxJob
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar focus_integration_outbox: %w", err)
+	}
+	return rows, nil
+}
+
+// ListDueOutboxJobs lista os passos pendentes cujo next_attempt_at já passou, dos mais antigos para os mais novos.
+func ListDueOutboxJobs(limit int) ([]OutboxJob, error) {
+	c := GetClient()
+	if c == nil {
+		return nil, fmt.Errorf("supabase client não inicializado")
+	}
+
+	data, _, err := c.
+		From("focus_integration_outbox").
+		Select("*", "", false).
+		Eq("status", OutboxPending).
+		Lte("next_attempt_at", time.Now().UTC().Format(time.RFC3339)).
+		Order("created_at", &postgrest.OrderOpts{Ascending: true}).
+		Limit(limit, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []OutboxJob
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar focus_integration_outbox: %w", err)
+	}
+	return rows, nil
+}
+
+// GetOutboxJobStatus devolve o status do passo ("" se ele não existe mais).
+func GetOutboxJobStatus(id string) (string, error) {
+	c := GetClient()
+	if c == nil {
+		return "", fmt.Errorf("supabase client não inicializado")
+	}
+
+	data, _, err := c.
+		From("focus_integration_outbox").
+		Select("status", "", false).
+		Eq("id", id).
+		Execute()
+	if err != nil {
+		return "", err
+	}
+
+	var rows []struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return "", fmt.Errorf("erro ao decodificar focus_integration_outbox: %w", err)
+	}
+	if len(rows) == 0 {
+		return "", nil
+	}
+	return rows[0].Status, nil
+}
+
+// ClaimOutboxJob reserva um passo pendente para execução (status running, lock_token, locked_until).
+// Devolve false se outra instância já o reservou.
+func ClaimOutboxJob(id, lockToken string, lockedUntil time.Time) (bool, error) {
+	c := GetClient()
+	if c == nil {
+		return false, fmt.Errorf("supabase client não inicializado")
+	}
+
+	data, _, err := c.
+		From("focus_integration_outbox").
+		Update(map[string]any{
+			"status":       OutboxRunning,
+			"lock_token":   lockToken,
+			"locked_until": lockedUntil.UTC(),
+		}, "", "").
+		Eq("id", id).
+		Eq("status", OutboxPending).
+		Execute()
+	if err != nil {
+		return false, err
+	}
+
+	var rows []json.RawMessage
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return false, fmt.Errorf("erro ao decodificar focus_integration_outbox: %w", err)
+	}
+	return len(rows) == 1, nil
+}
+
+// ReleaseExpiredOutboxJobs devolve para pending os passos reservados cuja reserva venceu
+// (instância que caiu no meio da execução) e informa quantos foram liberados.
+func ReleaseExpiredOutboxJobs() (int, error) {
+	c := GetClient()
+	if c == nil {
+		return 0, fmt.Errorf("supabase client não inicializado")
+	}
+
+	data, _, err := c.
+		From("focus_integration_outbox").
+		Update(map[string]any{
+			"status":       OutboxPending,
+			"lock_token":   nil,
+			"locked_until": nil,
+		}, "", "").
+		Eq("status", OutboxRunning).
+		Lt("locked_until", time.Now().UTC().Format(time.RFC3339Nano)).
+		Execute()
+	if err != nil {
+		return 0, err
+	}
+
+	var rows []json.RawMessage
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return 0, fmt.Errorf("erro ao decodificar focus_integration_outbox: %w", err)
+	}
+	return len(rows), nil
+}
+
+// ReleaseOutboxJobs devolve para pending, sem contar tentativa, os passos ainda reservados com lockToken.
+func ReleaseOutboxJobs(lockToken string) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+
+	_, _, err := c.
+		From("focus_integration_outbox").
+		Update(map[string]any{
+			"status":       OutboxPending,
+			"lock_token":   nil,
+			"locked_until": nil,
+		}, "", "").
+		Eq("lock_token", lockToken).
+		Eq("status", OutboxRunning).
+		Execute()
+
+	return err
+}
+
+// MarkOutboxJobDone conclui o passo reservado com lockToken.
+func MarkOutboxJobDone(id, lockToken string) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+
+	_, _, err := c.
+		From("focus_integration_outbox").
+		Update(map[string]any{
+			"status":       OutboxDone,
+			"lock_token":   nil,
+			"locked_until": nil,
+			"last_error":   nil,
+			"completed_at": time.Now().UTC(),
+		}, "", "").
+		Eq("id", id).
+		Eq("lock_token", lockToken).
+		Execute()
+
+	return err
+}
+
+// RescheduleOutboxJob registra a falha de uma tentativa, libera a reserva e agenda a próxima.
+func RescheduleOutboxJob(id, lockToken string, attempts int, errMsg string, next time.Time) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+
+	_, _, err := c.
+		From("focus_integration_outbox").
+		Update(map[string]any{
+			"status":          OutboxPending,
+			"lock_token":      nil,
+			"locked_until":    nil,
+			"attempts":        attempts,
+			"last_error":      errMsg,
+			"next_attempt_at": next.UTC(),
+		}, "", "").
+		Eq("id", id).
+		Eq("lock_token", lockToken).
+		Execute()
+
+	return err
+}
@@ -0,0 +1,188 @@
+package supabase
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/supabase-community/postgrest-go"
+)
+
+// Linhas lidas por requisição nas listagens completas (o PostgREST costuma limitar a 1000).
+const listPageSize = 1000
+
+// CompanyFocusStatus é o recorte de companies usado na reconciliação com a Focus.
+type CompanyFocusStatus struct {
+	ID              string `json:"id"`
+	CNPJ            string `json:"cnpj"`
+	FocusIntegrated bool   `json:"focus_integrated"`
+}
+
+// CertificateDates são as datas do certificado mais recente de uma empresa.
+type CertificateDates struct {
+	CompanyID      string     `json:"company_id"`
+	EffectiveDate  *time.Time `json:"effective_date"`
+	ExpirationDate *time.Time `json:"expiration_date"`
+}
+
+// ListCompaniesFocusStatus lista todas as empresas (CNPJ somente com números).
+func ListCompaniesFocusStatus() ([]CompanyFocusStatus, error) {
+	c := GetClient()
+	if c == nil {
+		return nil, fmt.Errorf("supabase client não inicializado")
+	}
+
+	var out []CompanyFocusStatus
+	for from := 0; ; from += listPageSize {
+		data, _, err := c.
+			From("companies").
+			Select("id,cnpj,focus_integrated", "", false).
+			Order("id", &postgrest.OrderOpts{Ascending: true}).
+			Range(from, from+listPageSize-1, "").
+			Execute()
+		if err != nil {
+			return nil, err
+		}
+
+		var rows []struct {
+			ID              string  `json:"id"`
+			CNPJ            *string `json:"cnpj"`
+			FocusIntegrated *bool   `json:"focus_integrated"`
+		}
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar companies: %w", err)
+		}
+		for _, row := range rows {
+			company := CompanyFocusStatus{ID: row.ID}
+			if row.CNPJ != nil {
+				company.CNPJ = onlyDigits(*row.CNPJ)
+			}
+			if row.FocusIntegrated != nil {
+				company.FocusIntegrated = *row.FocusIntegrated
+			}
+			out = append(out, company)
+		}
+		if len(rows) < listPageSize {
+			return out, nil
+		}
+	}
+}
+
+// ListFocusIntegrations lista a integração mais recente de cada empresa (a mesma que GetFocusIntegration devolve).
+func ListFocusIntegrations() ([]FocusIntegration, error) {
+	c := GetClient()
+	if c == nil {
+		return nil, fmt.Errorf("supabase client não inicializado")
+	}
+
+	seen := map[string]bool{}
+	var out []FocusIntegration
+	for from := 0; ; from += listPageSize {
+		data, _, err := c.
+			From("focus_integration").
+			Select("*", "", false).
+			Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+			Range(from, from+listPageSize-1, "").
+			Execute()
+		if err != nil {
+			return nil, err
+		}
+
+		rows, err := decodeFocusIntegrations(data)
+		if err != nil {
+			return nil, err
+		}
+		for _, fi := range rows {
+			if !seen[fi.CompanyID] {
+				seen[fi.CompanyID] = true
+				out = append(out, fi)
+			}
+		}
+		if len(rows) < listPageSize {
+			return out, nil
+		}
+	}
+}
+
+// UpdateFocusIntegrationToken troca o token da empresa gravado em focus_integration.
+func UpdateFocusIntegrationToken(id, token string) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+	if id == "" || token == "" {
+		return fmt.Errorf("id e token são obrigatórios")
+	}
+
+	_, _, err := c.
+		From("focus_integration").
+		Update(map[string]any{"token_focus_company": token}, "", "").
+		Eq("id", id).
+		Execute()
+
+	return err
+}
+
+// UpdateFocusIntegrationCompany aponta a integração existente (mesmo ambiente) para outra empresa da Focus.
+func UpdateFocusIntegrationCompany(id, focusCompanyID, token string) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+	if id == "" || focusCompanyID == "" || token == "" {
+		return fmt.Errorf("id, focus_company_id e token são obrigatórios")
+	}
+
+	_, _, err := c.
+		From("focus_integration").
+		Update(map[string]any{"focus_company_id": focusCompanyID, "token_focus_company": token}, "", "").
+		Eq("id", id).
+		Execute()
+
+	return err
+}
+
+// ListCertificateDates lê as datas do certificado de todas as empresas.
+// Os certificados ficam em `company_private` (fora do PostgREST), então a leitura é via RPC
+// (ver database/rpc_service_list_certificate_dates.sql).
+func ListCertificateDates() ([]CertificateDates, error) {
+	raw, err := RpcPublic("rpc_service_list_certificate_dates", nil)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar datas de certificado via RPC: %w", err)
+	}
+	if raw == "" {
+		return nil, nil
+	}
+
+	// a coluna é timestamp (sem fuso): o PostgREST devolve "2026-11-12T14:33:00"
+	var rows []struct {
+		CompanyID      string  `json:"company_id"`
+		EffectiveDate  *string `json:"effective_date"`
+		ExpirationDate *string `json:"expiration_date"`
+	}
+	if err := json.Unmarshal([]byte(raw), &rows); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar datas de certificado: %w", err)
+	}
+
+	out := make([]CertificateDates, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, CertificateDates{
+			CompanyID:      row.CompanyID,
+			EffectiveDate:  parseTimestamp(row.EffectiveDate),
+			ExpirationDate: parseTimestamp(row.ExpirationDate),
+		})
+	}
+	return out, nil
+}
+
+func parseTimestamp(s *string) *time.Time {
+	if s == nil || *s == "" {
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02"} {
+		if t, err := time.Parse(layout, *s); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package supabase
+
+import (
+	"fmt"
+	"io"
+
+	storage_go "github.com/supabase-community/storage-go"
+)
+
+// UploadFile grava (ou substitui) um objeto no bucket do Supabase Storage.
+func UploadFile(bucket, path, contentType string, data io.Reader) error {
+	c := GetClient()
+	if c == nil || c.Storage == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+
+	upsert := true
+	_, err := c.Storage.UploadFile(bucket, path, data, storage_go.FileOptions{
+		ContentType: &contentType,
+		Upsert:      &upsert,
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao enviar %s/%s para o Storage: %w", bucket, path, err)
+	}
+	return nil
+}
+
+// SignedURL devolve uma URL temporária (expiresIn segundos) para baixar um objeto de bucket privado.
+func SignedURL(bucket, path string, expiresIn int) (string, error) {
+	c := GetClient()
+	if c == nil || c.Storage == nil {
+		return "", fmt.Errorf("supabase client não inicializado")
+	}
+
+	resp, err := c.Storage.CreateSignedUrl(bucket, path, expiresIn)
+	if err != nil {
+		return "", fmt.Errorf("erro ao gerar URL assinada de %s/%s: %w", bucket, path, err)
+	}
+	return resp.SignedURL, nil
+}
@@ -0,0 +1,618 @@
+// Package supabasetest implementa um Supabase falso (PostgREST em memória) para testes de handlers,
+// do outbox, da reconciliação e dos jobs que gravam no Supabase.
+//
+// Emula o subconjunto do PostgREST usado por internal/supabase:
+//   - /rest/v1/{tabela}: select (colunas simples), filtros eq/neq/lt/lte/gt/gte/is/in/not.is, order, limit/offset,
+//     count=exact (Content-Range), insert (objeto ou lista), upsert com on_conflict, update e delete
+//   - /rest/v1/rpc/{nome}: funções registradas com HandleRPC
+//
+// Tabelas não precisam ser declaradas. Linhas novas recebem id (uuid) e created_at quando não informados.
+// Restrições únicas (unique_violation, código 23505) são declaradas com Unique, espelhando as migrações.
+// Falhas podem ser injetadas com FailNext (ex: Supabase fora do ar durante um passo).
+package supabasetest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seuuser/focus-integration-service/internal/supabase"
+)
+
+// Row é uma linha de tabela (JSON decodificado).
+type Row = map[string]any
+
+// RPCFunc implementa uma função chamada por /rest/v1/rpc/{nome}. O retorno é serializado em JSON (nil = corpo vazio).
+type RPCFunc func(args map[string]any) (any, error)
+
+type fault struct {
+	method string
+	table  string
+	times  int
+}
+
+// Server é o Supabase falso.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	tables  map[string][]Row
+	unique  map[string][][]string
+	rpcs    map[string]RPCFunc
+	faults  []*fault
+	counter int
+}
+
+// NewServer sobe o Supabase falso num httptest.Server. Chame Close ao final.
+func NewServer() *Server {
+	s := &Server{
+		tables: map[string][]Row{},
+		unique: map[string][][]string{},
+		rpcs:   map[string]RPCFunc{},
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Start sobe o servidor e aponta o client global de internal/supabase para ele (SUPABASE_URL/SUPABASE_KEY).
+// O servidor é fechado ao final do teste.
+func Start(t testing.TB) *Server {
+	t.Helper()
+	s := NewServer()
+	t.Cleanup(s.Close)
+	t.Setenv("SUPABASE_URL", s.URL)
+	t.Setenv("SUPABASE_KEY", "service-role-key")
+	t.Setenv("SUPABASE_SCHEMA", "company")
+	supabase.InitClient()
+	return s
+}
+
+// Unique declara uma restrição única (as colunas juntas) na tabela.
+func (s *Server) Unique(table string, columns ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unique[table] = append(s.unique[table], columns)
+}
+
+// HandleRPC registra a função name.
+func (s *Server) HandleRPC(name string, fn RPCFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rpcs[name] = fn
+}
+
+// FailNext faz as próximas n requisições à tabela (ou "rpc/{nome}") com o método informado (vazio = qualquer)
+// responderem 503, como o PostgREST fora do ar.
+func (s *Server) FailNext(method, table string, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{method: method, table: table, times: n})
+}
+
+// Insert grava linhas diretamente (sem restrições), preenchendo id e created_at.
+func (s *Server) Insert(table string, rows ...Row) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, row := range rows {
+		s.tables[table] = append(s.tables[table], s.withDefaults(cloneRow(row)))
+	}
+}
+
+// Rows devolve uma cópia das linhas da tabela, na ordem de gravação.
+func (s *Server) Rows(table string) []Row {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Row, 0, len(s.tables[table]))
+	for _, row := range s.tables[table] {
+		out = append(out, cloneRow(row))
+	}
+	return out
+}
+
+// Update altera diretamente as linhas da tabela em que match devolve true.
+func (s *Server) Update(table string, match func(Row) bool, set Row) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, row := range s.tables[table] {
+		if match(row) {
+			for k, v := range normalize(set) {
+				row[k] = v
+			}
+		}
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, ok := strings.CutPrefix(r.URL.Path, "/rest/v1/")
+	if !ok {
+		writeError(w, http.StatusNotFound, "PGRST000", "rota não emulada: "+r.URL.Path)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.takeFault(r.Method, name) {
+		writeError(w, http.StatusServiceUnavailable, "PGRST503", "falha injetada")
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	if rpc, isRPC := strings.CutPrefix(name, "rpc/"); isRPC {
+		s.serveRPC(w, rpc, body)
+		return
+	}
+
+	q := r.URL.Query()
+	filters, err := parseFilters(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "PGRST100", err.Error())
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.serveSelect(w, r, name, q, filters)
+	case http.MethodPost:
+		s.serveInsert(w, r, name, q, body)
+	case http.MethodPatch:
+		s.serveUpdate(w, name, filters, body)
+	case http.MethodDelete:
+		s.serveDelete(w, name, filters)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "PGRST000", "método não emulado: "+r.Method)
+	}
+}
+
+func (s *Server) takeFault(method, table string) bool {
+	for i, f := range s.faults {
+		if (f.method != "" && f.method != method) || f.table != table {
+			continue
+		}
+		f.times--
+		if f.times <= 0 {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		return true
+	}
+	return false
+}
+
+func (s *Server) serveRPC(w http.ResponseWriter, name string, body []byte) {
+	fn := s.rpcs[name]
+	if fn == nil {
+		writeError(w, http.StatusNotFound, "PGRST202", "função não encontrada: "+name)
+		return
+	}
+	var args map[string]any
+	_ = json.Unmarshal(body, &args)
+
+	// a função pode chamar os métodos do Server (Rows/Insert): roda sem o lock
+	s.mu.Unlock()
+	out, err := fn(args)
+	s.mu.Lock()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "P0001", err.Error())
+		return
+	}
+	if out == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) serveSelect(w http.ResponseWriter, r *http.Request, table string, q map[string][]string, filters []filter) {
+	var rows []Row
+	for _, row := range s.tables[table] {
+		if matchAll(row, filters) {
+			rows = append(rows, row)
+		}
+	}
+	if order := first(q["order"]); order != "" {
+		sortRows(rows, order)
+	}
+
+	total := len(rows)
+	offset, _ := strconv.Atoi(first(q["offset"]))
+	offset = min(max(offset, 0), total)
+	end := total
+	if limit := first(q["limit"]); limit != "" {
+		n, _ := strconv.Atoi(limit)
+		end = min(offset+n, total)
+	}
+	rows = rows[offset:end]
+
+	if strings.Contains(r.Header.Get("Prefer"), "count=exact") {
+		w.Header().Set("Content-Range", fmt.Sprintf("%d-%d/%d", offset, max(end-1, offset), total))
+	}
+	writeJSON(w, http.StatusOK, project(rows, first(q["select"])))
+}
+
+func (s *Server) serveInsert(w http.ResponseWriter, r *http.Request, table string, q map[string][]string, body []byte) {
+	var incoming []Row
+	if err := json.Unmarshal(body, &incoming); err != nil {
+		var one Row
+		if err := json.Unmarshal(body, &one); err != nil {
+			writeError(w, http.StatusBadRequest, "PGRST102", "corpo inválido")
+			return
+		}
+		incoming = []Row{one}
+	}
+
+	upsert := strings.Contains(r.Header.Get("Prefer"), "resolution=merge-duplicates")
+	var conflictCols []string
+	if oc := first(q["on_conflict"]); oc != "" {
+		conflictCols = strings.Split(oc, ",")
+	}
+
+	// valida tudo antes de gravar: um insert em lote é uma transação
+	staged := append([]Row(nil), s.tables[table]...)
+	var written []Row
+	for _, in := range incoming {
+		row := normalize(in)
+		if upsert {
+			if existing := findConflict(staged, row, conflictCols); existing != nil {
+				merged := cloneRow(existing)
+				for k, v := range row {
+					merged[k] = v
+				}
+				if dup := s.violates(table, staged, merged, existing); dup != "" {
+					writeError(w, http.StatusConflict, "23505", "duplicate key value violates unique constraint "+dup)
+					return
+				}
+				for i := range staged {
+					if sameRow(staged[i], existing) {
+						staged[i] = merged
+					}
+				}
+				written = append(written, merged)
+				continue
+			}
+		}
+		row = s.withDefaults(row)
+		if dup := s.violates(table, staged, row, nil); dup != "" {
+			writeError(w, http.StatusConflict, "23505", "duplicate key value violates unique constraint "+dup)
+			return
+		}
+		staged = append(staged, row)
+		written = append(written, row)
+	}
+	s.tables[table] = staged
+	writeJSON(w, http.StatusCreated, written)
+}
+
+func (s *Server) serveUpdate(w http.ResponseWriter, table string, filters []filter, body []byte) {
+	var set Row
+	if err := json.Unmarshal(body, &set); err != nil {
+		writeError(w, http.StatusBadRequest, "PGRST102", "corpo inválido")
+		return
+	}
+	set = normalize(set)
+
+	var updated []Row
+	for i, row := range s.tables[table] {
+		if !matchAll(row, filters) {
+			continue
+		}
+		merged := cloneRow(row)
+		for k, v := range set {
+			merged[k] = v
+		}
+		if dup := s.violates(table, s.tables[table], merged, row); dup != "" {
+			writeError(w, http.StatusConflict, "23505", "duplicate key value violates unique constraint "+dup)
+			return
+		}
+		s.tables[table][i] = merged
+		updated = append(updated, merged)
+	}
+	writeJSON(w, http.StatusOK, nonNil(updated))
+}
+
+func (s *Server) serveDelete(w http.ResponseWriter, table string, filters []filter) {
+	var kept, deleted []Row
+	for _, row := range s.tables[table] {
+		if matchAll(row, filters) {
+			deleted = append(deleted, row)
+		} else {
+			kept = append(kept, row)
+		}
+	}
+	s.tables[table] = kept
+	writeJSON(w, http.StatusOK, nonNil(deleted))
+}
+
+// violates devolve o nome da restrição única violada por row (ignorando self, a própria linha numa alteração).
+func (s *Server) violates(table string, rows []Row, row, self Row) string {
+	for _, cols := range s.unique[table] {
+		for _, other := range rows {
+			if self != nil && sameRow(other, self) {
+				continue
+			}
+			if equalOn(other, row, cols) {
+				return table + "_" + strings.Join(cols, "_") + "_key"
+			}
+		}
+	}
+	return ""
+}
+
+func (s *Server) withDefaults(row Row) Row {
+	s.counter++
+	if _, ok := row["id"]; !ok {
+		row["id"] = newUUID()
+	}
+	if _, ok := row["created_at"]; !ok {
+		// created_at estritamente crescente, para que "order=created_at" seja determinístico
+		row["created_at"] = time.Now().UTC().Add(time.Duration(s.counter) * time.Microsecond).Format(time.RFC3339Nano)
+	}
+	return row
+}
+
+func findConflict(rows []Row, row Row, cols []string) Row {
+	if len(cols) == 0 {
+		cols = []string{"id"}
+	}
+	for _, other := range rows {
+		if equalOn(other, row, cols) {
+			return other
+		}
+	}
+	return nil
+}
+
+func equalOn(a, b Row, cols []string) bool {
+	for _, c := range cols {
+		av, aok := a[c]
+		bv, bok := b[c]
+		if !aok || !bok || av == nil || bv == nil || valueString(av) != valueString(bv) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameRow(a, b Row) bool {
+	return a["id"] != nil && valueString(a["id"]) == valueString(b["id"])
+}
+
+type filter struct {
+	column string
+	not    bool
+	op     string
+	value  string
+}
+
+var reserved = map[string]bool{"select": true, "order": true, "limit": true, "offset": true, "on_conflict": true, "columns": true}
+
+func parseFilters(q map[string][]string) ([]filter, error) {
+	var out []filter
+	for col, vs := range q {
+		if reserved[col] {
+			continue
+		}
+		for _, v := range vs {
+			f := filter{column: col}
+			if rest, ok := strings.CutPrefix(v, "not."); ok {
+				f.not = true
+				v = rest
+			}
+			op, value, ok := strings.Cut(v, ".")
+			if !ok {
+				return nil, fmt.Errorf("filtro inválido %s=%s", col, v)
+			}
+			f.op, f.value = op, value
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+func matchAll(row Row, filters []filter) bool {
+	for _, f := range filters {
+		if f.match(row[f.column]) == f.not {
+			return false
+		}
+	}
+	return true
+}
+
+func (f filter) match(v any) bool {
+	switch f.op {
+	case "is":
+		switch f.value {
+		case "null":
+			return v == nil
+		case "true", "false":
+			b, ok := v.(bool)
+			return ok && strconv.FormatBool(b) == f.value
+		}
+		return false
+	case "in":
+		if v == nil {
+			return false
+		}
+		for _, item := range strings.Split(strings.Trim(f.value, "()"), ",") {
+			if strings.Trim(item, `"`) == valueString(v) {
+				return true
+			}
+		}
+		return false
+	}
+	if v == nil {
+		return false
+	}
+	switch f.op {
+	case "eq":
+		return valueString(v) == f.value
+	case "neq":
+		return valueString(v) != f.value
+	}
+	c := compare(v, f.value)
+	switch f.op {
+	case "lt":
+		return c < 0
+	case "lte":
+		return c <= 0
+	case "gt":
+		return c > 0
+	case "gte":
+		return c >= 0
+	}
+	return false
+}
+
+// compare compara o valor da coluna com o literal do filtro: como número, como data ou como texto.
+func compare(v any, literal string) int {
+	s := valueString(v)
+	if a, err := strconv.ParseFloat(s, 64); err == nil {
+		if b, err := strconv.ParseFloat(literal, 64); err == nil {
+			return cmpOrdered(a, b)
+		}
+	}
+	if a, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		if b, err := time.Parse(time.RFC3339Nano, literal); err == nil {
+			return a.Compare(b)
+		}
+	}
+	return strings.Compare(s, literal)
+}
+
+func cmpOrdered(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func sortRows(rows []Row, order string) {
+	type key struct {
+		column string
+		desc   bool
+	}
+	var keys []key
+	for _, part := range strings.Split(order, ",") {
+		fields := strings.Split(part, ".")
+		keys = append(keys, key{column: fields[0], desc: len(fields) > 1 && fields[1] == "desc"})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, k := range keys {
+			a, b := rows[i][k.column], rows[j][k.column]
+			if a == nil || b == nil {
+				if (a == nil) == (b == nil) {
+					continue
+				}
+				return b == nil // nulls last
+			}
+			c := compare(a, valueString(b))
+			if c == 0 {
+				continue
+			}
+			if k.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+func project(rows []Row, sel string) []Row {
+	out := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		if sel == "" || sel == "*" {
+			out = append(out, cloneRow(row))
+			continue
+		}
+		p := Row{}
+		for _, col := range strings.Split(sel, ",") {
+			col = strings.TrimSpace(col)
+			if v, ok := row[col]; ok {
+				p[col] = v
+			} else {
+				p[col] = nil
+			}
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// normalize passa a linha por JSON, para que valores gravados diretamente (Insert/Update do teste)
+// tenham os mesmos tipos dos recebidos pela API (números float64, datas em texto).
+func normalize(row Row) Row {
+	b, _ := json.Marshal(row)
+	var out Row
+	_ = json.Unmarshal(b, &out)
+	if out == nil {
+		out = Row{}
+	}
+	return out
+}
+
+func cloneRow(row Row) Row {
+	out := make(Row, len(row))
+	for k, v := range row {
+		out[k] = v
+	}
+	return out
+}
+
+func valueString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	case nil:
+		return ""
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func first(vs []string) string {
+	if len(vs) == 0 {
+		return ""
+	}
+	return vs[0]
+}
+
+func nonNil(rows []Row) []Row {
+	if rows == nil {
+		return []Row{}
+	}
+	return rows
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]any{"code": code, "message": message, "details": nil, "hint": nil})
+}
@@ -0,0 +1,174 @@
+package supabase
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/supabase-community/postgrest-go"
+)
+
+// FocusWebhookEvent é uma linha de focus_webhook_events: uma entrega de webhook da Focus.
+// DeliveryHash identifica a entrega (a Focus pode reenviar a mesma notificação).
+type FocusWebhookEvent struct {
+	DeliveryHash string          `json:"delivery_hash"`
+	CompanyID    string          `json:"company_id,omitempty"`
+	DocumentType string          `json:"document_type"`
+	Ref          string          `json:"ref,omitempty"`
+	Status       string          `json:"status,omitempty"`
+	Payload      json.RawMessage `json:"payload"`
+	ReceivedAt   time.Time       `json:"received_at"`
+}
+
+// InsertFocusWebhookEvent grava a entrega. duplicate=true quando o delivery_hash já existe
+// (a entrega já foi recebida antes e não deve ser reprocessada).
+func InsertFocusWebhookEvent(ev FocusWebhookEvent) (duplicate bool, err error) {
+	c := GetClient()
+	if c == nil {
+		return false, fmt.Errorf("supabase client não inicializado")
+	}
+	if ev.DeliveryHash == "" {
+		return false, fmt.Errorf("delivery_hash é obrigatório")
+	}
+
+	_, _, err = c.
+		From("focus_webhook_events").
+		Insert(ev, false, "", "", "").
+		Execute()
+	if err != nil && strings.HasPrefix(err.Error(), "(23505)") { // unique_violation
+		return true, nil
+	}
+	return false, err
+}
+
+// MarkFocusWebhookEventProcessed registra o fim do processamento (processError vazio = sucesso).
+// companyID (se informado) grava a empresa resolvida pela ref, usada por HasNewerProcessedFocusWebhookEvent.
+func MarkFocusWebhookEventProcessed(deliveryHash, companyID, processError string) error {
+	c := GetClient()
+	if c == nil {
+		return fmt.Errorf("supabase client não inicializado")
+	}
+
+	update := map[string]any{
+		"processed_at": time.Now().UTC(),
+	}
+	if companyID != "" {
+		update["company_id"] = companyID
+	}
+	if processError != "" {
+		update["error"] = processError
+	}
+
+	_, _, err := c.
+		From("focus_webhook_events").
+		Update(update, "", "").
+		Eq("delivery_hash", deliveryHash).
+		Execute()
+
+	return err
+}
+
+// ListPendingFocusWebhookEvents lista entregas gravadas e ainda não processadas (ex: o serviço reiniciou com a
+// entrega na fila), recebidas antes de receivedBefore, em ordem de chegada.
+func ListPendingFocusWebhookEvents(receivedBefore time.Time, limit int) ([]FocusWebhookEvent, error) {
+	c := GetClient()
+	if c == nil {
+		return nil, fmt.Errorf("supabase client não inicializado")
+	}
+
+	data, _, err := c.
+		From("focus_webhook_events").
+		Select("delivery_hash,company_id,document_type,ref,status,payload,received_at", "", false).
+		Is("processed_at", "null").
+		Lt("received_at", receivedBefore.UTC().Format(time.RFC3339Nano)).
+		Order("received_at", &postgrest.OrderOpts{Ascending: true}).
+		Limit(limit, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		FocusWebhookEvent
+		CompanyID *string `json:"company_id"`
+		Ref       *string `json:"ref"`
+		Status    *string `json:"status"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar focus_webhook_events: %w", err)
+	}
+	out := make([]FocusWebhookEvent, 0, len(rows))
+	for _, r := range rows {
+		ev := r.FocusWebhookEvent
+		if r.CompanyID != nil {
+			ev.CompanyID = *r.CompanyID
+		}
+		if r.Ref != nil {
+			ev.Ref = *r.Ref
+		}
+		if r.Status != nil {
+			ev.Status = *r.Status
+		}
+		out = append(out, ev)
+	}
+	return out, nil
+}
+
+// HasNewerProcessedFocusWebhookEvent indica se uma entrega mais recente do mesmo documento já foi aplicada
+// (processada sem erro): reprocessar ev voltaria o status do documento.
+func HasNewerProcessedFocusWebhookEvent(ev FocusWebhookEvent) (bool, error) {
+	c := GetClient()
+	if c == nil {
+		return false, fmt.Errorf("supabase client não inicializado")
+	}
+
+	data, _, err := c.
+		From("focus_webhook_events").
+		Select("delivery_hash", "", false).
+		Eq("company_id", ev.CompanyID).
+		Eq("document_type", ev.DocumentType).
+		Eq("ref", ev.Ref).
+		Gt("received_at", ev.ReceivedAt.UTC().Format(time.RFC3339Nano)).
+		Not("processed_at", "is", "null").
+		Is("error", "null").
+		Limit(1, "").
+		Execute()
+	if err != nil {
+		return false, err
+	}
+
+	var rows []struct {
+		DeliveryHash string `json:"delivery_hash"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return false, fmt.Errorf("erro ao decodificar focus_webhook_events: %w", err)
+	}
+	return len(rows) > 0, nil
+}
+
+// FindFocusDocumentsByRef busca documentos pelo tipo e ref, sem empresa.
+// Usado pelos webhooks que chegam sem company_id (ref só é única por empresa, então pode haver mais de um).
+func FindFocusDocumentsByRef(documentType, ref string) ([]FocusDocument, error) {
+	c := GetClient()
+	if c == nil {
+		return nil, fmt.Errorf("supabase client não inicializado")
+	}
+
+	data, _, err := c.
+		From("focus_documents").
+		Select("company_id,document_type,ref,environment,status", "", false).
+		Eq("document_type", documentType).
+		Eq("ref", ref).
+		Limit(2, "").
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []FocusDocument
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar focus_documents: %w", err)
+	}
+	return rows, nil
+}